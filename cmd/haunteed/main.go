@@ -3,16 +3,15 @@ package main
 import (
 	"os"
 
-	tea "github.com/charmbracelet/bubbletea"
-	"github.com/vinser/haunteed/internal/app"
+	"github.com/vinser/haunteed/internal/cli"
+	"github.com/vinser/haunteed/internal/flags"
 )
 
 var version = "dev"
 
 func main() {
-	p := tea.NewProgram(app.New(version), tea.WithAltScreen())
-	if _, err := p.Run(); err != nil {
-		println("Error:", err)
+	if err := cli.Run(version, flags.Parse(os.Args[1:])); err != nil {
+		println("Error:", err.Error())
 		os.Exit(1)
 	}
 }
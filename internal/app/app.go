@@ -1,28 +1,59 @@
 package app
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"maps"
+	"math/rand"
 	"os"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/vinser/haunteed/internal/ambilite"
+	"github.com/vinser/haunteed/internal/card"
+	"github.com/vinser/haunteed/internal/cosmetic"
 	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/eventlog"
 	"github.com/vinser/haunteed/internal/flags"
 	"github.com/vinser/haunteed/internal/floor"
 	"github.com/vinser/haunteed/internal/geoip"
+	"github.com/vinser/haunteed/internal/metrics"
 	"github.com/vinser/haunteed/internal/model/about"
+	"github.com/vinser/haunteed/internal/model/album"
+	"github.com/vinser/haunteed/internal/model/bestiary"
 	"github.com/vinser/haunteed/internal/model/bosskey"
+	"github.com/vinser/haunteed/internal/model/challenges"
+	"github.com/vinser/haunteed/internal/model/fame"
+	"github.com/vinser/haunteed/internal/model/gallery"
+	"github.com/vinser/haunteed/internal/model/generate"
+	"github.com/vinser/haunteed/internal/model/issue"
+	"github.com/vinser/haunteed/internal/model/latency"
 	"github.com/vinser/haunteed/internal/model/next"
 	"github.com/vinser/haunteed/internal/model/over"
 	"github.com/vinser/haunteed/internal/model/play"
+	"github.com/vinser/haunteed/internal/model/practice"
 	"github.com/vinser/haunteed/internal/model/quit"
 	"github.com/vinser/haunteed/internal/model/respawn"
 	"github.com/vinser/haunteed/internal/model/setup"
 	"github.com/vinser/haunteed/internal/model/splash"
+	"github.com/vinser/haunteed/internal/model/vault"
+	"github.com/vinser/haunteed/internal/model/versus"
+	"github.com/vinser/haunteed/internal/mutator"
+	"github.com/vinser/haunteed/internal/mux"
+	"github.com/vinser/haunteed/internal/observer"
+	"github.com/vinser/haunteed/internal/power"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/report"
 	"github.com/vinser/haunteed/internal/score"
+	"github.com/vinser/haunteed/internal/screenshot"
 	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/speech"
 	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
+	"github.com/vinser/haunteed/internal/telemetry"
+	"github.com/vinser/haunteed/internal/update"
 	"github.com/vinser/maze"
 )
 
@@ -32,7 +63,18 @@ const (
 	statusStartSplash status = iota
 	statusDoSettings
 	statusAbout
+	statusPractice
+	statusVersus
+	statusGallery
+	statusAlbum
+	statusBestiary
+	statusChallenges
+	statusIssue
+	statusLatency
+	statusVault
+	statusHallOfFame
 	statusGameplay
+	statusGenerating
 	statusFloorIntro
 	statusRespawning
 	statusGameOver
@@ -44,33 +86,75 @@ type Model struct {
 	state           *state.State
 	soundManager    *sound.Manager
 	floorCache      map[int]*floor.Floor
-	floorVisibility map[int]bool // Persists visibility state for "Crazy" mode across floors
+	floorVisibility map[int]bool             // Persists visibility state for "Crazy" mode across floors
+	checkpoints     map[int]dweller.Position // Last touched checkpoint by floor, the haunteed respawns there
+	guides          map[int]int              // Guided path uses left by floor, a new play model doesn't refill them
 	haunteed        *dweller.Haunteed
 	floor           *floor.Floor
 	score           *score.Score
+	practice        bool          // practice run with endless lives and no score recording
+	sandbox         *play.Sandbox // training dummies of a sandbox practice run, nil otherwise
+	versus          bool          // hot-seat versus round, see versusBoard
+	versusGhost     dweller.GhostType
+	versusBoard     *versus.Scoreboard                       // versus results of the session
+	bought          map[next.Item]int                        // floor intro shop purchases of the run, prices grow with them
+	photographed    map[dweller.GhostType]bool               // ghost types photographed in the run, see evidenceBonus
+	eaten           map[string]int                           // ghosts eaten in the run by name, for the share card
+	ghostStats      map[dweller.GhostType]dweller.GhostStats // ghost stats of the current floor from lost lives, see takeGhostStats
+	deepest         int                                      // deepest floor reached in the run
+	challenge       *challenges.Challenge                    // challenge being played, nil otherwise
+	challengeState  *state.State                             // copy of the state with the challenge mode and seed
+	practiceState   *state.State                             // copy of the state a practice run plays with, the regular seeds stay untouched
+	challengeStart  time.Time
+	challengeResult string           // result of the last challenge for the challenge list
+	dev             bool             // developer tools enabled with --dev
+	vision          style.Vision     // simulated color vision of the frames, see View
+	events          *eventlog.Log    // event log panel of the play screen, kept across floors
+	observer        *observer.Server // external tools watching the game, see SetObserver
+	hostMetrics     metrics.Provider // host metrics of the power failure events
+	hostEvents      metrics.Events   // power failure events of the current floor
 	// models
 	splash         splash.Model
 	setup          setup.Model
 	about          about.Model
+	practiceMenu   practice.Model
+	versusMenu     versus.Model
+	gallery        gallery.Model
+	album          album.Model
+	bestiary       bestiary.Model
+	challengeMenu  challenges.Model
+	issue          issue.Model
+	issueFrom      status // screen the issue report was opened from and returns to
+	latency        latency.Model
+	vault          vault.Model
+	fame           fame.Model
+	fameFrom       status    // idle screen the hall of fame covers and returns to
+	idleSince      time.Time // last key or mouse press, see openFame
 	play           play.Model
 	next           next.Model
 	respawn        respawn.Model
+	generate       generate.Model
+	pendingMove    tea.Msg // floor transition waiting for its floor to be generated
 	over           over.Model
 	quit           quit.Model
 	bosskey        bosskey.Model
 	bosskeyVisible bool
+	title          string           // terminal title last set, see windowTitle
+	telemetry      *telemetry.Stats // nil unless the player opted in
+	runStart       time.Time        // start of the current run for telemetry
 	// terminal size cache
 	termWidth  int
 	termHeight int
 }
 
-func New(version string) Model {
+// New returns the app model for the play command with the global flags applied.
+func New(version string, fl *flags.Flags) Model {
 	// Configure global settings first to ensure consistent behavior.
 	geoip.SetCacheTTL(0) // Ensure fresh location data for new sessions.
 
 	soundMgr, soundInitFailed := sound.Initialize()
 
-	state := getState(version)
+	state, dev := LoadState(version, fl)
 	if soundInitFailed {
 		state.Mute = true
 	}
@@ -80,6 +164,12 @@ func New(version string) Model {
 		soundMgr.Unmute()
 	}
 
+	applyLooks(state)
+	setUpdateNotice(state)
+	soundMgr.SetLoops(!power.Saving(state.SaverBelow))
+	soundMgr.SetOffset(state.AudioOffset())
+	applyBell(state)
+
 	splash := setSplash(state)
 	floorCache := make(map[int]*floor.Floor)
 	initialFloor := getFloor(0, state, floorCache, nil, nil)
@@ -90,35 +180,104 @@ func New(version string) Model {
 		score.SetHigh(highScores[0].Score)
 		score.SetNick(highScores[0].Nick)
 	}
-	return Model{
+	applyHandicap(state, haunteed, score)
+	m := Model{
 		status:          statusStartSplash,
 		state:           state,
 		soundManager:    soundMgr,
 		floorCache:      floorCache,
 		floorVisibility: make(map[int]bool),
+		checkpoints:     make(map[int]dweller.Position),
+		guides:          make(map[int]int),
+		dev:             dev,
 		haunteed:        haunteed,
 		floor:           initialFloor,
 		score:           score,
 		splash:          splash,
 		bosskey:         bosskey.New(soundMgr),
+		versusBoard:     &versus.Scoreboard{},
+		bought:          make(map[next.Item]int),
+		photographed:    make(map[dweller.GhostType]bool),
+		eaten:           make(map[string]int),
+		events:          eventlog.New(),
+		hostMetrics:     metrics.Host{},
+		telemetry:       startTelemetry(state),
+		runStart:        time.Now(),
+		idleSince:       time.Now(),
+	}
+	m.events.Tee(startSpeech(state).Say)
+	if (fl != nil && fl.NoSplash) || state.SkipSplash() {
+		m.status = statusGameplay
+		m.resetPlayModel()
+	}
+	return m
+}
+
+// startTelemetry begins a telemetry session if the player opted in.
+func startTelemetry(st *state.State) *telemetry.Stats {
+	if !st.Telemetry {
+		return nil
+	}
+	stats := telemetry.Load()
+	stats.Begin()
+	stats.Save()
+	return stats
+}
+
+// startSpeech returns the speaker of game events if the player set a speech command, nil otherwise.
+// A command that can't be found keeps quiet, "haunteed doctor" tells why.
+func startSpeech(st *state.State) *speech.Speaker {
+	if st.Speech == "" {
+		return nil
+	}
+	sp, err := speech.New(st.Speech)
+	if err != nil {
+		return nil
 	}
+	return sp
+}
+
+// endTelemetry marks a clean exit before quitting.
+func (m *Model) endTelemetry() {
+	m.telemetry.End()
+	m.telemetry.Save()
 }
 
-func getState(appVersion string) *state.State {
+// LoadState loads the saved state and applies the global flags to it.
+// It returns true if developer tools are enabled.
+func LoadState(appVersion string, fl *flags.Flags) (*state.State, bool) {
+	if fl != nil && fl.NoNetwork {
+		geoip.SetOffline(true)
+		update.SetOffline(true)
+	}
 	st := state.Load(appVersion)
-	if fl, ok := flags.Parse(); ok {
+	dev := false
+	if fl != nil {
+		dev = fl.Dev
 		if fl.Version {
 			log.Printf("Haunteed version: %s\n", appVersion)
 			os.Exit(0)
 		}
+		if fl.TelemetryExport {
+			if err := telemetry.Load().Export(os.Stdout); err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		}
 		if fl.Reset {
 			state.Reset()
-			return state.New(appVersion)
+			st = state.New(appVersion)
+			st.Streamer = fl.Streamer
+			return st, dev
 		}
 
 		if fl.Mute {
 			st.Mute = true
 		}
+		if fl.PlaintextState {
+			st.Plaintext = true
+		}
+		st.Streamer = fl.Streamer
 		if fl.Mode != "" {
 			st.GameMode = fl.Mode
 		}
@@ -129,39 +288,224 @@ func getState(appVersion string) *state.State {
 			st.SpriteSize = fl.Sprite
 		}
 	}
-	return st
+	return st, dev
+}
+
+// countSplashSkip keeps the number of splash screens skipped in a row, see state.SkipSplash.
+func (m *Model) countSplashSkip(skipped bool) {
+	if skipped {
+		m.state.SplashSkips++
+	} else {
+		m.state.SplashSkips = 0
+	}
+	m.state.Save()
+}
+
+// updateSaver checks the battery and switches the energy saver: fewer play frames and no music loops.
+// It runs on every floor, so the saver follows the charge and the charger during a run.
+func (m *Model) updateSaver() {
+	saver := power.Saving(m.state.SaverBelow)
+	m.soundManager.SetLoops(!saver)
+	m.play.SetSaver(saver)
+}
+
+// updateHostEvents samples the host metrics on every floor if the player opted in to the power failure events.
+// Versus rounds and challenges keep their rules.
+func (m *Model) updateHostEvents() {
+	m.hostEvents = metrics.Events{}
+	if m.state.HostEvents && !m.versus && m.challenge == nil && m.hostMetrics != nil {
+		m.hostEvents = metrics.Read(m.hostMetrics)
+	}
+	m.play.SetHostEvents(m.hostEvents)
+}
+
+// updateSpectral allows spectral photography in the pause menu of practice and sandbox runs.
+// Every run that records its score keeps the pause idle, the photos are taken while the game stands still.
+func (m *Model) updateSpectral() {
+	m.play.SetSpectral(m.practice)
+}
+
+// uiStyles returns the page and menu styles of the UI scale, banner titles and high contrast for the large one.
+func uiStyles(st *state.State) style.UI {
+	return style.NewUI(st.UIScale == state.UIScaleLarge)
+}
+
+// applyHandicap gives the haunteed of a new run the extra lives the player took
+// and scales its final score down for all the handicaps, see score.Multiplier.
+func applyHandicap(st *state.State, h *dweller.Haunteed, sc *score.Score) {
+	for range st.ExtraLives {
+		h.AddLife()
+	}
+	sc.SetPercent(score.Multiplier(st.SpeedBonus, st.ExtraLives, st.LongFright))
+}
+
+// updateHandicap slows the ghosts down and keeps them frightened longer if the player took these handicaps.
+// Versus rounds and challenges keep their rules.
+func (m *Model) updateHandicap() {
+	if !m.versus && m.challenge == nil {
+		m.play.SetHandicap(m.state.SpeedBonus, m.state.LongFright)
+	}
+}
+
+// applyLooks colors the haunteed with the looks worn, see the vault.
+func applyLooks(st *state.State) {
+	style.SetLooks(cosmetic.Color(st, cosmetic.KindSkin, ""), cosmetic.Color(st, cosmetic.KindSplash, ""))
+}
+
+// applyBell rings terminal bell patterns for key sounds if the player wants them.
+func applyBell(st *state.State) {
+	if st.Bell {
+		sound.SetBell(os.Stdout)
+	} else {
+		sound.SetBell(nil)
+	}
 }
 
 func setSplash(st *state.State) splash.Model {
 	width, height := getDefaultWidthHeight()
-	model := splash.New(st, width, height)
+	model := splash.New(st, uiStyles(st), width, height)
+	model.SetStill(power.Saving(st.SaverBelow))
 	return model
 }
 
 func setSetup(st *state.State, sm *sound.Manager) setup.Model {
 	width, height := getDefaultWidthHeight()
-	model := setup.New(st.GameMode, st.NightOption, st.SpriteSize, st.Mute, width, height, sm)
+	model := setup.New(st, uiStyles(st), width, height, sm)
 	return model
 }
 
 func setAbout(st *state.State) about.Model {
 	width, height := getDefaultWidthHeight()
-	model := about.New(st, width, height)
+	model := about.New(st, uiStyles(st), width, height)
+	return model
+}
+
+func setPractice(st *state.State, sm *sound.Manager) practice.Model {
+	width, height := getDefaultWidthHeight()
+	model := practice.New(st, uiStyles(st), width, height, sm)
+	return model
+}
+
+func setVersus(st *state.State, board *versus.Scoreboard, ghost dweller.GhostType, sm *sound.Manager) versus.Model {
+	width, height := getDefaultWidthHeight()
+	model := versus.New(board, ghost, uiStyles(st), width, height, sm)
+	return model
+}
+
+func setGallery(st *state.State, sm *sound.Manager) gallery.Model {
+	width, height := getDefaultWidthHeight()
+	model := gallery.New(st, uiStyles(st), width, height, sm)
+	return model
+}
+
+func setAlbum(st *state.State, sm *sound.Manager) album.Model {
+	width, height := getDefaultWidthHeight()
+	model := album.New(uiStyles(st), width, height, sm)
+	return model
+}
+
+func setVault(st *state.State, sm *sound.Manager) vault.Model {
+	width, height := getDefaultWidthHeight()
+	model := vault.New(st, uiStyles(st), width, height, sm)
+	return model
+}
+
+func setFame(st *state.State) fame.Model {
+	width, height := getDefaultWidthHeight()
+	model := fame.New(st, uiStyles(st), width, height)
+	return model
+}
+
+func setLatency(st *state.State, sm *sound.Manager) latency.Model {
+	width, height := getDefaultWidthHeight()
+	model := latency.New(st, uiStyles(st), width, height, sm)
+	return model
+}
+
+// openIssue opens the issue report with the settings, the floor and the latest events attached.
+func (m *Model) openIssue() tea.Cmd {
+	width, height := getDefaultWidthHeight()
+	r := report.Report{
+		Version:   m.state.Version,
+		Settings:  m.state,
+		Floor:     m.floor.Index,
+		Seed:      m.floor.Seed,
+		Width:     m.termWidth,
+		Height:    m.termHeight,
+		Events:    m.events.All(),
+		CreatedAt: time.Now(),
+	}
+	m.issueFrom = m.status
+	m.status = statusIssue
+	m.issue = issue.New(r, uiStyles(m.state), width, height, m.soundManager)
+	m.issue.SetSize(m.termWidth, m.termHeight)
+	return m.issue.Init()
+}
+
+// typing returns true if a text field takes the keys, so they don't quit, mute or call the boss.
+// Any key wakes the hall of fame, so it takes them all too.
+func (m Model) typing() bool {
+	switch m.status {
+	case statusHallOfFame:
+		return true
+	case statusIssue:
+		return m.issue.Typing()
+	case statusGameOver:
+		return m.over.Typing()
+	}
+	return false
+}
+
+func setBestiary(st *state.State, sm *sound.Manager) bestiary.Model {
+	width, height := getDefaultWidthHeight()
+	model := bestiary.New(st, uiStyles(st), width, height, sm)
+	return model
+}
+
+func setChallenges(st *state.State, last, result string, sm *sound.Manager) challenges.Model {
+	width, height := getDefaultWidthHeight()
+	model := challenges.New(st, last, result, uiStyles(st), width, height, sm)
 	return model
 }
 
 func setRespawn(st *state.State, lives int) respawn.Model {
 	width, height := getDefaultWidthHeight()
-	model := respawn.New(lives, width, height)
+	model := respawn.New(lives, uiStyles(st), width, height)
 	return model
 }
 
-func setNext(st *state.State, index int) next.Model {
+func setNext(st *state.State, f *floor.Floor, shop *next.Shop, stats map[dweller.GhostType]dweller.GhostStats) next.Model {
 	width, height := getDefaultWidthHeight()
-	model := next.New(index, width, height)
+	model := next.New(f.Index, f.Mutators, shop, stats, uiStyles(st), width, height)
 	return model
 }
 
+// keepGhostStats adds the ghost stats of the play to the floor stats before the play is replaced.
+func (m *Model) keepGhostStats() {
+	if m.ghostStats == nil {
+		m.ghostStats = make(map[dweller.GhostType]dweller.GhostStats)
+	}
+	for t, st := range m.play.GhostStats() {
+		m.ghostStats[t] = m.ghostStats[t].Add(st)
+	}
+}
+
+// takeGhostStats returns the ghost stats of the floor being left, the next floor starts from scratch.
+func (m *Model) takeGhostStats() map[dweller.GhostType]dweller.GhostStats {
+	m.keepGhostStats()
+	stats := m.ghostStats
+	m.ghostStats = nil
+	return stats
+}
+
+// shop returns the floor intro shop of the run, there is nothing to buy in practice.
+func (m *Model) shop() *next.Shop {
+	if m.practice {
+		return nil
+	}
+	return &next.Shop{Points: m.score.Get(), Bought: m.bought, Fuses: m.state.GameMode == state.ModeCrazy}
+}
+
 func (m *Model) setGameOver(score int) over.Model {
 	highScores := m.state.GetHighScores()
 
@@ -170,6 +514,7 @@ func (m *Model) setGameOver(score int) over.Model {
 		highScore = highScores[0].Score
 	}
 
+	m.soundManager.SetTempo(1)
 	if score > highScore {
 		m.soundManager.PlayWithCallback(sound.HIGH_SCORE, func() {
 			m.soundManager.PlayLoop(sound.INTRO)
@@ -180,15 +525,50 @@ func (m *Model) setGameOver(score int) over.Model {
 		})
 	}
 	width, height := getDefaultWidthHeight()
-	model := over.New(m.state, score, highScores, width, height)
+	model := over.New(m.state, score, highScores, uiStyles(m.state), width, height)
+	if banked, ok := m.score.Insured(); ok {
+		model.SetInsured(banked)
+	}
+	if percent := m.score.Percent(); percent < 100 {
+		model.SetHandicap(percent)
+	}
 	return model
 }
 
+// screenshot keeps the last frame of a run that beat the high score or ended deeper than any run before.
+// It is called before the run is added to the history.
+func (m *Model) screenshot(score int) {
+	reason := ""
+	if scores := m.state.GetHighScores(); len(scores) > 0 && score > scores[0].Score {
+		reason = screenshot.HighScore
+	} else if deepest, ok := m.state.DeepestRun(m.state.ModeName()); ok && m.floor.Index > deepest {
+		reason = screenshot.DeepestFloor
+	}
+	if reason != "" {
+		screenshot.Save(m.play.View(), reason, time.Now())
+	}
+}
+
+// run sums up the finished run for the history and the share card.
+func (m *Model) run(score int) state.Run {
+	_, insured := m.score.Insured()
+	return state.Run{
+		Mode:    m.state.ModeName(),
+		Floor:   max(m.deepest, m.floor.Index),
+		Score:   score,
+		Eaten:   m.eaten,
+		Deaths:  m.haunteed.Deaths(),
+		Photos:  len(m.photographed),
+		Insured: insured,
+		EndedAt: time.Now(),
+	}
+}
+
 func (m *Model) setQuit() quit.Model {
 	m.soundManager.StopAll()
 	m.soundManager.Play(sound.QUIT)
 	width, height := getDefaultWidthHeight()
-	model := quit.New(width, height)
+	model := quit.New(uiStyles(m.state), width, height)
 	return model
 }
 
@@ -217,29 +597,128 @@ func getWidthHeight(st *state.State) (int, int) {
 
 const minFloorVisibilityRadius = 4
 
+// Floor returns the floor generated from its seed, without constraints from adjacent floors.
+func Floor(index int, st *state.State) *floor.Floor {
+	return getFloor(index, st, make(map[int]*floor.Floor), nil, nil)
+}
+
+// FloorAbove returns the floor the stairs of f lead up to, generated from its seed and starting where f ends.
+func FloorAbove(f *floor.Floor, st *state.State) *floor.Floor {
+	end := f.Maze.End()
+	return getFloor(f.Index+1, st, make(map[int]*floor.Floor), &end, nil)
+}
+
 func getFloor(index int, st *state.State, cache map[int]*floor.Floor, startPoint, endPoint *maze.Point) *floor.Floor {
-	if f, ok := cache[index]; ok {
-		// A floor is regenerated if the required connection points (upstairs or downstairs) do not match the cached version.
-		// This ensures that returning to a floor from a different direction connects correctly.
-		startMismatch := startPoint != nil && f.Maze.Start() != *startPoint
-		endMismatch := endPoint != nil && f.Maze.End() != *endPoint
+	if f, ok := cachedFloor(index, st, cache, startPoint, endPoint); ok {
+		return f
+	}
+	f := floorBuild(index, st, startPoint, endPoint)(context.Background(), nil)
+	storeFloor(f, st, cache)
+	return f
+}
 
-		if !startMismatch && !endMismatch {
-			return f // Cached version is compatible, return it.
-		}
+// cachedFloor returns the cached floor if it is compatible with the required connection points.
+func cachedFloor(index int, st *state.State, cache map[int]*floor.Floor, startPoint, endPoint *maze.Point) (*floor.Floor, bool) {
+	f, ok := cache[index]
+	if !ok {
+		return nil, false
 	}
-	// Not in cache or incompatible, (re)generate the floor.
+	// A floor is regenerated if the required connection points (upstairs, downstairs or ladders) do not match the cached version.
+	// This ensures that returning to a floor from a different direction connects correctly.
+	startMismatch := startPoint != nil && f.Maze.Start() != *startPoint
+	endMismatch := endPoint != nil && f.Maze.End() != *endPoint
+	ladderMismatch := !sameLadder(f.LadderUp, getLadder(index, st), f) || !sameLadder(f.LadderDown, getLadder(index-1, st), f)
+	return f, !startMismatch && !endMismatch && !ladderMismatch
+}
+
+// floorBuild returns the generation of a floor which is safe to run in the background:
+// the floor seed is settled beforehand and the state is not touched afterwards.
+func floorBuild(index int, st *state.State, startPoint, endPoint *maze.Point) generate.Build {
 	if _, ok := st.FloorSeeds[index]; !ok {
 		st.FloorSeeds[index] = time.Now().UnixNano()
 	}
+	st.SetFloorEnds(index, state.FloorEnds{Start: statePoint(startPoint), End: statePoint(endPoint)})
+	seed := st.FloorSeeds[index]
+	ladderUp, ladderDown := getLadder(index, st), getLadder(index-1, st)
 	width, height := getMazeDimensions(st.GameMode)
-	f := floor.New(index, st.FloorSeeds[index], startPoint, endPoint, width, height, st.SpriteSize, st.GameMode, st.NightOption)
+	spriteSize, gameMode, nightOption, retro := st.SpriteSize, st.GameMode, st.NightOption, st.Retro
+	return func(ctx context.Context, progress func(floor.Progress)) *floor.Floor {
+		return floor.Generate(ctx, progress, index, seed, startPoint, endPoint, ladderUp, ladderDown, width, height, spriteSize, gameMode, nightOption, retro)
+	}
+}
+
+// statePoint returns the maze point as kept in the state, nil stays nil.
+func statePoint(p *maze.Point) *state.Point {
+	if p == nil {
+		return nil
+	}
+	return &state.Point{X: p.X, Y: p.Y}
+}
+
+// floorEnds returns the connection points the floor was last carved around, see state.FloorEnds.
+func floorEnds(index int, st *state.State) (startPoint, endPoint *maze.Point) {
+	ends := st.FloorEnds[index]
+	if ends.Start != nil {
+		startPoint = &maze.Point{X: ends.Start.X, Y: ends.Start.Y}
+	}
+	if ends.End != nil {
+		endPoint = &maze.Point{X: ends.End.X, Y: ends.End.Y}
+	}
+	return startPoint, endPoint
+}
+
+// storeFloor applies the settings to a generated floor and caches it.
+func storeFloor(f *floor.Floor, st *state.State, cache map[int]*floor.Floor) {
+	if st.Weekly {
+		f.SetMutators(mutator.Weekly(time.Now()))
+	}
 
 	// Set floor visibility radius
 	setFloorVisibility(f, st)
-	cache[index] = f
+	cache[f.Index] = f
+}
 
-	return f
+const (
+	// Ladders connect floors deeper than this in either direction
+	minLadderDepth = 2
+	// Chance that a pair of adjacent deep floors is connected with a ladder
+	ladderChance = 0.5
+)
+
+// getLadder returns the ladder point connecting floor index with floor index+1 or nil if there is none.
+// It is derived from the first floor seed, so both floors agree on it regardless of which one is generated first.
+func getLadder(index int, st *state.State) *maze.Point {
+	if index < minLadderDepth && index+1 > -minLadderDepth {
+		return nil
+	}
+	rng := rand.New(rand.NewSource(st.FloorSeeds[0] ^ int64(index)*0x9E3779B9))
+	if rng.Float64() >= ladderChance {
+		return nil
+	}
+	width, height := getMazeDimensions(st.GameMode)
+	// Odd coordinates outside the den are always carved by the maze generator
+	for {
+		p := maze.Point{X: 1 + 2*rng.Intn((width-1)/2), Y: 1 + 2*rng.Intn((height-1)/2)}
+		if abs(p.X-width/2) > floor.DenWidth/2+1 || abs(p.Y-height/2) > floor.DenHeight/2+1 {
+			return &p
+		}
+	}
+}
+
+// sameLadder checks if the cached floor ladder matches the expected one.
+// A ladder dropped by the floor because it collided with stairs is considered matching.
+func sameLadder(got, want *maze.Point, f *floor.Floor) bool {
+	if got == nil || want == nil {
+		return got == want || (want != nil && (*want == f.Maze.Start() || *want == f.Maze.End()))
+	}
+	return *got == *want
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
 }
 
 // Set floor visibility radius
@@ -268,6 +747,20 @@ func setFloorVisibility(f *floor.Floor, st *state.State) {
 	}
 }
 
+// fallDisorientPeriod is how long controls stay mirrored after falling through a hole in crazy mode.
+// The floor intro screen takes part of it.
+const fallDisorientPeriod = 10 * time.Second
+
+// evidenceBonus is paid once a run has photos of all ghost types.
+const evidenceBonus = 3000
+
+// landingPos maps the coordinates of a hole onto the floor below.
+// Both floors share dimensions, so the same cell is used or the nearest open one if it is a wall there.
+func landingPos(f *floor.Floor, x, y int) dweller.Position {
+	px, py := f.NearestOpen(x, y)
+	return dweller.Position{X: px, Y: py}
+}
+
 func getMazeDimensions(gameMode string) (width, height int) {
 	switch gameMode {
 	case state.ModeEasy:
@@ -282,11 +775,228 @@ func getMazeDimensions(gameMode string) (width, height int) {
 }
 
 func (m Model) Init() tea.Cmd {
+	if m.status == statusGameplay {
+		// No splash, no intro music
+		return tea.Batch(m.play.Init(), m.over.Init(), mouseCmd(m.state), checkUpdateCmd(m.state), observeCmd(m.observer), fameCheckCmd(m.state))
+	}
 	m.soundManager.PlayLoop(sound.INTRO)
-	return tea.Batch(m.splash.Init(), m.over.Init(), tea.DisableMouse)
+	return tea.Batch(m.splash.Init(), m.over.Init(), mouseCmd(m.state), checkUpdateCmd(m.state), observeCmd(m.observer), fameCheckCmd(m.state))
 }
 
+// SetObserver streams the game events to the observer server and takes its commands, call it before Init.
+func (m *Model) SetObserver(s *observer.Server) {
+	m.observer = s
+	m.events.Tee(s.Emit)
+}
+
+// observerMsg is a command sent by an observer.
+type observerMsg observer.Command
+
+// observeCmd waits for the next observer command.
+func observeCmd(s *observer.Server) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		c, ok := <-s.Commands()
+		if !ok {
+			return nil
+		}
+		return observerMsg(c)
+	}
+}
+
+// observe runs an observer command and waits for the next one.
+func (m *Model) observe(c observer.Command) tea.Cmd {
+	var cmd tea.Cmd
+	switch c.Name {
+	case "pause", "resume":
+		if m.status != statusGameplay {
+			c.Reply(map[string]any{"ok": false, "error": "not playing"})
+			break
+		}
+		cmd = m.play.Pause(c.Name == "pause")
+		c.Reply(map[string]any{"ok": true, "paused": m.play.Paused()})
+	case "screenshot":
+		if path, err := screenshot.Save(m.View(), "observer", time.Now()); err != nil {
+			c.Reply(map[string]any{"ok": false, "error": err.Error()})
+		} else {
+			c.Reply(map[string]any{"ok": true, "path": path})
+		}
+	case "vision":
+		if len(c.Args) == 0 {
+			c.Reply(map[string]any{"ok": true, "vision": m.vision.String()})
+			break
+		}
+		v, ok := style.ParseVision(strings.Join(c.Args, " "))
+		if !ok {
+			c.Reply(map[string]any{"ok": false, "error": "unknown vision, use " + strings.Join(style.VisionNames, ", ")})
+			break
+		}
+		m.vision = v
+		c.Reply(map[string]any{"ok": true, "vision": m.vision.String()})
+	case "state":
+		c.Reply(map[string]any{
+			"ok":      true,
+			"playing": m.status == statusGameplay,
+			"paused":  m.status == statusGameplay && m.play.Paused(),
+			"mode":    m.state.ModeName(),
+			"floor":   m.floor.Index,
+			"score":   m.score.Get(),
+			"lives":   m.haunteed.Lives(),
+		})
+	default:
+		c.Reply(map[string]any{"ok": false, "error": "unknown command, use " + strings.Join(observer.Commands, ", ")})
+	}
+	return tea.Batch(cmd, observeCmd(m.observer))
+}
+
+// mouseCmd turns mouse tracking on or off as the player wants it.
+func mouseCmd(st *state.State) tea.Cmd {
+	if st.Mouse {
+		return tea.EnableMouseCellMotion
+	}
+	return tea.DisableMouse
+}
+
+// paneSizeMsg is sent when tmux told the size of the pane, Width and Height are the terminal size it is checked against.
+type paneSizeMsg struct {
+	Width, Height         int
+	PaneWidth, PaneHeight int
+}
+
+// checkPaneCmd compares the reported terminal size with the tmux pane, they disagree when another client
+// of a smaller terminal is attached to the session.
+func checkPaneCmd(width, height int) tea.Cmd {
+	if mux.Detect() != mux.Tmux {
+		return nil
+	}
+	return func() tea.Msg {
+		w, h, ok := mux.PaneSize()
+		if !ok {
+			return nil
+		}
+		return paneSizeMsg{Width: width, Height: height, PaneWidth: w, PaneHeight: h}
+	}
+}
+
+// fameCheckPeriod is how often idle screens are checked for the hall of fame.
+const fameCheckPeriod = 15 * time.Second
+
+// fameCheckMsg is sent every fameCheckPeriod if the hall of fame is on.
+type fameCheckMsg struct{}
+
+func fameCheckCmd(st *state.State) tea.Cmd {
+	if st.FameIdleMin <= 0 {
+		return nil
+	}
+	return tea.Tick(fameCheckPeriod, func(t time.Time) tea.Msg {
+		return fameCheckMsg{}
+	})
+}
+
+// openFame shows the hall of fame over the splash or the game over screen once nobody has touched
+// the keys for state.FameIdleMin minutes, a screensaver for kiosk installs.
+func (m *Model) openFame() tea.Cmd {
+	if m.bosskeyVisible || (m.status != statusStartSplash && m.status != statusGameOver) ||
+		time.Since(m.idleSince) < time.Duration(m.state.FameIdleMin)*time.Minute {
+		return nil
+	}
+	m.fameFrom = m.status
+	m.status = statusHallOfFame
+	m.fame = setFame(m.state)
+	m.fame.SetSize(m.termWidth, m.termHeight)
+	return tea.Batch(m.fame.Init(), minimapCmd(m.state))
+}
+
+// minimapCmd generates the deepest floor reached in the game mode for the hall of fame, carved around
+// the same connection points as when it was played. Floors whose seed is gone are left out.
+func minimapCmd(st *state.State) tea.Cmd {
+	deepest, ok := st.DeepestRun(st.ModeName())
+	if !ok {
+		return nil
+	}
+	if _, ok := st.FloorSeeds[deepest]; !ok {
+		return nil
+	}
+	startPoint, endPoint := floorEnds(deepest, st)
+	build := floorBuild(deepest, st, startPoint, endPoint)
+	return func() tea.Msg {
+		return fame.MinimapMsg{Floor: deepest, Lines: build(context.Background(), nil).Minimap()}
+	}
+}
+
+// updateCheckedMsg is sent when the daily update check is done.
+type updateCheckedMsg struct {
+	Latest string
+}
+
+// checkUpdateCmd queries the latest release in the background if the player opted in and a day has passed.
+func checkUpdateCmd(st *state.State) tea.Cmd {
+	if !st.UpdateCheck || time.Since(st.CheckedAt) < update.CheckPeriod {
+		return nil
+	}
+	return func() tea.Msg {
+		release, err := update.Latest()
+		if err != nil {
+			return nil // Try again next time
+		}
+		return updateCheckedMsg{Latest: release.Version}
+	}
+}
+
+// setUpdateNotice shows the footer notice when the save file couldn't be opened or was restored from a backup,
+// or a newer release is known.
+func setUpdateNotice(st *state.State) {
+	if st.Locked {
+		render.SetNotice("The save file is locked with a passphrase, nothing is saved this session")
+	} else if st.Recovered != "" {
+		render.SetNotice(fmt.Sprintf("The save file was damaged, progress is restored from %s", st.Recovered))
+	} else if st.UpdateCheck && update.Newer(st.Latest, st.Version) {
+		render.SetNotice(fmt.Sprintf("Haunteed %s is out — run \"%s\"", st.Latest, update.Command()))
+	} else {
+		render.SetNotice("")
+	}
+}
+
+// Update updates the screen models and keeps the terminal title in step with them.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	model, cmd := m.update(msg)
+	updated, ok := model.(Model)
+	if !ok {
+		return model, cmd
+	}
+	if title := updated.windowTitle(); title != updated.title {
+		updated.title = title
+		cmd = tea.Batch(cmd, tea.SetWindowTitle(title)) // The program output passes it on in a multiplexer, see mux.Output
+	}
+	return updated, cmd
+}
+
+// windowTitle returns the terminal title for the current screen, empty if the title is off.
+func (m Model) windowTitle() string {
+	switch {
+	case !m.state.TermTitle:
+		return ""
+	case m.bosskeyVisible:
+		return bosskey.Title
+	}
+	switch m.status {
+	case statusGameplay, statusGenerating, statusFloorIntro, statusRespawning:
+		return fmt.Sprintf("haunteed — Floor %d — %s pts", m.floor.Index, score.Format(m.score.Get()))
+	}
+	return "haunteed"
+}
+
+func (m Model) update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case observerMsg:
+		return m, m.observe(observer.Command(msg))
+	case fameCheckMsg:
+		return m, tea.Batch(m.openFame(), fameCheckCmd(m.state))
+	case tea.KeyMsg, tea.MouseMsg:
+		m.idleSince = time.Now()
+	}
 	if m.bosskeyVisible {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
@@ -300,8 +1010,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, m.setup.Init()
 				case statusAbout:
 					return m, m.about.Init()
+				case statusPractice:
+					return m, m.practiceMenu.Init()
+				case statusVersus:
+					return m, m.versusMenu.Init()
+				case statusGallery:
+					return m, m.gallery.Init()
+				case statusAlbum:
+					return m, m.album.Init()
+				case statusBestiary:
+					return m, m.bestiary.Init()
+				case statusChallenges:
+					return m, m.challengeMenu.Init()
+				case statusIssue:
+					return m, m.issue.Init()
+				case statusLatency:
+					return m, m.latency.Init()
+				case statusVault:
+					return m, m.vault.Init()
+				case statusHallOfFame:
+					return m, m.fame.Init()
 				case statusGameplay:
 					return m, m.play.Init()
+				case statusGenerating:
+					return m, m.generate.Start()
 				case statusFloorIntro:
 					return m, m.next.Init()
 				case statusRespawning:
@@ -327,9 +1059,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	var cmds []tea.Cmd
 
 	switch msg := msg.(type) {
+	case updateCheckedMsg:
+		m.state.CheckedAt = time.Now()
+		m.state.Latest = msg.Latest
+		m.state.Save()
+		setUpdateNotice(m.state)
+		return m, nil
+	case paneSizeMsg:
+		if msg.Width != m.termWidth || msg.Height != m.termHeight {
+			return m, nil // Resized again since
+		}
+		if msg.PaneWidth != msg.Width || msg.PaneHeight != msg.Height {
+			render.SetNotice(fmt.Sprintf("The tmux pane is %dx%d but the terminal reports %dx%d, try \"tmux resize-window -A\"",
+				msg.PaneWidth, msg.PaneHeight, msg.Width, msg.Height))
+		} else {
+			setUpdateNotice(m.state)
+		}
+		return m, nil
 	case tea.KeyMsg:
+		if m.typing() {
+			break // Letters go to the text field
+		}
 		switch msg.String() {
 		case "b", "B":
+			if m.status == statusGenerating {
+				m.generate.Stop() // started over when the boss is gone
+			}
 			m.bosskeyVisible = true
 			m.bosskey.SetSize(m.termWidth, m.termHeight)
 			m.soundManager.StopAll()
@@ -339,6 +1094,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.quit = m.setQuit()
 			m.quit.SetSize(m.termWidth, m.termHeight)
 			return m, m.quit.Init()
+		case "f7": // cycle simulated color vision
+			if m.dev {
+				m.vision = m.vision.Next()
+				return m, nil
+			}
 		case "m", "M": // mute/unmute
 			m.state.Mute = !m.state.Mute
 			if m.state.Mute {
@@ -361,6 +1121,26 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.setup.SetSize(msg.Width, msg.Height)
 		case statusAbout:
 			m.about.SetSize(msg.Width, msg.Height)
+		case statusPractice:
+			m.practiceMenu.SetSize(msg.Width, msg.Height)
+		case statusVersus:
+			m.versusMenu.SetSize(msg.Width, msg.Height)
+		case statusGallery:
+			m.gallery.SetSize(msg.Width, msg.Height)
+		case statusAlbum:
+			m.album.SetSize(msg.Width, msg.Height)
+		case statusBestiary:
+			m.bestiary.SetSize(msg.Width, msg.Height)
+		case statusChallenges:
+			m.challengeMenu.SetSize(msg.Width, msg.Height)
+		case statusIssue:
+			m.issue.SetSize(msg.Width, msg.Height)
+		case statusLatency:
+			m.latency.SetSize(msg.Width, msg.Height)
+		case statusVault:
+			m.vault.SetSize(msg.Width, msg.Height)
+		case statusHallOfFame:
+			m.fame.SetSize(msg.Width, msg.Height)
 		case statusGameplay:
 			// Create a custom window size message for the play model
 			playWindowSizeMsg := play.WindowSizeMsg{
@@ -369,6 +1149,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 			m.play, cmd = m.play.Update(playWindowSizeMsg)
 			cmds = append(cmds, cmd)
+		case statusGenerating:
+			m.generate.SetSize(msg.Width, msg.Height)
+			m.play, cmd = m.play.Update(play.WindowSizeMsg{Width: msg.Width, Height: msg.Height})
+			cmds = append(cmds, cmd)
 		case statusFloorIntro:
 			m.next.SetSize(msg.Width, msg.Height)
 		case statusRespawning:
@@ -379,7 +1163,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.quit.SetSize(msg.Width, msg.Height)
 		}
 		// Force a full repaint by returning no cached content and clearing the screen
-		cmds = append(cmds, tea.ClearScreen)
+		cmds = append(cmds, tea.ClearScreen, checkPaneCmd(msg.Width, msg.Height))
 		return m, tea.Batch(cmds...)
 	}
 
@@ -393,6 +1177,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.soundManager.StopListed(sound.INTRO)
 		case splash.TimedoutMsg:
 			m.status = statusGameplay
+			m.score.Add(m.splash.Bonus())
+			m.countSplashSkip(msg.Skipped)
 			m.resetPlayModel()
 			m.soundManager.StopListed(sound.INTRO)
 			cmd = m.play.Init()
@@ -406,6 +1192,41 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.status = statusAbout
 			m.about = setAbout(m.state)
 			m.about.SetSize(m.termWidth, m.termHeight)
+		case setup.ViewPracticeMsg:
+			m.status = statusPractice
+			m.practiceMenu = setPractice(m.state, m.soundManager)
+			m.practiceMenu.SetSize(m.termWidth, m.termHeight)
+		case setup.ViewVersusMsg:
+			m.status = statusVersus
+			m.versusMenu = setVersus(m.state, m.versusBoard, m.versusGhost, m.soundManager)
+			m.versusMenu.SetSize(m.termWidth, m.termHeight)
+		case setup.ViewGalleryMsg:
+			m.status = statusGallery
+			m.gallery = setGallery(m.state, m.soundManager)
+			m.gallery.SetSize(m.termWidth, m.termHeight)
+		case setup.ViewAlbumMsg:
+			m.status = statusAlbum
+			m.album = setAlbum(m.state, m.soundManager)
+			m.album.SetSize(m.termWidth, m.termHeight)
+		case setup.ViewIssueMsg:
+			cmd = m.openIssue()
+		case setup.ViewVaultMsg:
+			m.status = statusVault
+			m.vault = setVault(m.state, m.soundManager)
+			m.vault.SetSize(m.termWidth, m.termHeight)
+		case setup.ViewLatencyMsg:
+			m.status = statusLatency
+			m.latency = setLatency(m.state, m.soundManager)
+			m.latency.SetSize(m.termWidth, m.termHeight)
+			cmd = m.latency.Init()
+		case setup.ViewBestiaryMsg:
+			m.status = statusBestiary
+			m.bestiary = setBestiary(m.state, m.soundManager)
+			m.bestiary.SetSize(m.termWidth, m.termHeight)
+		case setup.ViewChallengesMsg:
+			m.status = statusChallenges
+			m.challengeMenu = setChallenges(m.state, "", "", m.soundManager)
+			m.challengeMenu.SetSize(m.termWidth, m.termHeight)
 		case setup.SaveSettingsMsg:
 			m.status = statusGameplay
 			if msg.Reset {
@@ -416,6 +1237,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state.NightOption = msg.CrazyNight
 				m.state.SpriteSize = msg.SpriteSize
 				m.state.Mute = msg.Mute
+				m.state.Bell = msg.Bell
+				m.state.TurnBased = msg.TurnBased
+				m.state.Weekly = msg.Weekly
+				m.state.Retro = msg.Retro
+				m.state.SpeedBonus = msg.SpeedBonus
+				m.state.ExtraLives = msg.ExtraLives
+				m.state.LongFright = msg.LongFright
+				m.state.RepeatMs = msg.RepeatMs
+				m.state.DebounceMs = msg.DebounceMs
+				m.state.StickySteps = msg.Sticky
+				m.state.Initials = msg.Initials
+				m.state.UIScale = msg.UIScale
+				m.state.Mouse = msg.Mouse
+				m.state.SaverBelow = msg.SaverBelow
+				m.state.Splash = msg.Splash
+				if msg.Splash == state.SplashAlways {
+					m.state.SplashSkips = 0
+				}
+				m.state.Telemetry = msg.Telemetry
+				m.state.UpdateCheck = msg.Update
+				m.state.TermTitle = msg.TermTitle
+			}
+			setUpdateNotice(m.state)
+			if m.state.Telemetry != (m.telemetry != nil) {
+				m.endTelemetry()
+				m.telemetry = startTelemetry(m.state)
 			}
 			if err := m.state.Save(); err != nil {
 				log.Fatal(err)
@@ -425,8 +1272,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			} else {
 				m.soundManager.Unmute()
 			}
+			m.soundManager.SetOffset(m.state.AudioOffset())
+			applyBell(m.state)
 			m.resetForNewGame()
-			cmd = m.play.Init()
+			cmd = tea.Batch(m.play.Init(), mouseCmd(m.state))
 		case setup.DiscardSettingsMsg:
 			m.status = statusGameplay
 			m.resetPlayModel()
@@ -445,59 +1294,315 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.about, cmd = m.about.Update(msg)
 		}
 		cmds = append(cmds, cmd)
+	case statusPractice:
+		switch msg := msg.(type) {
+		case practice.StartPracticeMsg:
+			m.status = statusGameplay
+			m.startPractice(msg.Floor, msg.Seed)
+			cmd = m.play.Init()
+		case practice.StartSandboxMsg:
+			m.status = statusGameplay
+			m.startSandbox(msg.Sandbox, msg.SpeedPercent)
+			cmd = m.play.Init()
+		case practice.ClosePracticeMsg:
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		default:
+			m.practiceMenu, cmd = m.practiceMenu.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusVersus:
+		switch msg := msg.(type) {
+		case versus.StartVersusMsg:
+			m.status = statusGameplay
+			m.versusGhost = msg.Ghost
+			m.startVersus()
+			cmd = m.play.Init()
+		case versus.CloseVersusMsg:
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		default:
+			m.versusMenu, cmd = m.versusMenu.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusGallery:
+		switch msg := msg.(type) {
+		case gallery.CloseGalleryMsg:
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		default:
+			m.gallery, cmd = m.gallery.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusAlbum:
+		switch msg := msg.(type) {
+		case album.CloseAlbumMsg:
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		default:
+			m.album, cmd = m.album.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusBestiary:
+		switch msg := msg.(type) {
+		case bestiary.CloseBestiaryMsg:
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		default:
+			m.bestiary, cmd = m.bestiary.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusChallenges:
+		switch msg := msg.(type) {
+		case challenges.StartChallengeMsg:
+			m.status = statusGameplay
+			m.startChallenge(msg.Challenge)
+			cmd = m.play.Init()
+		case challenges.CloseChallengesMsg:
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		default:
+			m.challengeMenu, cmd = m.challengeMenu.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusIssue:
+		switch msg := msg.(type) {
+		case issue.CloseIssueMsg:
+			m.status = m.issueFrom
+			if m.status == statusGameplay {
+				cmd = m.play.Init() // The ghost ticker stopped while the report was open
+			} else {
+				m.setup = setSetup(m.state, m.soundManager)
+				m.setup.SetSize(m.termWidth, m.termHeight)
+			}
+		default:
+			m.issue, cmd = m.issue.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusVault:
+		switch msg := msg.(type) {
+		case vault.CloseVaultMsg:
+			if err := m.state.Save(); err != nil {
+				log.Fatal(err)
+			}
+			applyLooks(m.state)
+			m.haunteed.SetHaunteedSprites(m.state.SpriteSize)
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		default:
+			m.vault, cmd = m.vault.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusHallOfFame:
+		switch msg := msg.(type) {
+		case fame.WakeMsg:
+			m.status = m.fameFrom
+			if m.status == statusGameOver {
+				cmd = m.over.Init()
+			} else {
+				cmd = m.splash.Init()
+			}
+		default:
+			m.fame, cmd = m.fame.Update(msg)
+		}
+		cmds = append(cmds, cmd)
+	case statusLatency:
+		switch msg := msg.(type) {
+		case latency.SaveLatencyMsg:
+			m.state.AudioOffsetMs = msg.OffsetMs
+			if err := m.state.Save(); err != nil {
+				log.Fatal(err)
+			}
+			m.soundManager.SetOffset(m.state.AudioOffset())
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		case latency.CloseLatencyMsg:
+			m.soundManager.SetOffset(m.state.AudioOffset())
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+		default:
+			m.latency, cmd = m.latency.Update(msg)
+		}
+		cmds = append(cmds, cmd)
 	case statusGameplay:
+		if msg, ok := msg.(tea.KeyMsg); ok && m.practice && msg.String() == "esc" {
+			m.soundManager.StopAll()
+			m.practice = false
+			m.practiceState = nil
+			m.sandbox = nil
+			m.resetForNewGame()
+			m.status = statusDoSettings
+			m.setup = setSetup(m.state, m.soundManager)
+			m.setup.SetSize(m.termWidth, m.termHeight)
+			return m, nil
+		}
+		if msg, ok := msg.(tea.KeyMsg); ok && m.versus && msg.String() == "esc" {
+			m.soundManager.StopAll()
+			m.endVersus()
+			return m, nil
+		}
+		if m.challenge != nil && m.challengeOver(msg) {
+			return m, nil
+		}
+		if cmd := m.generateFloor(msg); cmd != nil {
+			return m, cmd
+		}
 		switch msg := msg.(type) {
+		case play.VersusOverMsg:
+			m.versusBoard.Record(msg.Ghost, msg.Captured, msg.Survived)
+			m.endVersus()
 		case play.NextFloorMsg:
+			stats := m.takeGhostStats()
 			m.soundManager.Play(sound.TRANSITION_UP)
 			m.status = statusFloorIntro
 			nextFloorIndex := m.floor.Index + 1
 			prevFloorEndPoint := m.floor.Maze.End()
-			m.floor = getFloor(nextFloorIndex, m.state, m.floorCache, &prevFloorEndPoint, nil)
+			m.floor = getFloor(nextFloorIndex, m.playState(), m.floorCache, &prevFloorEndPoint, nil)
+			m.deepest = max(m.deepest, m.floor.Index)
 			startPoint := m.floor.Maze.Start()
 			m.haunteed.SetPos(dweller.Position{X: startPoint.X, Y: startPoint.Y})
 			m.haunteed.SetHome(dweller.Position{X: startPoint.X, Y: startPoint.Y})
 			m.haunteed.SetHaunteedSprites(m.state.SpriteSize)
-			m.next = setNext(m.state, nextFloorIndex)
+			m.next = setNext(m.state, m.floor, m.shop(), stats)
 			m.next.SetSize(m.termWidth, m.termHeight)
 		case play.PrevFloorMsg:
+			stats := m.takeGhostStats()
 			m.soundManager.Play(sound.TRANSITION_DOWN)
 			m.status = statusFloorIntro
 			prevFloorIndex := m.floor.Index - 1
 			currentFloorStartPoint := m.floor.Maze.Start()
 			// The new floor's end must connect to the current floor's start.
-			m.floor = getFloor(prevFloorIndex, m.state, m.floorCache, nil, &currentFloorStartPoint)
+			m.floor = getFloor(prevFloorIndex, m.playState(), m.floorCache, nil, &currentFloorStartPoint)
 			endPoint := m.floor.Maze.End()
 			m.haunteed.SetPos(dweller.Position{X: endPoint.X, Y: endPoint.Y})
 			startPoint := m.floor.Maze.Start()
 			m.haunteed.SetHome(dweller.Position{X: startPoint.X, Y: startPoint.Y})
 			m.haunteed.SetHaunteedSprites(m.state.SpriteSize)
-			m.next = setNext(m.state, prevFloorIndex)
+			m.next = setNext(m.state, m.floor, m.shop(), stats)
+			m.next.SetSize(m.termWidth, m.termHeight)
+		case play.ClimbMsg:
+			stats := m.takeGhostStats()
+			currentFloorStartPoint := m.floor.Maze.Start()
+			currentFloorEndPoint := m.floor.Maze.End()
+			if msg.Floor > m.floor.Index {
+				m.soundManager.Play(sound.TRANSITION_UP)
+				m.floor = getFloor(msg.Floor, m.playState(), m.floorCache, &currentFloorEndPoint, nil)
+				m.deepest = max(m.deepest, m.floor.Index)
+			} else {
+				m.soundManager.Play(sound.TRANSITION_DOWN)
+				m.floor = getFloor(msg.Floor, m.playState(), m.floorCache, nil, &currentFloorStartPoint)
+			}
+			m.status = statusFloorIntro
+			m.haunteed.SetPos(landingPos(m.floor, msg.X, msg.Y))
+			startPoint := m.floor.Maze.Start()
+			m.haunteed.SetHome(dweller.Position{X: startPoint.X, Y: startPoint.Y})
+			m.haunteed.SetHaunteedSprites(m.state.SpriteSize)
+			m.next = setNext(m.state, m.floor, m.shop(), stats)
+			m.next.SetSize(m.termWidth, m.termHeight)
+		case play.FallMsg:
+			stats := m.takeGhostStats()
+			m.soundManager.Play(sound.TRANSITION_DOWN)
+			m.status = statusFloorIntro
+			currentFloorStartPoint := m.floor.Maze.Start()
+			m.floor = getFloor(msg.Floor, m.playState(), m.floorCache, nil, &currentFloorStartPoint)
+			m.haunteed.SetPos(landingPos(m.floor, msg.X, msg.Y))
+			startPoint := m.floor.Maze.Start()
+			m.haunteed.SetHome(dweller.Position{X: startPoint.X, Y: startPoint.Y})
+			m.haunteed.SetHaunteedSprites(m.state.SpriteSize)
+			if m.state.GameMode == state.ModeCrazy {
+				m.haunteed.Disorient(fallDisorientPeriod)
+			}
+			m.next = setNext(m.state, m.floor, m.shop(), stats)
 			m.next.SetSize(m.termWidth, m.termHeight)
 		case play.RespawnMsg:
-			setFloorVisibility(m.floor, m.state)
+			m.keepGhostStats()
+			setFloorVisibility(m.floor, m.playState())
 			m.status = statusRespawning
 			m.respawn = setRespawn(m.state, msg.Lives)
 			m.respawn.SetSize(m.termWidth, m.termHeight)
 			cmd = m.respawn.Init()
 		case play.GameOverMsg:
+			m.telemetry.RecordRun(m.state.ModeName(), time.Since(m.runStart))
+			m.telemetry.Save()
 			m.status = statusGameOver
 			score := msg.Score
+			m.screenshot(score)
+			m.events.Add("game over %d pts", score)
+			run := m.run(score)
+			m.state.AddRun(run)
+			earned := cosmetic.Earned(run)
+			m.state.Ectoplasm += earned
+			if err := m.state.Save(); err != nil {
+				log.Fatal(err)
+			}
 			m.over = m.setGameOver(score)
+			m.over.SetCard(card.Text(run))
+			m.over.SetEctoplasm(earned)
 			m.over.SetSize(m.termWidth, m.termHeight)
 			cmd = m.over.Init()
+		case play.EvidenceMsg:
+			m.state.AddEvidence(msg.Evidence)
+			if err := m.state.Save(); err != nil {
+				log.Fatal(err)
+			}
+			if !m.photographed[msg.Ghost] {
+				m.photographed[msg.Ghost] = true
+				if len(m.photographed) == len(dweller.GhostNames) { // the set is complete
+					m.soundManager.Play(sound.KILL_GHOST)
+					m.score.Add(evidenceBonus)
+				}
+			}
+			return m, nil
+		case play.SightingMsg:
+			if msg.Eaten {
+				m.eaten[msg.Ghost.String()]++
+			}
+			m.state.RecordSighting(msg.Ghost.String(), msg.Eaten)
+			if err := m.state.Save(); err != nil {
+				log.Fatal(err)
+			}
+			return m, nil
 		case play.VisibilityToggledMsg:
 			m.floorVisibility[msg.FloorIndex] = msg.IsVisible
 			return m, nil // State updated, no further action needed
+		case play.ReportIssueMsg:
+			cmd = m.openIssue()
 		default:
 			m.play, cmd = m.play.Update(msg)
+			if pos, ok := m.play.ReachedCheckpoint(); ok {
+				m.checkpoints[m.floor.Index] = pos
+			}
+			m.guides[m.floor.Index] = m.play.GuidesLeft()
 		}
 		cmds = append(cmds, cmd)
 	case statusFloorIntro:
 		switch msg := msg.(type) {
 		case next.TimedoutMsg:
+			m.events.Add("entered floor %d", m.floor.Index)
 			m.resetPlayModel()
 			m.status = statusGameplay
 			cmd = m.play.Init()
+		case next.PurchaseMsg:
+			m.soundManager.Play(sound.UI_SAVE)
+			m.score.Add(-msg.Price)
+			m.bought[msg.Item]++
+			switch msg.Item {
+			case next.ExtraLife:
+				m.haunteed.AddLife()
+			case next.TrapKit:
+				m.haunteed.AddPickup(dweller.Trap)
+			case next.FuseCharge:
+				m.haunteed.AddPickup(dweller.FuseCharge)
+			}
 		default:
 			m.next, cmd = m.next.Update(msg)
 		}
@@ -506,7 +1611,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		switch msg := msg.(type) {
 		case respawn.TimedoutMsg:
 			m.resetPlayModel()
-			m.haunteed.SetPos(m.haunteed.Home())
+			m.haunteed.SetPos(m.respawnPos())
 			m.status = statusGameplay
 			cmd = m.play.Init()
 		default:
@@ -516,7 +1621,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case statusGameOver:
 		switch msg := msg.(type) {
 		case over.SaveHighScoreMsg:
-			if err := m.state.UpdateAndSave(m.floor.Index, m.score.Get(), m.floor.Seed, msg.Nick); err != nil {
+			if err := m.state.UpdateAndSave(m.floor.Index, m.score.Final(), m.floor.Seed, msg.Nick); err != nil {
 				log.Fatal(err)
 			}
 			m.over.SetHighScores(m.state.GetHighScores())
@@ -528,14 +1633,32 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case over.QuitGameMsg:
 			m.status = statusQuitting
 			m.soundManager.StopListed(sound.INTRO)
+			m.endTelemetry()
 			return m, tea.Quit
 		default:
 			m.over, cmd = m.over.Update(msg)
 		}
 		cmds = append(cmds, cmd)
+	case statusGenerating:
+		switch msg := msg.(type) {
+		case generate.ReadyMsg:
+			storeFloor(msg.Floor, m.playState(), m.floorCache)
+			m.status = statusGameplay
+			move := m.pendingMove
+			m.pendingMove = nil
+			return m.update(move) // the floor is cached now
+		case generate.CancelMsg:
+			m.status = statusGameplay
+			m.pendingMove = nil
+			cmd = m.play.Init()
+		default:
+			m.generate, cmd = m.generate.Update(msg)
+		}
+		cmds = append(cmds, cmd)
 	case statusQuitting:
 		switch msg := msg.(type) {
 		case quit.TimedoutMsg:
+			m.endTelemetry()
 			return m, tea.Quit
 		default:
 			m.quit, cmd = m.quit.Update(msg)
@@ -549,19 +1672,242 @@ func (m *Model) resetForNewGame() {
 	// Reset for a new game
 	m.floorCache = make(map[int]*floor.Floor)
 	m.floorVisibility = make(map[int]bool)
+	m.checkpoints = make(map[int]dweller.Position)
+	m.guides = make(map[int]int)
+	m.bought = make(map[next.Item]int)
+	m.photographed = make(map[dweller.GhostType]bool)
+	m.eaten = make(map[string]int)
+	m.ghostStats = nil
+	m.deepest = 0
+	m.events.Clear()
 	m.floor = getFloor(0, m.state, m.floorCache, nil, nil)
 	startPos := dweller.Position{X: m.floor.Maze.Start().X, Y: m.floor.Maze.Start().Y}
 	m.haunteed = dweller.PlaceHaunteed(m.state.SpriteSize, m.state.GameMode, startPos)
 	m.score.Reset()
+	m.runStart = time.Now()
 	if highScores := m.state.GetHighScores(); len(highScores) > 0 {
 		m.score.SetHigh(highScores[0].Score)
 		m.score.SetNick(highScores[0].Nick)
 	}
+	applyHandicap(m.state, m.haunteed, m.score)
 	m.resetPlayModel()
 }
 
+// floorTarget returns the floor a transition message leads to with the connection points it requires.
+func (m *Model) floorTarget(msg tea.Msg) (index int, startPoint, endPoint *maze.Point, ok bool) {
+	start, end := m.floor.Maze.Start(), m.floor.Maze.End()
+	switch msg := msg.(type) {
+	case play.NextFloorMsg:
+		return m.floor.Index + 1, &end, nil, true
+	case play.PrevFloorMsg:
+		return m.floor.Index - 1, nil, &start, true
+	case play.ClimbMsg:
+		if msg.Floor > m.floor.Index {
+			return msg.Floor, &end, nil, true
+		}
+		return msg.Floor, nil, &start, true
+	case play.FallMsg:
+		return msg.Floor, nil, &start, true
+	}
+	return 0, nil, nil, false
+}
+
+// generateFloor starts generating the floor a transition leads to in the background, unless it is cached.
+// The transition is replayed once the floor is ready.
+func (m *Model) generateFloor(msg tea.Msg) tea.Cmd {
+	index, startPoint, endPoint, ok := m.floorTarget(msg)
+	if !ok {
+		return nil
+	}
+	st := m.playState()
+	if _, ok := cachedFloor(index, st, m.floorCache, startPoint, endPoint); ok {
+		return nil
+	}
+	m.status = statusGenerating
+	m.pendingMove = msg
+	width, height := getDefaultWidthHeight()
+	m.generate = generate.New(index, floorBuild(index, st, startPoint, endPoint), uiStyles(st), width, height, m.soundManager)
+	m.generate.SetSize(m.termWidth, m.termHeight)
+	return m.generate.Start()
+}
+
+// respawnPos returns where the haunteed respawns on the current floor: the last touched checkpoint or home.
+func (m *Model) respawnPos() dweller.Position {
+	if pos, ok := m.checkpoints[m.floor.Index]; ok {
+		return pos
+	}
+	return m.haunteed.Home()
+}
+
+// startPractice starts a practice run on a previously reached floor, carved around the same connection points
+// as when it was played. The run uses its own copy of the state, floor cache and score, so the regular game is left intact.
+func (m *Model) startPractice(index int, seed int64) {
+	st := *m.state
+	st.FloorSeeds = maps.Clone(m.state.FloorSeeds)
+	st.FloorSeeds[index] = seed
+	st.FloorEnds = maps.Clone(m.state.FloorEnds)
+	m.practice = true
+	m.practiceState = &st
+	m.floorCache = make(map[int]*floor.Floor)
+	m.floorVisibility = make(map[int]bool)
+	m.checkpoints = make(map[int]dweller.Position)
+	m.guides = make(map[int]int)
+	startPoint, endPoint := floorEnds(index, &st)
+	m.floor = getFloor(index, &st, m.floorCache, startPoint, endPoint)
+	startPos := dweller.Position{X: m.floor.Maze.Start().X, Y: m.floor.Maze.Start().Y}
+	m.haunteed = dweller.PlaceHaunteed(st.SpriteSize, st.GameMode, startPos)
+	m.haunteed.SetImmortal(true)
+	m.score = score.NewScore()
+	m.resetPlayModel()
+}
+
+// sandboxSeed is the seed of the sandbox floor, every sandbox is the same floor
+const sandboxSeed = 1
+
+// startSandbox starts a practice run on a sandbox floor opened up for training: no crumbling walls,
+// no mutators and the ghost speed scaled by speedPercent. Like practice, the regular game is left intact.
+func (m *Model) startSandbox(s play.Sandbox, speedPercent int) {
+	st := *m.state
+	st.Weekly = false
+	st.Retro = false
+	st.FloorSeeds = map[int]int64{0: sandboxSeed}
+	st.FloorEnds = nil
+	m.practice = true
+	m.practiceState = &st
+	m.sandbox = &s
+	m.floorCache = make(map[int]*floor.Floor)
+	m.floorVisibility = make(map[int]bool)
+	m.checkpoints = make(map[int]dweller.Position)
+	m.guides = make(map[int]int)
+	m.floor = getFloor(0, &st, m.floorCache, nil, nil)
+	m.floor.SetMutators(nil)
+	m.floor.OpenUp()
+	m.floor.GhostTickInterval = m.floor.GhostTickInterval * 100 / time.Duration(speedPercent)
+	startPos := dweller.Position{X: m.floor.Maze.Start().X, Y: m.floor.Maze.Start().Y}
+	m.haunteed = dweller.PlaceHaunteed(m.state.SpriteSize, m.state.GameMode, startPos)
+	m.haunteed.SetImmortal(true)
+	m.score = score.NewScore()
+	m.resetPlayModel()
+}
+
+// startVersus starts a hot-seat versus round on the ground floor.
+// Like practice, the round uses its own floor cache and score, so the regular game is left intact.
+func (m *Model) startVersus() {
+	m.versus = true
+	m.floorCache = make(map[int]*floor.Floor)
+	m.floorVisibility = make(map[int]bool)
+	m.floor = getFloor(0, m.state, m.floorCache, nil, nil)
+	startPos := dweller.Position{X: m.floor.Maze.Start().X, Y: m.floor.Maze.Start().Y}
+	m.haunteed = dweller.PlaceHaunteed(m.state.SpriteSize, m.state.GameMode, startPos)
+	m.score = score.NewScore()
+	m.resetPlayModel()
+}
+
+// startChallenge starts a challenge on its seeded ground floor with the challenge rules.
+// The state is copied with the challenge mode and seed, so the regular game is left intact.
+func (m *Model) startChallenge(c challenges.Challenge) {
+	st := *m.state
+	st.GameMode = c.Mode
+	st.TurnBased = false
+	st.Weekly = false
+	st.Retro = false
+	st.FloorSeeds = map[int]int64{0: c.Seed}
+	st.FloorEnds = nil
+	m.challenge = &c
+	m.challengeState = &st
+	m.floorCache = make(map[int]*floor.Floor)
+	m.floorVisibility = make(map[int]bool)
+	m.checkpoints = make(map[int]dweller.Position)
+	m.guides = make(map[int]int)
+	m.floor = getFloor(0, &st, m.floorCache, nil, nil)
+	m.floor.SetMutators(c.Mutators())
+	startPos := dweller.Position{X: m.floor.Maze.Start().X, Y: m.floor.Maze.Start().Y}
+	m.haunteed = dweller.PlaceHaunteed(st.SpriteSize, st.GameMode, startPos)
+	m.score = score.NewScore()
+	m.challengeStart = time.Now()
+	m.resetPlayModel()
+}
+
+// challengeOver ends the challenge on reaching the stairs up, running out of lives, falling through or leaving.
+// Other ways off the floor are closed. It returns true if the message is handled.
+func (m *Model) challengeOver(msg tea.Msg) bool {
+	c := *m.challenge
+	jingle := sound.GAME_OVER
+	switch msg := msg.(type) {
+	case play.NextFloorMsg:
+		d := time.Since(m.challengeStart)
+		jingle = sound.HIGH_SCORE
+		m.challengeResult = fmt.Sprintf("%s cleared in %.1fs: %s", c.Name, d.Seconds(), c.Medal(d))
+		if m.state.RecordChallenge(c.ID, d) {
+			m.challengeResult += ", new best!"
+			if err := m.state.Save(); err != nil {
+				log.Fatal(err)
+			}
+		}
+	case play.GameOverMsg:
+		m.challengeResult = fmt.Sprintf("%s failed: out of lives.", c.Name)
+	case play.FallMsg:
+		m.challengeResult = fmt.Sprintf("%s failed: fell through the floor.", c.Name)
+	case tea.KeyMsg:
+		if msg.String() != "esc" {
+			return false
+		}
+		jingle = sound.UI_CANCEL
+		m.challengeResult = ""
+	case play.PrevFloorMsg, play.ClimbMsg:
+		return true
+	default:
+		return false
+	}
+	m.soundManager.StopAll()
+	m.soundManager.Play(jingle)
+	m.challenge = nil
+	m.challengeState = nil
+	m.resetForNewGame()
+	m.status = statusChallenges
+	m.challengeMenu = setChallenges(m.state, c.ID, m.challengeResult, m.soundManager)
+	m.challengeMenu.SetSize(m.termWidth, m.termHeight)
+	return true
+}
+
+// playState returns the state the floor is played with: the challenge copy in a challenge, the practice copy
+// in practice and the sandbox, the regular one otherwise.
+func (m *Model) playState() *state.State {
+	if m.challengeState != nil {
+		return m.challengeState
+	}
+	if m.practiceState != nil {
+		return m.practiceState
+	}
+	return m.state
+}
+
+// endVersus leaves the versus round for the versus screen with the updated scoreboard.
+func (m *Model) endVersus() {
+	m.versus = false
+	m.resetForNewGame()
+	m.status = statusVersus
+	m.versusMenu = setVersus(m.state, m.versusBoard, m.versusGhost, m.soundManager)
+	m.versusMenu.SetSize(m.termWidth, m.termHeight)
+}
+
 func (m *Model) resetPlayModel() {
-	m.play = play.New(m.state, m.soundManager, m.floor, m.score, m.haunteed, m.floorVisibility[m.floor.Index])
+	m.play = play.New(m.playState(), uiStyles(m.state), m.soundManager, m.floor, m.score, m.haunteed, m.floorVisibility[m.floor.Index])
+	m.play.SetDebug(m.dev)
+	if n, ok := m.guides[m.floor.Index]; ok {
+		m.play.SetGuidesLeft(n)
+	}
+	m.play.SetEvents(m.events)
+	m.updateSaver()
+	m.updateHostEvents()
+	m.updateSpectral()
+	m.updateHandicap()
+	if m.sandbox != nil {
+		m.play.SetSandbox(*m.sandbox)
+	}
+	if m.versus {
+		m.play.SetVersus(m.versusGhost, versus.RoundTime)
+	}
 	// Seed the play model with the latest terminal size so it renders correctly before any manual resize
 	if m.termWidth > 0 && m.termHeight > 0 {
 		m.play, _ = m.play.Update(play.WindowSizeMsg{Width: m.termWidth, Height: m.termHeight})
@@ -574,14 +1920,36 @@ func (m *Model) resetPlayModelForRespawn() {
 	// We keep the current haunteed instance because it tracks lives.
 	m.haunteed.SetPos(m.haunteed.Home())
 	// Create a new play model, which will re-place ghosts.
-	m.play = play.New(m.state, m.soundManager, m.floor, m.score, m.haunteed, m.floorVisibility[m.floor.Index])
+	m.play = play.New(m.playState(), uiStyles(m.state), m.soundManager, m.floor, m.score, m.haunteed, m.floorVisibility[m.floor.Index])
+	m.play.SetDebug(m.dev)
+	if n, ok := m.guides[m.floor.Index]; ok {
+		m.play.SetGuidesLeft(n)
+	}
+	m.play.SetEvents(m.events)
+	m.updateSaver()
+	m.play.SetHostEvents(m.hostEvents)
+	m.updateSpectral()
+	m.updateHandicap()
+	if m.sandbox != nil {
+		m.play.SetSandbox(*m.sandbox)
+	}
 	// Seed size immediately
 	if m.termWidth > 0 && m.termHeight > 0 {
 		m.play, _ = m.play.Update(play.WindowSizeMsg{Width: m.termWidth, Height: m.termHeight})
 	}
 }
 
+// View renders the current screen as seen with the simulated color vision, the screenshots taken
+// through the observer included.
 func (m Model) View() string {
+	frame := m.view()
+	if m.vision != style.NormalVision {
+		frame = style.Simulate(frame, m.vision) + "\n" + uiStyles(m.state).Footer.Render("Vision: "+m.vision.String())
+	}
+	return frame
+}
+
+func (m Model) view() string {
 	if m.bosskeyVisible {
 		return m.bosskey.View()
 	}
@@ -592,8 +1960,33 @@ func (m Model) View() string {
 		return m.setup.View()
 	case statusAbout:
 		return m.about.View()
+	case statusPractice:
+		return m.practiceMenu.View()
+	case statusVersus:
+		return m.versusMenu.View()
+	case statusGallery:
+		return m.gallery.View()
+	case statusAlbum:
+		return m.album.View()
+	case statusBestiary:
+		return m.bestiary.View()
+	case statusChallenges:
+		return m.challengeMenu.View()
+	case statusIssue:
+		return m.issue.View()
+	case statusLatency:
+		return m.latency.View()
+	case statusVault:
+		return m.vault.View()
+	case statusHallOfFame:
+		return m.fame.View()
 	case statusGameplay:
 		return m.play.View()
+	case statusGenerating:
+		if m.generate.Visible() {
+			return m.generate.View()
+		}
+		return m.play.View() // quick generations go unnoticed
 	case statusFloorIntro:
 		return m.next.View()
 	case statusRespawning:
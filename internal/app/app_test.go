@@ -0,0 +1,32 @@
+package app
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vinser/haunteed/internal/flags"
+	"github.com/vinser/haunteed/internal/model/respawn"
+)
+
+func TestGuidesSurviveRespawn(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	m := New("test", &flags.Flags{NoNetwork: true, NoSplash: true, Mute: true})
+	m.score.Add(1000)
+	full := m.play.GuidesLeft()
+
+	model, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("c")})
+	m = model.(Model)
+	if got := m.play.GuidesLeft(); got != full-1 {
+		t.Fatalf("GuidesLeft() after a guide = %d, want %d", got, full-1)
+	}
+
+	m.status = statusRespawning
+	model, _ = m.Update(respawn.TimedoutMsg{})
+	m = model.(Model)
+	if got := m.play.GuidesLeft(); got != full-1 {
+		t.Errorf("GuidesLeft() after a respawn = %d, want %d", got, full-1)
+	}
+}
@@ -0,0 +1,290 @@
+// Package balance plays seeded bot games headless and compares their outcome with a stored baseline,
+// so ghost AI and difficulty changes show their impact before a release.
+package balance
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/vinser/haunteed/internal/app"
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/floor"
+	"github.com/vinser/haunteed/internal/score"
+	"github.com/vinser/haunteed/internal/state"
+)
+
+const (
+	// BaselinePath is the baseline kept in the repository, relative to the module root
+	BaselinePath = "internal/balance/baseline.json"
+
+	// Games is the number of seeded bot games played per mode
+	Games = 20
+
+	maxFloors        = 10                     // A bot game ends here at the latest
+	botLives         = 3                      // As many lives as the player has
+	botStepPeriod    = 200 * time.Millisecond // The bot walks briskly, five cells a second
+	frightenedPeriod = 10 * time.Second       // Power mode period of the play screen
+	maxGhostMoves    = 2000                   // The bot gives up a floor after so many ghost moves
+)
+
+// Modes are the game modes the bot plays
+var Modes = []string{state.ModeEasy, state.ModeNoisy, state.ModeCrazy}
+
+// dotPoints are the points of a dot by game mode, as scored in daylight
+var dotPoints = map[string]int{state.ModeEasy: 5, state.ModeNoisy: 10, state.ModeCrazy: 15}
+
+// Report holds the outcome of the bot games by game mode.
+type Report map[string]Stats
+
+// Stats sums up the bot games of a game mode.
+type Stats struct {
+	Games  int     `json:"games"`
+	Floors Summary `json:"floors"` // Floors cleared before the bot ran out of lives
+	Score  Summary `json:"score"`
+}
+
+// Summary describes a distribution of game results.
+type Summary struct {
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	Min    int     `json:"min"`
+	Max    int     `json:"max"`
+}
+
+// Run plays the bot games of every mode. The seeds are fixed, so the same build always gives the same report.
+func Run() Report {
+	r := make(Report)
+	for _, mode := range Modes {
+		floors := make([]int, Games)
+		scores := make([]int, Games)
+		for i := range Games {
+			floors[i], scores[i] = Play(mode, int64(i+1)*7919)
+		}
+		r[mode] = Stats{Games: Games, Floors: summarize(floors), Score: summarize(scores)}
+	}
+	return r
+}
+
+// Play plays a bot game of the mode with floors generated from the seed.
+// It returns the number of floors cleared and the final score.
+func Play(mode string, seed int64) (int, int) {
+	st := &state.State{
+		Settings: state.Settings{
+			GameMode:    mode,
+			NightOption: state.NightNever,
+			SpriteSize:  state.SpriteDefault,
+		},
+		FloorSeeds: make(map[int]int64),
+	}
+	for i := 0; i <= maxFloors; i++ {
+		st.FloorSeeds[i] = seed + int64(i)
+	}
+	b := &bot{mode: mode, lives: botLives, score: score.NewScore(), rng: rand.New(rand.NewSource(seed))}
+	f := app.Floor(0, st)
+	floors := 0
+	for floors < maxFloors && b.clear(f) {
+		floors++
+		f = app.FloorAbove(f, st)
+	}
+	return floors, b.score.Final()
+}
+
+// bot walks the shortest way to the stairs of each floor, eating what lies on it.
+// It doesn't dodge ghosts, so the results follow the ghost AI and the floor difficulty.
+type bot struct {
+	mode  string
+	lives int
+	score *score.Score
+	rng   *rand.Rand
+}
+
+// clear takes attempts at the floor until the bot reaches the stairs or runs out of lives.
+func (b *bot) clear(f *floor.Floor) bool {
+	for b.lives > 0 {
+		if b.attempt(f) {
+			return true
+		}
+		b.lives--
+		b.score.Die()
+	}
+	return false
+}
+
+// attempt walks the bot from the floor start. It returns false if the bot is caught or can't reach the stairs.
+// Ghosts move on a simulated clock and leave the den by turns as in the puzzle variant, eaten ones come straight back.
+func (b *bot) attempt(f *floor.Floor) bool {
+	ghosts := dweller.PlaceGhosts(f.Index, state.SpriteDefault, b.mode, f.Maze.Width(), f.Maze.Height(), f.Dens, b.rng)
+	for _, g := range ghosts {
+		g.Hold()
+	}
+	gc := dweller.NewGhostController()
+	gc.SetTurnPeriod(f.GhostTickInterval)
+	releaseMoves := max(int(dweller.ReleaseDelay(1)/f.GhostTickInterval), 1)
+
+	end := dweller.Position{X: f.Maze.End().X, Y: f.Maze.End().Y}
+	pos := dweller.Position{X: f.Maze.Start().X, Y: f.Maze.Start().Y}
+	dir := dweller.No
+	var clock, nextMove, powerUntil time.Duration
+	for moves := 0; moves < maxGhostMoves; {
+		clock += botStepPeriod
+		path := f.Guide(pos.X, pos.Y, 1)
+		if len(path) == 0 {
+			return pos == end
+		}
+		if item, _ := f.ItemAt(path[0].X, path[0].Y); item == floor.CrumblingWall {
+			f.BumpWall(path[0].X, path[0].Y)
+		} else {
+			next := dweller.Position{X: path[0].X, Y: path[0].Y}
+			dir = direction(pos, next)
+			pos = next
+			switch f.EatItem(pos.X, pos.Y) {
+			case floor.Dot:
+				b.score.Add(dotPoints[b.mode])
+			case floor.PowerPellet:
+				b.score.Add(50)
+				powerUntil = clock + frightenedPeriod
+				for _, g := range ghosts {
+					g.SetState(dweller.Frightened)
+				}
+			}
+		}
+		if pos == end {
+			return true
+		}
+		if b.collide(ghosts, pos) {
+			return false
+		}
+
+		for clock >= nextMove {
+			powerMode := clock < powerUntil
+			if !powerMode {
+				b.score.ResetGhostStreak()
+				for _, g := range ghosts {
+					if g.State() == dweller.Frightened {
+						g.SetState(dweller.Chase)
+					}
+				}
+			}
+			moves++
+			for i, g := range ghosts {
+				if !g.Reinforcement() && moves >= i*releaseMoves {
+					g.SetRelease(0)
+				}
+			}
+			gc.Update(ghosts)
+			dweller.MoveGhosts(ghosts, f, powerMode, pos, dir)
+			if b.collide(ghosts, pos) {
+				return false
+			}
+			nextMove += f.GhostTickInterval
+			if powerMode {
+				nextMove += f.GhostTickInterval // Frightened ghosts are slowed down
+			}
+		}
+	}
+	return false
+}
+
+// collide eats frightened ghosts on the bot cell, it returns true if a chasing one catches the bot.
+func (b *bot) collide(ghosts []*dweller.Ghost, pos dweller.Position) bool {
+	for _, g := range ghosts {
+		if g.Pos() != pos {
+			continue
+		}
+		switch g.State() {
+		case dweller.Frightened:
+			b.score.AddGhostPoints()
+			g.SetState(dweller.Eaten)
+		case dweller.Chase:
+			return true
+		}
+	}
+	return false
+}
+
+// direction returns the direction of a step between adjacent cells.
+func direction(from, to dweller.Position) dweller.Direction {
+	switch {
+	case to.Y < from.Y:
+		return dweller.Up
+	case to.Y > from.Y:
+		return dweller.Down
+	case to.X < from.X:
+		return dweller.Left
+	case to.X > from.X:
+		return dweller.Right
+	}
+	return dweller.No
+}
+
+// summarize returns the mean, median and range of the values.
+func summarize(values []int) Summary {
+	if len(values) == 0 {
+		return Summary{}
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+	sum := 0
+	for _, v := range sorted {
+		sum += v
+	}
+	n := len(sorted)
+	median := float64(sorted[n/2])
+	if n%2 == 0 {
+		median = float64(sorted[n/2-1]+sorted[n/2]) / 2
+	}
+	return Summary{Mean: float64(sum) / float64(n), Median: median, Min: sorted[0], Max: sorted[n-1]}
+}
+
+// Load reads a report saved with Save.
+func Load(path string) (Report, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var r Report
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("balance baseline %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Save writes the report as indented JSON, e.g. to become the new baseline.
+func (r Report) Save(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Write prints the report with the changes against the baseline, modes missing from the baseline have no changes.
+func (r Report) Write(w io.Writer, baseline Report) {
+	fmt.Fprintf(w, "%-6s %22s %26s\n", "mode", "floors mean/median", "score mean/median")
+	for _, mode := range Modes {
+		s, ok := r[mode]
+		if !ok {
+			continue
+		}
+		floors := fmt.Sprintf("%.1f/%.1f", s.Floors.Mean, s.Floors.Median)
+		points := fmt.Sprintf("%.0f/%.0f", s.Score.Mean, s.Score.Median)
+		if base, ok := baseline[mode]; ok {
+			floors += fmt.Sprintf(" (%s)", delta(s.Floors.Mean-base.Floors.Mean, "%+.1f"))
+			points += fmt.Sprintf(" (%s)", delta(s.Score.Mean-base.Score.Mean, "%+.0f"))
+		}
+		fmt.Fprintf(w, "%-6s %22s %26s\n", mode, floors, points)
+	}
+}
+
+// delta formats a change of the mean, no change is shown as ±0
+func delta(d float64, format string) string {
+	if s := fmt.Sprintf(format, d); s != fmt.Sprintf(format, 0.0) && s != fmt.Sprintf(format, -0.0) {
+		return s
+	}
+	return "±0"
+}
@@ -0,0 +1,56 @@
+package balance
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestSummarize(t *testing.T) {
+	got := summarize([]int{4, 1, 3, 2})
+	want := Summary{Mean: 2.5, Median: 2.5, Min: 1, Max: 4}
+	if got != want {
+		t.Errorf("summarize = %+v, want %+v", got, want)
+	}
+	if got := summarize(nil); got != (Summary{}) {
+		t.Errorf("summarize(nil) = %+v, want zero", got)
+	}
+}
+
+func TestPlayIsReproducible(t *testing.T) {
+	floors, points := Play(state.ModeEasy, 42)
+	if floors < 0 || floors > maxFloors {
+		t.Fatalf("Play cleared %d floors, want 0 to %d", floors, maxFloors)
+	}
+	if f, p := Play(state.ModeEasy, 42); f != floors || p != points {
+		t.Errorf("Play with the same seed = %d floors %d points, want %d floors %d points", f, p, floors, points)
+	}
+}
+
+func TestSaveLoadWrite(t *testing.T) {
+	base := Report{state.ModeEasy: {Games: 2, Floors: Summary{Mean: 3, Median: 3}, Score: Summary{Mean: 500, Median: 500}}}
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	if err := base.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded[state.ModeEasy] != base[state.ModeEasy] {
+		t.Fatalf("Load = %+v, want %+v", loaded, base)
+	}
+
+	r := Report{state.ModeEasy: {Games: 2, Floors: Summary{Mean: 2.5, Median: 2}, Score: Summary{Mean: 500, Median: 450}}}
+	var b bytes.Buffer
+	r.Write(&b, loaded)
+	out := b.String()
+	for _, want := range []string{"2.5/2.0 (-0.5)", "500/450 (±0)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Write output lacks %q:\n%s", want, out)
+		}
+	}
+}
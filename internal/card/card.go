@@ -0,0 +1,96 @@
+// Package card renders a finished run as a short card to share, like the word game shares.
+package card
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/mux"
+	"github.com/vinser/haunteed/internal/score"
+	"github.com/vinser/haunteed/internal/state"
+)
+
+// ghostSquares are the ghost colors as emoji, in the order of dweller.GhostNames.
+var ghostSquares = []string{"🟥", "🟪", "🟦", "🟩"}
+
+// floorBar is the number of floors shown on the card.
+const floorBar = 10
+
+// achievement is a badge earned by a run.
+type achievement struct {
+	name   string
+	earned func(r state.Run) bool
+}
+
+var achievements = []achievement{
+	{"Deathless", func(r state.Run) bool { return r.Deaths == 0 && r.Floor > 0 }},
+	{"High climber", func(r state.Run) bool { return r.Floor >= floorBar }},
+	{"Ghostbuster", func(r state.Run) bool { return eaten(r) >= 16 }},
+	{"Full house", func(r state.Run) bool {
+		for _, name := range dweller.GhostNames {
+			if r.Eaten[name] == 0 {
+				return false
+			}
+		}
+		return true
+	}},
+	{"Paparazzo", func(r state.Run) bool { return r.Photos >= len(dweller.GhostNames) }},
+	{"Insured", func(r state.Run) bool { return r.Insured }},
+}
+
+// eaten returns the number of ghosts eaten in the run.
+func eaten(r state.Run) int {
+	n := 0
+	for _, count := range r.Eaten {
+		n += count
+	}
+	return n
+}
+
+// Achievements returns the names of the badges earned by the run.
+func Achievements(r state.Run) []string {
+	var names []string
+	for _, a := range achievements {
+		if a.earned(r) {
+			names = append(names, a.name)
+		}
+	}
+	return names
+}
+
+// Text returns the card of the run.
+func Text(r state.Run) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "HAUNTEED · %s · %s\n", r.Mode, r.EndedAt.Format("2006-01-02"))
+
+	reached := min(r.Floor, floorBar)
+	more := ""
+	if r.Floor > floorBar {
+		more = "+"
+	}
+	fmt.Fprintf(&b, "🪜 %s%s floor %d\n", strings.Repeat("🟨", reached)+strings.Repeat("⬛", floorBar-reached), more, r.Floor)
+
+	fmt.Fprintf(&b, "👻 %d", eaten(r))
+	for i, name := range dweller.GhostNames {
+		if n := r.Eaten[name]; n > 0 {
+			fmt.Fprintf(&b, " %s%d", ghostSquares[i], n)
+		}
+	}
+	b.WriteString("\n")
+
+	fmt.Fprintf(&b, "💀 %d  ⭐ %s\n", r.Deaths, score.Format(r.Score))
+	if names := Achievements(r); len(names) > 0 {
+		fmt.Fprintf(&b, "🏆 %s\n", strings.Join(names, " · "))
+	}
+	return b.String()
+}
+
+// Copy puts the text into the terminal clipboard with the OSC 52 escape sequence, passed through
+// tmux and screen. Terminals which do not support it silently ignore the sequence.
+func Copy(w io.Writer, text string) error {
+	_, err := fmt.Fprint(w, mux.Passthrough("\x1b]52;c;"+base64.StdEncoding.EncodeToString([]byte(text))+"\a"))
+	return err
+}
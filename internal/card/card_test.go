@@ -0,0 +1,68 @@
+package card
+
+import (
+	"bytes"
+	"encoding/base64"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestAchievements(t *testing.T) {
+	tests := []struct {
+		name string
+		run  state.Run
+		want []string
+	}{
+		{"ground floor", state.Run{}, nil},
+		{"deathless", state.Run{Floor: 3}, []string{"Deathless"}},
+		{"all four", state.Run{Floor: 12, Deaths: 2, Eaten: map[string]int{"Curly": 5, "Lofty": 5, "Fluffy": 5, "Virty": 1}},
+			[]string{"High climber", "Ghostbuster", "Full house"}},
+		{"photos and insurance", state.Run{Deaths: 1, Photos: 4, Insured: true}, []string{"Paparazzo", "Insured"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Achievements(tt.run); !slices.Equal(got, tt.want) {
+				t.Errorf("Achievements() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestText(t *testing.T) {
+	r := state.Run{
+		Mode:    "easy",
+		Floor:   7,
+		Score:   12450,
+		Eaten:   map[string]int{"Curly": 3, "Virty": 1},
+		Deaths:  2,
+		EndedAt: time.Date(2026, time.October, 14, 20, 0, 0, 0, time.UTC),
+	}
+	want := "HAUNTEED · easy · 2026-10-14\n" +
+		"🪜 🟨🟨🟨🟨🟨🟨🟨⬛⬛⬛ floor 7\n" +
+		"👻 4 🟥3 🟩1\n" +
+		"💀 2  ⭐ 12,450\n"
+	if got := Text(r); got != want {
+		t.Errorf("Text() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestCopy(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
+	var buf bytes.Buffer
+	if err := Copy(&buf, "boo"); err != nil {
+		t.Fatal(err)
+	}
+	seq := buf.String()
+	if !strings.HasPrefix(seq, "\x1b]52;c;") || !strings.HasSuffix(seq, "\a") {
+		t.Fatalf("Copy() = %q, want an OSC 52 sequence", seq)
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.TrimSuffix(strings.TrimPrefix(seq, "\x1b]52;c;"), "\a"))
+	if err != nil || string(data) != "boo" {
+		t.Errorf("Copy() payload = %q, %v, want boo", data, err)
+	}
+}
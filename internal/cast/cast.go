@@ -0,0 +1,149 @@
+// Package cast records terminal output as asciinema v2 cast files,
+// so runs can be replayed with asciinema or embedded on web pages with its player.
+package cast
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// header is the first line of a v2 cast file
+type header struct {
+	Version   int               `json:"version"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	Timestamp int64             `json:"timestamp"`
+	Env       map[string]string `json:"env,omitempty"`
+}
+
+// Recorder passes output through to the terminal and writes every frame to the cast file with its timing.
+// It keeps the terminal's Fd so the program still sees a TTY it can size and put in raw mode.
+type Recorder struct {
+	term    *os.File
+	file    *os.File
+	out     *bufio.Writer
+	start   time.Time
+	width   int
+	height  int
+	pending []byte // Start of a rune split by the last write, recorded with the next one
+	mu      sync.Mutex
+	err     error
+}
+
+// New creates the cast file at path and writes its header. Width and height are the terminal size.
+func New(path string, term *os.File, width, height int) (*Recorder, error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	r := &Recorder{
+		term:   term,
+		file:   file,
+		out:    bufio.NewWriter(file),
+		start:  time.Now(),
+		width:  width,
+		height: height,
+	}
+	h := header{
+		Version:   2,
+		Width:     width,
+		Height:    height,
+		Timestamp: r.start.Unix(),
+		Env:       map[string]string{"TERM": os.Getenv("TERM"), "SHELL": os.Getenv("SHELL")},
+	}
+	if err := r.writeLine(h); err != nil {
+		file.Close()
+		return nil, err
+	}
+	return r, nil
+}
+
+// Write writes p to the terminal and records it as an output event.
+// A rune split between writes is recorded whole with the next event, JSON can't hold half of it.
+// Recording errors never break the game, they are reported by Close.
+func (r *Recorder) Write(p []byte) (int, error) {
+	n, err := r.term.Write(p)
+	if n > 0 {
+		r.mu.Lock()
+		data := append(r.pending, p[:n]...)
+		cut := completeRunes(data)
+		r.pending = append([]byte(nil), data[cut:]...)
+		if r.err == nil && cut > 0 {
+			r.err = r.writeLine([]any{time.Since(r.start).Seconds(), "o", string(data[:cut])})
+		}
+		r.mu.Unlock()
+	}
+	return n, err
+}
+
+// Resize records a resize event if the terminal size changed, players follow it.
+func (r *Recorder) Resize(width, height int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if width == r.width && height == r.height {
+		return
+	}
+	r.width, r.height = width, height
+	if r.err == nil {
+		r.err = r.writeLine([]any{time.Since(r.start).Seconds(), "r", fmt.Sprintf("%dx%d", width, height)})
+	}
+}
+
+// completeRunes returns the length of b without a rune cut short at its end
+func completeRunes(b []byte) int {
+	for i := len(b) - 1; i >= 0 && i >= len(b)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(b[i]) {
+			if !utf8.FullRune(b[i:]) {
+				return i
+			}
+			break
+		}
+	}
+	return len(b)
+}
+
+// Read reads from the terminal.
+func (r *Recorder) Read(p []byte) (int, error) {
+	return r.term.Read(p)
+}
+
+// Fd returns the terminal's file descriptor.
+func (r *Recorder) Fd() uintptr {
+	return r.term.Fd()
+}
+
+// Close flushes and closes the cast file, the terminal stays open.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return r.err
+	}
+	if len(r.pending) > 0 && r.err == nil {
+		r.err = r.writeLine([]any{time.Since(r.start).Seconds(), "o", string(r.pending)})
+	}
+	if err := r.out.Flush(); err != nil && r.err == nil {
+		r.err = err
+	}
+	if err := r.file.Close(); err != nil && r.err == nil {
+		r.err = err
+	}
+	r.file = nil
+	return r.err
+}
+
+// writeLine writes v as a single JSON line
+func (r *Recorder) writeLine(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = r.out.Write(data)
+	return err
+}
@@ -0,0 +1,111 @@
+package cast
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRecorder(t *testing.T) {
+	dir := t.TempDir()
+	term, err := os.Create(filepath.Join(dir, "term"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	path := filepath.Join(dir, "out.cast")
+	r, err := New(path, term, 80, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	frames := []string{"\x1b[2Jhello", "\x1b[Hworld"}
+	for _, f := range frames {
+		if _, err := r.Write([]byte(f)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	passed, _ := os.ReadFile(term.Name())
+	if string(passed) != frames[0]+frames[1] {
+		t.Errorf("terminal got %q", passed)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Scan()
+	var h header
+	if err := json.Unmarshal(scanner.Bytes(), &h); err != nil {
+		t.Fatal(err)
+	}
+	if h.Version != 2 || h.Width != 80 || h.Height != 25 {
+		t.Errorf("header = %+v", h)
+	}
+	var last float64
+	for i := 0; scanner.Scan(); i++ {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		if len(event) != 3 || event[1] != "o" || event[2] != frames[i] {
+			t.Errorf("event %d = %v", i, event)
+		}
+		if at := event[0].(float64); at < last {
+			t.Errorf("event %d goes back in time", i)
+		} else {
+			last = at
+		}
+	}
+}
+
+func TestRecorderSplitRuneAndResize(t *testing.T) {
+	dir := t.TempDir()
+	term, err := os.Create(filepath.Join(dir, "term"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer term.Close()
+	path := filepath.Join(dir, "out.cast")
+	r, err := New(path, term, 80, 25)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wall := []byte("─") // 3 bytes
+	r.Write(append([]byte("wall "), wall[:2]...))
+	r.Write(append(wall[2:], " end"...))
+	r.Resize(80, 25) // Same size, nothing to record
+	r.Resize(100, 30)
+	if err := r.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	scanner.Scan() // header
+	want := [][2]string{{"o", "wall "}, {"o", "─ end"}, {"r", "100x30"}}
+	i := 0
+	for ; scanner.Scan(); i++ {
+		var event []any
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			t.Fatal(err)
+		}
+		if i >= len(want) || event[1] != want[i][0] || event[2] != want[i][1] {
+			t.Errorf("event %d = %v", i, event)
+		}
+	}
+	if i != len(want) {
+		t.Errorf("got %d events, want %d", i, len(want))
+	}
+}
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+
+	"github.com/vinser/haunteed/internal/balance"
+	"github.com/vinser/haunteed/internal/flags"
+)
+
+// balanceReport plays the seeded bot games and prints how they changed against the baseline.
+// With --save the report becomes the new baseline.
+func balanceReport(inv *flags.Invocation) error {
+	path, save := balance.BaselinePath, false
+	for _, a := range inv.Args {
+		if a == "--save" {
+			save = true
+		} else {
+			path = a
+		}
+	}
+	fmt.Printf("Playing %d bot games per mode...\n", balance.Games)
+	r := balance.Run()
+	baseline, err := balance.Load(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		fmt.Printf("No baseline at %s yet\n", path)
+	case err != nil:
+		return err
+	}
+	r.Write(os.Stdout, baseline)
+	if save {
+		if err := r.Save(path); err != nil {
+			return err
+		}
+		fmt.Printf("Baseline saved to %s\n", path)
+	}
+	return nil
+}
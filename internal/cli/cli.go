@@ -0,0 +1,177 @@
+// Package cli runs haunteed subcommands.
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vinser/haunteed/internal/app"
+	"github.com/vinser/haunteed/internal/card"
+	"github.com/vinser/haunteed/internal/cast"
+	"github.com/vinser/haunteed/internal/flags"
+	"github.com/vinser/haunteed/internal/mux"
+	"github.com/vinser/haunteed/internal/observer"
+	"github.com/vinser/haunteed/internal/paths"
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/update"
+	"golang.org/x/term"
+)
+
+// Run runs the subcommand of the invocation.
+func Run(version string, inv *flags.Invocation) error {
+	state.SetPassphrase(askPassphrase, rememberPassphrase)
+	switch inv.Command {
+	case "play":
+		return play(version, inv)
+	case "simulate":
+		return simulate(version, inv)
+	case "balance":
+		return balanceReport(inv)
+	case "config":
+		return config(version, inv)
+	case "export":
+		return export(version)
+	case "snapshot":
+		return snapshot(version, inv)
+	case "card":
+		return printCard(version)
+	case "doctor":
+		return doctor(version, inv)
+	case "paths":
+		return printPaths()
+	case "update":
+		return update.Run(version, os.Stdout)
+	case "completion":
+		return flags.Completion(arg(inv.Args, 0), os.Stdout)
+	case "man":
+		flags.Man(version, os.Stdout)
+		return nil
+	}
+	return fmt.Errorf("unknown command: %s", inv.Command)
+}
+
+func play(version string, inv *flags.Invocation) error {
+	var obs *observer.Server
+	if inv.Flags != nil && inv.Flags.Observer != "" {
+		var err error
+		if obs, err = observer.Listen(inv.Flags.Observer); err != nil {
+			return err
+		}
+		defer obs.Close()
+	}
+	opts := []tea.ProgramOption{tea.WithAltScreen()}
+	var out mux.Terminal = os.Stdout
+	var rec *cast.Recorder
+	if inv.Flags != nil && inv.Flags.RecordCast != "" {
+		w, h, err := term.GetSize(int(os.Stdout.Fd()))
+		if err != nil {
+			w, h = minTermWidth, minTermHeight
+		}
+		if rec, err = cast.New(inv.Flags.RecordCast, os.Stdout, w, h); err != nil {
+			return err
+		}
+		out = rec
+		opts = append(opts, tea.WithFilter(func(_ tea.Model, msg tea.Msg) tea.Msg {
+			if size, ok := msg.(tea.WindowSizeMsg); ok {
+				rec.Resize(size.Width, size.Height)
+			}
+			return msg
+		}))
+	}
+	opts = append(opts, tea.WithOutput(mux.NewOutput(out)))
+	model := app.New(version, inv.Flags)
+	model.SetObserver(obs)
+	p := tea.NewProgram(model, opts...)
+	_, err := p.Run()
+	if rec != nil {
+		if cerr := rec.Close(); err == nil {
+			err = cerr
+		}
+	}
+	return err
+}
+
+// export prints high scores and floor seeds, e.g. to move progress to another machine by hand.
+func export(version string) error {
+	st := state.Load(version)
+	report := struct {
+		Version      string                       `json:"version"`
+		EasyScores   []state.HighScore            `json:"easy_scores"`
+		NoisyScores  []state.HighScore            `json:"noisy_scores"`
+		CrazyScores  []state.HighScore            `json:"crazy_scores"`
+		PuzzleScores map[string][]state.HighScore `json:"puzzle_scores"`
+		WeeklyScores map[string][]state.HighScore `json:"weekly_scores"`
+		FloorSeeds   map[int]int64                `json:"floor_seeds"`
+	}{st.Version, st.EasyScores, st.NoisyScores, st.CrazyScores, st.PuzzleScores, st.WeeklyScores, st.FloorSeeds}
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// snapshot prints the floor generated from its seed with the current settings.
+// Floors not reached yet have no seed, they would come out different every time.
+func snapshot(version string, inv *flags.Invocation) error {
+	index, err := intArg(inv.Args, 0, 0)
+	if err != nil {
+		return err
+	}
+	st, _ := app.LoadState(version, inv.Flags)
+	if _, ok := st.FloorSeeds[index]; !ok {
+		return fmt.Errorf("snapshot: floor %d is not reached yet", index)
+	}
+	f := app.Floor(index, st)
+	fmt.Printf("Floor %d (%s, seed %d)\n", f.Index, st.GameMode, f.Seed)
+	if names := f.Mutators.Names(); names != "" {
+		fmt.Printf("Mutators: %s\n", names)
+	}
+	fmt.Printf("Difficulty: %s\n", f.Difficulty)
+	fmt.Print(f.String())
+	return nil
+}
+
+// printCard prints the share card of the last finished run.
+func printCard(version string) error {
+	r, ok := state.Load(version).LastRun()
+	if !ok {
+		return errors.New("card: no finished runs yet")
+	}
+	fmt.Print(card.Text(r))
+	return nil
+}
+
+// printPaths prints the directories files are kept in, with the variables that move them.
+func printPaths() error {
+	for _, d := range paths.All {
+		dir, err := d.Path()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%-6s %s  (%s)\n", d.Name, dir, d.Env)
+	}
+	return nil
+}
+
+// arg returns the i-th argument or empty string
+func arg(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
+
+// intArg returns the i-th argument as a number or the default if it is missing
+func intArg(args []string, i, def int) (int, error) {
+	a := arg(args, i)
+	if a == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(a)
+	if err != nil {
+		return 0, fmt.Errorf("invalid number: %s", a)
+	}
+	return n, nil
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/vinser/haunteed/internal/flags"
+	"github.com/vinser/haunteed/internal/state"
+)
+
+// setting is a state field editable with the config command
+type setting struct {
+	key string
+	get func(st *state.State) string
+	set func(st *state.State, value string) error
+}
+
+var settings = []setting{
+	{"mode", func(st *state.State) string { return st.GameMode },
+		enumSetter([]string{state.ModeEasy, state.ModeNoisy, state.ModeCrazy}, func(st *state.State, v string) { st.GameMode = v })},
+	{"night", func(st *state.State) string { return st.NightOption },
+		enumSetter([]string{state.NightNever, state.NightAlways, state.NightReal}, func(st *state.State, v string) { st.NightOption = v })},
+	{"sprite", func(st *state.State) string { return st.SpriteSize },
+		enumSetter([]string{state.SpriteSmall, state.SpriteMedium, state.SpriteLarge}, func(st *state.State, v string) { st.SpriteSize = v })},
+	{"mute", func(st *state.State) string { return strconv.FormatBool(st.Mute) },
+		boolSetter(func(st *state.State, v bool) { st.Mute = v })},
+	{"turn-based", func(st *state.State) string { return strconv.FormatBool(st.TurnBased) },
+		boolSetter(func(st *state.State, v bool) { st.TurnBased = v })},
+	{"weekly", func(st *state.State) string { return strconv.FormatBool(st.Weekly) },
+		boolSetter(func(st *state.State, v bool) { st.Weekly = v })},
+	{"retro", func(st *state.State) string { return strconv.FormatBool(st.Retro) },
+		boolSetter(func(st *state.State, v bool) { st.Retro = v })},
+	{"speed-bonus", func(st *state.State) string { return strconv.FormatBool(st.SpeedBonus) },
+		boolSetter(func(st *state.State, v bool) { st.SpeedBonus = v })},
+	{"extra-lives", func(st *state.State) string { return strconv.Itoa(st.ExtraLives) },
+		intSetter(func(st *state.State, v int) { st.ExtraLives = min(v, state.MaxExtraLives) })},
+	{"long-fright", func(st *state.State) string { return strconv.FormatBool(st.LongFright) },
+		boolSetter(func(st *state.State, v bool) { st.LongFright = v })},
+	{"ui-scale", func(st *state.State) string { return st.UIScale },
+		enumSetter([]string{state.UIScaleNormal, state.UIScaleLarge}, func(st *state.State, v string) { st.UIScale = v })},
+	{"repeat-ms", func(st *state.State) string { return strconv.Itoa(int(st.RepeatThreshold().Milliseconds())) },
+		intSetter(func(st *state.State, v int) { st.RepeatMs = v })},
+	{"debounce-ms", func(st *state.State) string { return strconv.Itoa(st.DebounceMs) },
+		intSetter(func(st *state.State, v int) { st.DebounceMs = v })},
+	{"sticky-steps", func(st *state.State) string { return strconv.Itoa(max(st.StickySteps, 1)) },
+		intSetter(func(st *state.State, v int) { st.StickySteps = v })},
+	{"mouse", func(st *state.State) string { return strconv.FormatBool(st.Mouse) },
+		boolSetter(func(st *state.State, v bool) { st.Mouse = v })},
+	{"bell", func(st *state.State) string { return strconv.FormatBool(st.Bell) },
+		boolSetter(func(st *state.State, v bool) { st.Bell = v })},
+	{"speech", func(st *state.State) string { return st.Speech }, setSpeech},
+	{"initials", func(st *state.State) string { return strconv.FormatBool(st.Initials) },
+		boolSetter(func(st *state.State, v bool) { st.Initials = v })},
+	{"plaintext-state", func(st *state.State) string { return strconv.FormatBool(st.Plaintext) },
+		boolSetter(func(st *state.State, v bool) { st.Plaintext = v })},
+	{"passphrase", func(st *state.State) string { return strconv.FormatBool(st.Passphrase) },
+		boolSetter(func(st *state.State, v bool) { st.Passphrase = v })},
+	{"audio-offset-ms", func(st *state.State) string { return strconv.Itoa(st.AudioOffsetMs) },
+		intSetter(func(st *state.State, v int) { st.AudioOffsetMs = min(v, state.MaxAudioOffsetMs) })},
+	{"splash", func(st *state.State) string { return splashValue(st.Splash) },
+		enumSetter([]string{state.SplashAuto, state.SplashAlways}, setSplash)},
+	{"hall-of-fame", func(st *state.State) string { return strconv.Itoa(st.FameIdleMin) },
+		intSetter(func(st *state.State, v int) { st.FameIdleMin = v })},
+	{"host-events", func(st *state.State) string { return strconv.FormatBool(st.HostEvents) },
+		boolSetter(func(st *state.State, v bool) { st.HostEvents = v })},
+	{"saver-below", func(st *state.State) string { return strconv.Itoa(st.SaverBelow) },
+		intSetter(func(st *state.State, v int) { st.SaverBelow = v })},
+	{"telemetry", func(st *state.State) string { return strconv.FormatBool(st.Telemetry) },
+		boolSetter(func(st *state.State, v bool) { st.Telemetry = v })},
+	{"update-check", func(st *state.State) string { return strconv.FormatBool(st.UpdateCheck) },
+		boolSetter(func(st *state.State, v bool) { st.UpdateCheck = v })},
+	{"term-title", func(st *state.State) string { return strconv.FormatBool(st.TermTitle) },
+		boolSetter(func(st *state.State, v bool) { st.TermTitle = v })},
+	{"quick-bar", func(st *state.State) string { return strings.Join(st.QuickBarActions(), ",") },
+		quickBarSetter},
+}
+
+// setSpeech sets the text-to-speech command template, off turns speech off.
+func setSpeech(st *state.State, v string) error {
+	if strings.EqualFold(v, "off") {
+		v = ""
+	}
+	st.Speech = strings.TrimSpace(v)
+	return nil
+}
+
+// splashValue shows the splash setting, saves made before it existed have none.
+func splashValue(splash string) string {
+	if splash == "" {
+		return state.SplashDefault
+	}
+	return splash
+}
+
+// setSplash changes the splash setting, always brings the splash back for good.
+func setSplash(st *state.State, v string) {
+	st.Splash = v
+	if v == state.SplashAlways {
+		st.SplashSkips = 0
+	}
+}
+
+// quickBarSetter binds a comma-separated list of actions to the number keys, "default" restores the default bar.
+func quickBarSetter(st *state.State, v string) error {
+	if strings.ToLower(v) == "default" {
+		st.QuickBar = nil
+		return nil
+	}
+	actions := strings.Split(strings.ToLower(v), ",")
+	if len(actions) > state.MaxQuickBar {
+		return fmt.Errorf("invalid value: %s. Use up to %d actions", v, state.MaxQuickBar)
+	}
+	for i, a := range actions {
+		actions[i] = strings.TrimSpace(a)
+		if !slices.Contains(state.QuickActions, actions[i]) {
+			return fmt.Errorf("invalid action: %s. Use %s or default", actions[i], strings.Join(state.QuickActions, ", "))
+		}
+	}
+	st.QuickBar = actions
+	return nil
+}
+
+func enumSetter(values []string, set func(*state.State, string)) func(*state.State, string) error {
+	return func(st *state.State, v string) error {
+		v = strings.ToLower(v)
+		if !slices.Contains(values, v) {
+			return fmt.Errorf("invalid value: %s. Use %s", v, strings.Join(values, ", "))
+		}
+		set(st, v)
+		return nil
+	}
+}
+
+func boolSetter(set func(*state.State, bool)) func(*state.State, string) error {
+	return func(st *state.State, v string) error {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("invalid value: %s. Use true or false", v)
+		}
+		set(st, b)
+		return nil
+	}
+}
+
+func intSetter(set func(*state.State, int)) func(*state.State, string) error {
+	return func(st *state.State, v string) error {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			return fmt.Errorf("invalid value: %s. Use a non-negative number", v)
+		}
+		set(st, n)
+		return nil
+	}
+}
+
+// config shows saved settings or changes one of them.
+func config(version string, inv *flags.Invocation) error {
+	st := state.Load(version)
+	if st.Locked {
+		return state.ErrPassphrase
+	}
+	switch arg(inv.Args, 0) {
+	case "", "show":
+		if path, err := state.SavePath(); err == nil {
+			fmt.Printf("# %s\n", path)
+		}
+		for _, s := range settings {
+			fmt.Printf("%s = %s\n", s.key, s.get(st))
+		}
+		return nil
+	case "set":
+		key, value := arg(inv.Args, 1), arg(inv.Args, 2)
+		for _, s := range settings {
+			if s.key == key {
+				if err := s.set(st, value); err != nil {
+					return err
+				}
+				return st.Save()
+			}
+		}
+		return fmt.Errorf("unknown setting: %s", key)
+	}
+	return fmt.Errorf("unknown config action: %s. Use show or set", arg(inv.Args, 0))
+}
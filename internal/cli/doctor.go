@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/vinser/haunteed/internal/flags"
+	"github.com/vinser/haunteed/internal/geoip"
+	"github.com/vinser/haunteed/internal/mux"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/speech"
+	"github.com/vinser/haunteed/internal/state"
+	"golang.org/x/term"
+)
+
+// Minimal comfortable terminal size
+const (
+	minTermWidth  = 80
+	minTermHeight = 25
+)
+
+// doctor checks the environment the game depends on and prints a report.
+func doctor(version string, inv *flags.Invocation) error {
+	report := func(ok bool, check, detail string) {
+		mark := "ok  "
+		if !ok {
+			mark = "warn"
+		}
+		fmt.Printf("[%s] %-10s %s\n", mark, check, detail)
+	}
+
+	fd := int(os.Stdout.Fd())
+	if !term.IsTerminal(fd) {
+		report(false, "terminal", "standard output is not a terminal")
+	} else if w, h, err := term.GetSize(fd); err != nil {
+		report(false, "terminal", err.Error())
+	} else {
+		report(w >= minTermWidth && h >= minTermHeight, "terminal",
+			fmt.Sprintf("%dx%d (at least %dx%d recommended), TERM=%s", w, h, minTermWidth, minTermHeight, os.Getenv("TERM")))
+	}
+
+	switch mux.Detect() {
+	case mux.Tmux:
+		if w, h, ok := mux.PaneSize(); ok {
+			report(true, "mux", fmt.Sprintf("tmux pane %dx%d, clipboard needs \"set -g allow-passthrough on\"", w, h))
+		} else {
+			report(false, "mux", "tmux doesn't tell the pane size")
+		}
+	case mux.Screen:
+		report(true, "mux", "screen, sizes can't be checked")
+	}
+
+	if path, err := state.SavePath(); err != nil {
+		report(false, "data", err.Error())
+	} else {
+		probe := filepath.Join(filepath.Dir(path), ".doctor")
+		if err := os.WriteFile(probe, nil, 0644); err != nil {
+			report(false, "data", err.Error())
+		} else {
+			os.Remove(probe)
+			if _, err := os.Stat(path); err != nil {
+				report(true, "data", path+" (no saved progress yet)")
+			} else {
+				report(true, "data", path)
+			}
+		}
+	}
+
+	if m, failed := sound.Initialize(); failed {
+		report(false, "audio", "no audio output, the game will be muted")
+	} else {
+		m.Close()
+		report(true, "audio", "sound output is available")
+	}
+
+	if st := state.Load(version); st.Speech != "" {
+		if sp, err := speech.New(st.Speech); err != nil {
+			report(false, "speech", err.Error())
+		} else {
+			sp.Close()
+			report(true, "speech", st.Speech)
+		}
+	}
+
+	if inv.Flags != nil && inv.Flags.NoNetwork {
+		report(true, "network", "disabled by -no-network")
+	} else if loc, err := geoip.GetLocationInfo(); err != nil {
+		report(false, "network", "location lookup failed, real night falls on Kansas City: "+err.Error())
+	} else {
+		report(true, "network", fmt.Sprintf("located in %s, %s (%s)", loc.City, loc.Country, loc.Timezone))
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/vinser/haunteed/internal/keyring"
+	"golang.org/x/term"
+)
+
+// keyringAccount names the save passphrase in the keyring
+const keyringAccount = "save passphrase"
+
+// keyringTried is set once the keyring passphrase was tried, a wrong one is asked for on the terminal
+var keyringTried bool
+
+// askPassphrase returns the save passphrase cached in the keyring, or asks for it on the terminal.
+// A new passphrase is always typed twice.
+func askPassphrase(newKey bool) (string, error) {
+	if !newKey && !keyringTried {
+		keyringTried = true
+		if passphrase, err := keyring.Get(keyringAccount); err == nil && passphrase != "" {
+			return passphrase, nil
+		}
+	}
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", errors.New("no terminal to ask for it")
+	}
+	if !newKey {
+		return readPassphrase("Save passphrase: ")
+	}
+	passphrase, err := readPassphrase("New save passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if passphrase == "" {
+		return "", errors.New("the passphrase is empty")
+	}
+	repeated, err := readPassphrase("Repeat the passphrase: ")
+	if err != nil {
+		return "", err
+	}
+	if repeated != passphrase {
+		return "", errors.New("the passphrases don't match")
+	}
+	return passphrase, nil
+}
+
+func readPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	return string(passphrase), err
+}
+
+// rememberPassphrase caches the passphrase in the keyring so it is asked for once per machine.
+// Systems without a keyring ask every time.
+func rememberPassphrase(passphrase string) {
+	keyring.Set(keyringAccount, passphrase)
+}
@@ -0,0 +1,48 @@
+package cli
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/vinser/haunteed/internal/app"
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/flags"
+)
+
+// defaultSimulationMoves is the number of ghost moves simulated if not given
+const defaultSimulationMoves = 1000
+
+// simulate runs ghosts against a haunteed standing still on the floor start and reports the first capture.
+// Ghosts are released from the den immediately and always chase, so it is the worst case for balance checks.
+func simulate(version string, inv *flags.Invocation) error {
+	index, err := intArg(inv.Args, 0, 0)
+	if err != nil {
+		return err
+	}
+	moves, err := intArg(inv.Args, 1, defaultSimulationMoves)
+	if err != nil {
+		return err
+	}
+	st, _ := app.LoadState(version, inv.Flags)
+	f := app.Floor(index, st)
+	rng := rand.New(rand.NewSource(st.FloorSeeds[f.Index]))
+	ghosts := dweller.PlaceGhosts(f.Index, st.SpriteSize, st.GameMode, f.Maze.Width(), f.Maze.Height(), f.Dens, rng)
+	for _, g := range ghosts {
+		g.SetRelease(0)
+	}
+	htPos := dweller.Position{X: f.Maze.Start().X, Y: f.Maze.Start().Y}
+
+	fmt.Printf("Floor %d (%s, seed %d): %d ghosts, move every %v\n", f.Index, st.GameMode, f.Seed, len(ghosts), f.GhostTickInterval)
+	for move := 1; move <= moves; move++ {
+		dweller.MoveGhosts(ghosts, f, false, htPos, dweller.No)
+		for _, g := range ghosts {
+			if g.Pos() == htPos {
+				fmt.Printf("Caught after %d ghost moves (%v of play)\n", move, time.Duration(move)*f.GhostTickInterval)
+				return nil
+			}
+		}
+	}
+	fmt.Printf("Not caught within %d ghost moves\n", moves)
+	return nil
+}
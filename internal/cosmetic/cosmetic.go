@@ -0,0 +1,106 @@
+// Package cosmetic prices the looks bought with ectoplasm earned by runs.
+// Looks change nothing but colors, so scores stay comparable.
+package cosmetic
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+// Kinds of items, a look of each kind is worn at a time
+const (
+	KindSkin   = "skin"   // Color of the haunteed in the maze
+	KindSplash = "splash" // Color of the big haunteed on the splash screen
+)
+
+// Item is a look for sale in the vault.
+type Item struct {
+	ID    string
+	Name  string
+	Kind  string
+	Price int    // Ectoplasm
+	Color string // Color name, see style.RGBColor
+}
+
+// Items lists the looks for sale, the cheapest first.
+var Items = []Item{
+	{ID: "skin-ecto", Name: "Ecto green haunteed", Kind: KindSkin, Price: 10, Color: "green"},
+	{ID: "splash-frost", Name: "Frosty splash", Kind: KindSplash, Price: 15, Color: "cyan"},
+	{ID: "skin-frost", Name: "Frost cyan haunteed", Kind: KindSkin, Price: 25, Color: "cyan"},
+	{ID: "splash-blood", Name: "Blood moon splash", Kind: KindSplash, Price: 30, Color: "red"},
+	{ID: "skin-blush", Name: "Blush magenta haunteed", Kind: KindSkin, Price: 40, Color: "magenta"},
+	{ID: "skin-wraith", Name: "Wraith white haunteed", Kind: KindSkin, Price: 60, Color: "white"},
+}
+
+// Per run earnings
+const (
+	FloorEcto  = 1    // For each floor reached
+	ScoreEcto  = 1000 // Points for one more
+	MaxRunEcto = 25   // Cap of a single run
+)
+
+var (
+	ErrOwned = errors.New("already owned")
+	ErrPrice = errors.New("not enough ectoplasm")
+)
+
+// Earned returns the ectoplasm a run pays: one for showing up, one for each floor reached and one per ScoreEcto points.
+func Earned(run state.Run) int {
+	return min(1+max(run.Floor, 0)*FloorEcto+run.Score/ScoreEcto, MaxRunEcto)
+}
+
+// Owned returns true if the item is bought.
+func Owned(st *state.State, id string) bool {
+	return slices.Contains(st.Unlocked, id)
+}
+
+// Buy spends ectoplasm on the item and wears it.
+func Buy(st *state.State, it Item) error {
+	if Owned(st, it.ID) {
+		return ErrOwned
+	}
+	if st.Ectoplasm < it.Price {
+		return ErrPrice
+	}
+	st.Ectoplasm -= it.Price
+	st.Unlocked = append(st.Unlocked, it.ID)
+	Wear(st, it)
+	return nil
+}
+
+// Wear puts the owned item on instead of the one of the same kind, the item worn already is taken off.
+func Wear(st *state.State, it Item) {
+	if !Owned(st, it.ID) {
+		return
+	}
+	if st.Worn == nil {
+		st.Worn = make(map[string]string)
+	}
+	if st.Worn[it.Kind] == it.ID {
+		delete(st.Worn, it.Kind)
+		return
+	}
+	st.Worn[it.Kind] = it.ID
+}
+
+// Worn returns true if the item is worn.
+func Worn(st *state.State, id string) bool {
+	for _, worn := range st.Worn {
+		if worn == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Color returns the color of the look of the kind worn, def if none is worn.
+func Color(st *state.State, kind, def string) string {
+	for _, it := range Items {
+		if it.Kind == kind && st.Worn[kind] == it.ID {
+			return it.Color
+		}
+	}
+	return def
+}
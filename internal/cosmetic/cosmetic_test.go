@@ -0,0 +1,55 @@
+package cosmetic
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestEarned(t *testing.T) {
+	tests := []struct {
+		run  state.Run
+		want int
+	}{
+		{state.Run{}, 1},
+		{state.Run{Floor: 3, Score: 2500}, 6},
+		{state.Run{Floor: -2, Score: 999}, 1},
+		{state.Run{Floor: 40, Score: 90000}, MaxRunEcto},
+	}
+	for _, tt := range tests {
+		if got := Earned(tt.run); got != tt.want {
+			t.Errorf("Earned(%+v) = %d, want %d", tt.run, got, tt.want)
+		}
+	}
+}
+
+func TestBuyAndWear(t *testing.T) {
+	st := &state.State{Ectoplasm: 30}
+	green, cyan := Items[0], Items[2]
+
+	if err := Buy(st, cyan); err != nil {
+		t.Fatal(err)
+	}
+	if err := Buy(st, cyan); !errors.Is(err, ErrOwned) {
+		t.Errorf("second buy: %v, want ErrOwned", err)
+	}
+	if err := Buy(st, green); !errors.Is(err, ErrPrice) {
+		t.Errorf("buy with %d left: %v, want ErrPrice", st.Ectoplasm, err)
+	}
+	if st.Ectoplasm != 5 {
+		t.Errorf("Ectoplasm = %d, want 5", st.Ectoplasm)
+	}
+	if got := Color(st, KindSkin, "yellow"); got != "cyan" {
+		t.Errorf("bought skin not worn: %s", got)
+	}
+
+	Wear(st, cyan)
+	if got := Color(st, KindSkin, "yellow"); got != "yellow" {
+		t.Errorf("skin not taken off: %s", got)
+	}
+	Wear(st, green) // Not owned
+	if Worn(st, green.ID) {
+		t.Error("wearing an item not owned")
+	}
+}
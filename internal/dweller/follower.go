@@ -0,0 +1,53 @@
+package dweller
+
+// Follower is a rescued colleague walking the haunteed's trail, one cell per move.
+// It is slower than the haunteed, so the trail grows while the haunteed hurries ahead.
+type Follower struct {
+	position Position
+	trail    []Position // cells the haunteed left since, the oldest first
+}
+
+// NewFollower returns a follower standing at the position.
+func NewFollower(pos Position) *Follower {
+	return &Follower{position: pos}
+}
+
+// Pos returns the follower's current position.
+func (f *Follower) Pos() Position {
+	return f.position
+}
+
+// Track adds a cell the haunteed left to the trail.
+func (f *Follower) Track(p Position) {
+	f.trail = append(f.trail, p)
+}
+
+// Move takes the next step along the trail. Loops the haunteed walked are cut short:
+// the follower jumps to the newest trail cell next to it.
+func (f *Follower) Move() {
+	for len(f.trail) > 0 && f.trail[0] == f.position {
+		f.trail = f.trail[1:]
+	}
+	if len(f.trail) == 0 {
+		return
+	}
+	next := 0
+	for i := len(f.trail) - 1; i > 0; i-- {
+		if manhattan(f.trail[i], f.position) == 1 {
+			next = i
+			break
+		}
+	}
+	f.position = f.trail[next]
+	f.trail = f.trail[next+1:]
+}
+
+// Caught returns true if a ghost that isn't frightened or on its way home stands on the follower.
+func (f *Follower) Caught(ghosts []*Ghost) bool {
+	for _, g := range ghosts {
+		if g.Pos() == f.position && (g.State() == Chase || g.State() == Scatter) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package dweller
+
+import "testing"
+
+func TestFollower(t *testing.T) {
+	f := NewFollower(Position{X: 1, Y: 1})
+	// The haunteed walks right, down, left and up again around a block, then on to the right
+	for _, p := range []Position{{1, 1}, {2, 1}, {3, 1}, {3, 2}, {2, 2}, {1, 2}} {
+		f.Track(p)
+	}
+	f.Move()
+	if got, want := f.Pos(), (Position{X: 1, Y: 2}); got != want {
+		t.Fatalf("Pos() = %v, want %v cutting the loop short", got, want)
+	}
+	f.Move()
+	if got, want := f.Pos(), (Position{X: 1, Y: 2}); got != want {
+		t.Errorf("Pos() = %v, want %v at the end of the trail", got, want)
+	}
+
+	f = NewFollower(Position{X: 1, Y: 1})
+	for _, p := range []Position{{1, 1}, {2, 1}, {3, 1}} {
+		f.Track(p)
+	}
+	for _, want := range []Position{{2, 1}, {3, 1}} {
+		f.Move()
+		if got := f.Pos(); got != want {
+			t.Errorf("Pos() = %v, want %v", got, want)
+		}
+	}
+}
@@ -44,6 +44,16 @@ type Ghost struct {
 	rng           *rand.Rand
 	exitTarget    Position // where to move during exiting
 	releaseTime   time.Time
+	pathSprite    []string      // debug overlay of the intended path
+	targetSprite  []string      // debug overlay of the target tile
+	controlled    bool          // a human player steers the ghost, see Steer
+	steer         Direction     // direction chosen by the player
+	den           int           // index of the floor den the ghost starts in, see Reinforcement
+	respawnDelay  time.Duration // time an eaten ghost waits at home once power mode is over, see RespawnDelay
+	respawning    bool          // an eaten ghost waits at home to exit again
+	held          bool          // waits in the den until released, see Hold
+	stats         GhostStats    // what the ghost did on the floor, see Stats
+	near          bool          // the ghost is within closeRange of the haunteed, see Watch
 }
 
 // NewGhost creates a ghost with specified type and home position.
@@ -71,11 +81,24 @@ func NewGhost(t GhostType, home Position, mazeWidth, mazeHeight int, rng *rand.R
 	}
 }
 
+// GhostNames are the ghost names by type
+var GhostNames = []string{"Curly", "Lofty", "Fluffy", "Virty"}
+
+// String returns the ghost name.
+func (t GhostType) String() string {
+	if int(t) < len(GhostNames) {
+		return GhostNames[t]
+	}
+	return "Ghost"
+}
+
 // GhostController manages ghost behavior state transitions over time.
 type GhostController struct {
 	modeIndex   int
 	modeTimer   time.Time
 	modePattern []ghostModePhase
+	turnPeriod  time.Duration // Time each Update stands for in turn-based play, 0 follows the clock
+	elapsed     time.Duration // Turn time spent in the current phase
 }
 
 // ghostModePhase defines a chase/scatter phase and its duration.
@@ -100,14 +123,33 @@ func NewGhostController() *GhostController {
 	}
 }
 
+// SkipScatter jumps straight to the final chase phase.
+func (gc *GhostController) SkipScatter() {
+	gc.modeIndex = len(gc.modePattern) - 1
+	gc.modeTimer = time.Now()
+}
+
+// SetTurnPeriod makes phases advance by period on each Update instead of following the clock,
+// so turn-based play goes through the same phases however long the player thinks.
+func (gc *GhostController) SetTurnPeriod(period time.Duration) {
+	gc.turnPeriod = period
+	gc.elapsed = 0
+}
+
 // Update updates ghost states based on the time and current phase.
 func (gc *GhostController) Update(ghosts []*Ghost) {
-	if time.Since(gc.modeTimer) >= gc.modePattern[gc.modeIndex].duration {
+	spent := time.Since(gc.modeTimer)
+	if gc.turnPeriod > 0 {
+		gc.elapsed += gc.turnPeriod
+		spent = gc.elapsed
+	}
+	if spent >= gc.modePattern[gc.modeIndex].duration {
 		gc.modeIndex++
 		if gc.modeIndex >= len(gc.modePattern) {
 			gc.modeIndex = len(gc.modePattern) - 1
 		}
 		gc.modeTimer = time.Now()
+		gc.elapsed = 0
 	}
 
 	currentState := gc.modePattern[gc.modeIndex].state
@@ -146,36 +188,112 @@ func (g *Ghost) targetPos(ht Position, htDir Direction, curlyPos Position) Posit
 	return g.scatterTarget
 }
 
-// Place new ghosts in the ghosts den randomly.
-func PlaceGhosts(floorNum int, spriteSize string, gameMode string, mazeWidth, mazeHeight, denWidth, denHeight int, rng *rand.Rand) []*Ghost {
-	if denWidth%2 == 0 {
-		denWidth++
+// Target returns the tile the ghost is heading to in its current state.
+// Frightened ghosts wander randomly and have no target.
+func (g *Ghost) Target(ghosts []*Ghost, ht Position, htDir Direction) (Position, bool) {
+	if g.controlled && g.state != Eaten && g.state != Exiting {
+		return Position{}, false // Only the player knows
+	}
+	switch g.state {
+	case Frightened:
+		return Position{}, false
+	case Eaten:
+		return g.home, true
+	case Exiting:
+		return g.exitTarget, true
+	}
+	var curlyPos Position
+	for _, other := range ghosts {
+		if other.ghostType == Curly {
+			curlyPos = other.Pos()
+			break
+		}
+	}
+	return g.targetPos(ht, htDir, curlyPos), true
+}
+
+// PlanPath returns up to maxLen steps of the shortest walkable path from the ghost to the target.
+// If the target itself is unreachable, e.g. a scatter corner inside a wall, the path leads to the closest reachable tile.
+func (g *Ghost) PlanPath(f *floor.Floor, target Position, maxLen int) []Position {
+	passable := func(p Position) bool {
+		tile, err := f.ItemAt(p.X, p.Y)
+		return err == nil && tile != floor.Wall && (tile != floor.CrumblingWall || g.state == Eaten)
+	}
+	parent := map[Position]Position{g.position: g.position}
+	queue := []Position{g.position}
+	best := g.position
+	for head := 0; head < len(queue); head++ {
+		cur := queue[head]
+		if manhattan(cur, target) < manhattan(best, target) {
+			best = cur
+		}
+		if cur == target {
+			break
+		}
+		for _, d := range []Direction{Up, Down, Left, Right} {
+			next := cur.moveIn(d)
+			if _, seen := parent[next]; !seen && passable(next) {
+				parent[next] = cur
+				queue = append(queue, next)
+			}
+		}
+	}
+	var path []Position
+	for p := best; p != g.position; p = parent[p] {
+		path = append([]Position{p}, path...)
 	}
+	if len(path) > maxLen {
+		path = path[:maxLen]
+	}
+	return path
+}
+
+// OverlaySprites returns debug overlay sprites for the ghost path and target tiles.
+func (g *Ghost) OverlaySprites() (path, target []string) {
+	return g.pathSprite, g.targetSprite
+}
+
+// Place new ghosts in the floor dens randomly: the four ghosts in the first den
+// and a reinforcement ghost in each of the others, held there until released.
+func PlaceGhosts(floorNum int, spriteSize string, gameMode string, mazeWidth, mazeHeight int, dens []floor.Den, rng *rand.Rand) []*Ghost {
 	if rng == nil {
 		rng = rand.New(rand.NewSource(time.Now().UnixNano()))
 	}
-	// Compute top-left position of the den inner area (room - walls)
-	startCol := (mazeWidth-denWidth)/2 + 1
-	startRow := (mazeHeight-denHeight)/2 + 1
 
-	ghosts := make([]*Ghost, 4)
-	for i := Curly; i <= Virty; i++ {
+	var ghosts []*Ghost
+	place := func(t GhostType, den int) *Ghost {
+		d := dens[den]
 		pos := Position{
-			X: startCol + rng.Intn(denWidth-2),
-			Y: startRow + rng.Intn(denHeight-2),
+			X: d.X + rng.Intn(d.Width),
+			Y: d.Y + rng.Intn(d.Height),
 		}
-		ghosts[i] = NewGhost(GhostType(i), pos, mazeWidth, mazeHeight, rng)
-		ghosts[i].SetExit(mazeWidth, mazeHeight, denWidth, denHeight)
-		ghosts[i].SetState(Exiting)
-		delay := time.Duration(i) * 3 * time.Second
-		ghosts[i].SetRelease(delay)
-		ghosts[i].typeSprite = setGhostTypeSprite(floorNum, spriteSize, i, gameMode)
-		ghosts[i].stateSprites = setGhostStateSprites(floorNum, spriteSize, gameMode)
+		g := NewGhost(t, pos, mazeWidth, mazeHeight, rng)
+		g.exitTarget = Position{X: d.Exit.X, Y: d.Exit.Y}
+		g.den = den
+		g.respawnDelay = RespawnDelay(floorNum)
+		g.SetState(Exiting)
+		g.typeSprite = setGhostTypeSprite(floorNum, spriteSize, t, gameMode)
+		g.stateSprites = setGhostStateSprites(floorNum, spriteSize, gameMode)
+		g.pathSprite, g.targetSprite = setGhostOverlaySprites(floorNum, spriteSize, t)
+		ghosts = append(ghosts, g)
+		return g
+	}
+	for i := Curly; i <= Virty; i++ {
+		place(i, 0).SetRelease(ReleaseDelay(int(i)))
+	}
+	// Reinforcements take turns by floor, so a floor does not always call the same ghost
+	for den := 1; den < len(dens); den++ {
+		place(GhostType((floorNum+den)%len(GhostNames)), den).Hold()
 	}
 
 	return ghosts
 }
 
+// Reinforcement returns true if the ghost starts in a reinforcement den and waits there to be called.
+func (g *Ghost) Reinforcement() bool {
+	return g.den > 0
+}
+
 // State returns the type of the ghost.
 func (g *Ghost) Type() GhostType {
 	return g.ghostType
@@ -188,7 +306,11 @@ func (g *Ghost) State() GhostState {
 
 // SetState updates the ghost's state.
 func (g *Ghost) SetState(state GhostState) {
+	if state == Eaten && g.state != Eaten {
+		g.stats.Eaten++
+	}
 	g.state = state
+	g.respawning = false
 }
 
 // Pos returns the current position of the ghost.
@@ -206,14 +328,53 @@ func (g *Ghost) Home() Position {
 	return g.home
 }
 
-func (g *Ghost) SetExit(mazeWidth, mazeHeight, denWidth, denHeight int) {
-	g.exitTarget = Position{X: mazeWidth / 2, Y: (mazeHeight-denHeight)/2 - 1}
+// ReleaseDelay returns how long the i-th ghost waits in the den before exiting.
+func ReleaseDelay(i int) time.Duration {
+	return time.Duration(i) * 3 * time.Second
+}
+
+// maxRespawnDelay caps the respawn delay on deep floors
+const maxRespawnDelay = 8 * time.Second
+
+// RespawnDelay returns how long an eaten ghost waits in the den before exiting again on the floor.
+// The farther the floor is from the ground one, the longer the wait, up to maxRespawnDelay.
+func RespawnDelay(floorNum int) time.Duration {
+	return min(time.Second+time.Duration(abs(floorNum))*time.Second/2, maxRespawnDelay)
+}
 
+// RespawnLeft returns the time an eaten ghost still waits at home, false if the ghost is not respawning.
+func (g *Ghost) RespawnLeft() (time.Duration, bool) {
+	if !g.respawning {
+		return 0, false
+	}
+	return max(time.Until(g.releaseTime), 0), true
+}
+
+// Respawning returns true if the ghost was eaten and waits at home to exit again.
+func (g *Ghost) Respawning() bool {
+	return g.respawning
+}
+
+// ReleaseLeft returns the time the ghost still waits in the den before exiting,
+// false if it doesn't wait or is held until released.
+func (g *Ghost) ReleaseLeft() (time.Duration, bool) {
+	left := time.Until(g.releaseTime)
+	if g.state != Exiting || g.held || left <= 0 {
+		return 0, false
+	}
+	return left, true
+}
+
+// Hold keeps the ghost in the den until it is released with SetRelease.
+func (g *Ghost) Hold() {
+	g.releaseTime = time.Now().Add(9999 * time.Hour) // effectively forever
+	g.held = true
 }
 
 // SetRelease sets the time after which the ghost is allowed to exit the den.
 func (g *Ghost) SetRelease(delay time.Duration) {
 	g.releaseTime = time.Now().Add(delay)
+	g.held = false
 }
 
 // Move moves the ghost in its current direction.
@@ -234,7 +395,10 @@ func (g *Ghost) NextPos() Position {
 }
 
 // MoveGhosts moves each ghost according to its state.
-func MoveGhosts(ghosts []*Ghost, f *floor.Floor, powerMode bool, htPos Position, htDir Direction) {
+// Chasing ghosts go for an escort, e.g. a follower, instead of the haunteed when it is closer.
+// It returns positions of crumbling walls broken by eaten ghosts on their way home.
+func MoveGhosts(ghosts []*Ghost, f *floor.Floor, powerMode bool, htPos Position, htDir Direction, escorts ...Position) []Position {
+	var brokenWalls []Position
 	var curlyPos Position
 	for _, g := range ghosts {
 		if g.ghostType == Curly {
@@ -243,20 +407,43 @@ func MoveGhosts(ghosts []*Ghost, f *floor.Floor, powerMode bool, htPos Position,
 		}
 	}
 
+	var posts map[*Ghost]Position
+	if f.Mutators.GhostsSurround() {
+		posts = surroundTargets(ghosts, f, htPos)
+	}
+
 	for _, g := range ghosts {
+		if g.controlled && (g.state == Chase || g.state == Scatter || g.state == Frightened) {
+			g.moveSteered(f, ghosts)
+			continue
+		}
 		switch g.State() {
 		case Frightened:
 			g.MoveRandom(f, ghosts)
 		case Eaten:
 			if g.Pos() == g.Home() {
 				if !powerMode {
-					g.SetState(Chase)
+					// Back in the den, the ghost takes its time before it exits again
+					g.SetState(Exiting)
+					g.SetRelease(g.respawnDelay)
+					g.respawning = true
 				}
-			} else {
-				g.MoveToHome(f, ghosts)
+			} else if g.MoveToHome(f, ghosts) {
+				brokenWalls = append(brokenWalls, g.Pos())
 			}
 		case Chase,
 			Scatter:
+			if e, ok := g.nearestEscort(htPos, escorts); ok && g.state == Chase {
+				g.moveToTarget(f, e, ghosts)
+				continue
+			}
+			if post, ok := posts[g]; ok {
+				g.moveToTarget(f, post, ghosts)
+				continue
+			}
+			if g.state == Chase && f.Mutators.GhostsFollowScent() && g.followScent(f, ghosts) {
+				continue
+			}
 			target := g.targetPos(htPos, htDir, curlyPos)
 			g.moveToTarget(f, target, ghosts)
 		case Exiting:
@@ -267,6 +454,7 @@ func MoveGhosts(ghosts []*Ghost, f *floor.Floor, powerMode bool, htPos Position,
 			if time.Now().Before(g.releaseTime) {
 				continue // Delay exit
 			}
+			g.respawning = false
 			if g.Pos().X == g.exitTarget.X && abs(g.Pos().Y-g.exitTarget.Y) <= 1 { // Fix exitTarget inaccuracy
 				g.SetState(Chase)
 			} else {
@@ -274,16 +462,24 @@ func MoveGhosts(ghosts []*Ghost, f *floor.Floor, powerMode bool, htPos Position,
 			}
 		}
 	}
+	return brokenWalls
 }
 
 // Move moves the ghost in its current direction.
 func (g *Ghost) Move() {
 	g.position = g.NextPos()
+	g.stats.Traveled++
 }
 
 // MoveToHome moves the ghost one step closer to its home position.
-func (g *Ghost) MoveToHome(f *floor.Floor, allGhosts []*Ghost) {
+// Eaten ghosts go through crumbling walls, breaking them. It returns true if a wall was broken.
+func (g *Ghost) MoveToHome(f *floor.Floor, allGhosts []*Ghost) bool {
 	g.moveToTarget(f, g.home, allGhosts)
+	if tile, err := f.ItemAt(g.position.X, g.position.Y); err == nil && tile == floor.CrumblingWall {
+		f.BreakWall(g.position.X, g.position.Y)
+		return true
+	}
+	return false
 }
 
 func abs(x int) int {
@@ -293,6 +489,39 @@ func abs(x int) int {
 	return x
 }
 
+// SetControlled hands the ghost over to a human player or back to its AI.
+// Controlled ghosts still leave the den and return home when eaten on their own.
+func (g *Ghost) SetControlled(controlled bool) {
+	g.controlled = controlled
+	g.steer = No
+}
+
+// IsControlled returns true if a human player steers the ghost.
+func (g *Ghost) IsControlled() bool {
+	return g.controlled
+}
+
+// Steer sets the direction a controlled ghost turns to as soon as it can.
+func (g *Ghost) Steer(dir Direction) {
+	g.steer = dir
+}
+
+// moveSteered moves a controlled ghost: it turns to the steered direction when the way is open
+// and keeps going straight until then.
+func (g *Ghost) moveSteered(f *floor.Floor, allGhosts []*Ghost) {
+	if g.steer != No && g.canMoveTo(g.position, g.steer, f, allGhosts) {
+		g.direction = g.steer
+	}
+	if g.canMoveTo(g.position, g.direction, f, allGhosts) {
+		g.Move()
+	}
+}
+
+// Dir returns the ghost's movement direction.
+func (g *Ghost) Dir() Direction {
+	return g.direction
+}
+
 // SetDirection sets the ghost's movement direction.
 func (g *Ghost) SetDirection(dir Direction) {
 	g.direction = dir
@@ -341,13 +570,16 @@ func (g *Ghost) validAllDirections(f *floor.Floor, allGhosts []*Ghost) []Directi
 }
 
 // canMoveTo checks if a ghost can move to a new position.
-// It checks for walls and other ghosts.
+// It checks for walls, the flashlight beam and other ghosts. Only eaten ghosts on their way home cross the beam.
 func (g *Ghost) canMoveTo(p Position, d Direction, f *floor.Floor, allGhosts []*Ghost) bool {
 	newPos := p.moveIn(d)
 
-	// Check for walls
+	// Check for walls, eaten ghosts break through crumbling ones
 	tile, err := f.ItemAt(newPos.X, newPos.Y)
-	if err != nil || tile == floor.Wall || tile == floor.CrumblingWall {
+	if err != nil || tile == floor.Wall || (tile == floor.CrumblingWall && g.state != Eaten) {
+		return false
+	}
+	if g.state != Eaten && f.InBeam(newPos.X, newPos.Y) {
 		return false
 	}
 
@@ -431,6 +663,40 @@ func (g *Ghost) findBestDirections(target Position, validDirections []Direction)
 	return candidates
 }
 
+// nearestEscort returns the escort closest to the ghost if it is closer than the haunteed.
+func (g *Ghost) nearestEscort(htPos Position, escorts []Position) (Position, bool) {
+	best, ok := htPos, false
+	for _, e := range escorts {
+		if manhattan(g.position, e) < manhattan(g.position, best) {
+			best, ok = e, true
+		}
+	}
+	return best, ok
+}
+
+// followScent moves the ghost one step along the haunteed's trail toward its fresher end.
+// It returns false if no neighboring cell smells stronger than the ghost's own, the ghost has to find the trail first.
+func (g *Ghost) followScent(f *floor.Floor, allGhosts []*Ghost) bool {
+	strongest := f.Scent(g.position.X, g.position.Y)
+	var candidates []Direction
+	for _, d := range g.validAllDirections(f, allGhosts) {
+		next := g.position.moveIn(d)
+		switch scent := f.Scent(next.X, next.Y); {
+		case scent > strongest:
+			strongest = scent
+			candidates = []Direction{d}
+		case scent == strongest && len(candidates) > 0:
+			candidates = append(candidates, d)
+		}
+	}
+	if len(candidates) == 0 {
+		return false
+	}
+	g.direction = candidates[g.rng.Intn(len(candidates))]
+	g.Move()
+	return true
+}
+
 // moveToTarget moves the ghost one step toward the target position.
 func (g *Ghost) moveToTarget(l *floor.Floor, target Position, allGhosts []*Ghost) {
 	// Find best directions, excluding reversing.
@@ -536,6 +802,29 @@ func getGhostTypeSprite(size string, ghostType GhostType) []string {
 	return []string{" "}
 }
 
+func setGhostOverlaySprites(floorNum int, spriteSize string, ghostType GhostType) (path, target []string) {
+	brightStyle, dimStyle := getGostTypeStyle(floorNum, ghostType)
+	pathSprite, targetSprite := getGhostOverlaySprite(spriteSize)
+	for _, s := range pathSprite {
+		path = append(path, dimStyle.Render(s))
+	}
+	for _, s := range targetSprite {
+		target = append(target, brightStyle.Bold(true).Render(s))
+	}
+	return path, target
+}
+
+func getGhostOverlaySprite(size string) (path, target []string) {
+	switch size {
+	case state.SpriteSmall:
+		return []string{"·"}, []string{"×"}
+	case state.SpriteLarge:
+		return []string{"    ", " ·· "}, []string{"╲  ╱", "╱  ╲"}
+	default: // state.SpriteMedium
+		return []string{"··"}, []string{"><"}
+	}
+}
+
 func setGhostStateSprites(floorNum int, spriteSize, gameMode string) map[GhostState][]string {
 	var sprites = map[GhostState][]string{
 		Frightened: nil,
@@ -13,12 +13,30 @@ type Haunteed struct {
 	home         Position
 	position     Position
 	direction    Direction
+	facing       Direction // last direction headed in, the flashlight shines this way
 	lives        int
+	deaths       int // lives lost in the run
 	brightSprite []string
 	dimSprite    []string
 	lastHitTime  time.Time
+	// disorientedUntil mirrors the controls until the given time, e.g. after falling through a hole
+	disorientedUntil time.Time
+	immortal         bool             // practice runs never run out of lives
+	pocket           [pickupCount]int // stored pickups by kind
 }
 
+// Pickup is a kind of item the haunteed carries until it is used.
+type Pickup int
+
+const (
+	Rewind     Pickup = iota // rolls the world back a few seconds
+	Trap                     // sends a ghost stepping on it home
+	FuseCharge               // toggles the lights like a fuse
+	Camera                   // photographs a ghost next to the haunteed
+	Flashlight               // shines a beam ghosts keep out of
+	pickupCount
+)
+
 const hitCooldown = 100 * time.Millisecond
 
 // NewHaunteed returns a new Haunteed instance with default values.
@@ -39,6 +57,7 @@ func NewHaunteed(home Position, gameMode string) *Haunteed {
 		home:        home,
 		position:    home, // starting position
 		direction:   Right,
+		facing:      Right,
 		lives:       lives,
 		lastHitTime: time.Now().Add(-hitCooldown), // Initialize to allow immediate hit
 	}
@@ -71,6 +90,11 @@ func (p *Haunteed) Dir() Direction {
 	return p.direction
 }
 
+// Facing returns the last direction Haunteed headed in, it is never No.
+func (p *Haunteed) Facing() Direction {
+	return p.facing
+}
+
 // SetPos sets Haunteed's position explicitly.
 func (p *Haunteed) SetPos(pos Position) {
 	p.position = pos
@@ -106,6 +130,22 @@ func (p *Haunteed) HandleInput(key string) {
 	default:
 		p.direction = No
 	}
+	if p.IsDisoriented() {
+		p.direction = oppositeDirection(p.direction)
+	}
+	if p.direction != No {
+		p.facing = p.direction
+	}
+}
+
+// Disorient mirrors Haunteed's controls for the given period.
+func (p *Haunteed) Disorient(period time.Duration) {
+	p.disorientedUntil = time.Now().Add(period)
+}
+
+// IsDisoriented returns true while Haunteed's controls are mirrored.
+func (p *Haunteed) IsDisoriented() bool {
+	return time.Now().Before(p.disorientedUntil)
 }
 
 // Lives returns Haunteed's remaining lives.
@@ -113,18 +153,38 @@ func (p *Haunteed) Lives() int {
 	return p.lives
 }
 
+// SetImmortal makes Haunteed keep its lives when hit.
+func (p *Haunteed) SetImmortal(immortal bool) {
+	p.immortal = immortal
+}
+
+// IsImmortal returns true if Haunteed never loses lives.
+func (p *Haunteed) IsImmortal() bool {
+	return p.immortal
+}
+
 // LoseLife reduces Haunteed's lives by 1.
 func (p *Haunteed) LoseLife() {
 	if time.Since(p.lastHitTime) < hitCooldown {
 		return // Still in cooldown period
 	}
+	if p.immortal {
+		p.lastHitTime = time.Now()
+		return
+	}
 
 	if p.lives > 0 {
 		p.lives--
+		p.deaths++
 		p.lastHitTime = time.Now()
 	}
 }
 
+// Deaths returns the number of lives Haunteed has lost.
+func (p *Haunteed) Deaths() int {
+	return p.deaths
+}
+
 // IsDead returns true if Haunteed has no lives left.
 func (p *Haunteed) IsDead() bool {
 	return p.lives <= 0
@@ -135,6 +195,25 @@ func (p *Haunteed) AddLife() {
 	p.lives++
 }
 
+// AddPickup puts a pickup into Haunteed's pocket.
+func (p *Haunteed) AddPickup(kind Pickup) {
+	p.pocket[kind]++
+}
+
+// UsePickup takes a pickup out of the pocket, it returns false if there is none.
+func (p *Haunteed) UsePickup(kind Pickup) bool {
+	if p.pocket[kind] <= 0 {
+		return false
+	}
+	p.pocket[kind]--
+	return true
+}
+
+// Pickups returns the number of pickups of the kind in the pocket.
+func (p *Haunteed) Pickups(kind Pickup) int {
+	return p.pocket[kind]
+}
+
 func (h *Haunteed) Render(size string) []string {
 	isBright := (time.Now().UnixNano()/int64(time.Millisecond)/500)%2 == 0
 	if isBright {
@@ -145,6 +224,7 @@ func (h *Haunteed) Render(size string) []string {
 
 func (h *Haunteed) SetHaunteedSprites(spriteSize string) {
 	brightStyle, dimStyle := getHaunteedStyle()
+	h.brightSprite, h.dimSprite = nil, nil
 
 	for _, s := range getHaunteedSprite(spriteSize) {
 		h.brightSprite = append(h.brightSprite, brightStyle.Render(s))
@@ -159,7 +239,7 @@ func (h *Haunteed) SetHaunteedSprites(spriteSize string) {
 const haunteedMaxBrightnessFloor = 1000
 
 func getHaunteedStyle() (brightStyle, dimStyle lipgloss.Style) {
-	color := style.RGBColor["yellow"]
+	color := style.RGBColor[style.HaunteedColor()]
 	brightR, dimR := style.FloorColorShift(color.R, haunteedMaxBrightnessFloor)
 	brightG, dimG := style.FloorColorShift(color.G, haunteedMaxBrightnessFloor)
 	brightB, dimB := style.FloorColorShift(color.B, haunteedMaxBrightnessFloor)
@@ -0,0 +1,92 @@
+package dweller
+
+import (
+	"sort"
+
+	"github.com/vinser/haunteed/internal/floor"
+)
+
+// surroundDepth is how far down each way out of the haunteed's cell the ghost king posts a ghost.
+const surroundDepth = 4
+
+// surroundTargets plans the hunt of the ghost king: the chasing ghost closest to the haunteed goes straight for it,
+// the others are posted at the ends of the other ways out, so the haunteed runs into one of them whichever way it turns.
+// Ghosts left without a way to cut off go straight for the haunteed too.
+func surroundTargets(ghosts []*Ghost, f *floor.Floor, ht Position) map[*Ghost]Position {
+	var hunters []*Ghost
+	for _, g := range ghosts {
+		if g.state == Chase && !g.controlled {
+			hunters = append(hunters, g)
+		}
+	}
+	targets := make(map[*Ghost]Position, len(hunters))
+	if len(hunters) == 0 {
+		return targets
+	}
+	sort.SliceStable(hunters, func(i, j int) bool {
+		return manhattan(hunters[i].position, ht) < manhattan(hunters[j].position, ht)
+	})
+	targets[hunters[0]] = ht
+
+	// Each way out is taken by the free ghost closest to its end, the closest pairs first
+	exits := wayOuts(f, ht)
+	for len(exits) > 0 {
+		bestGhost, bestExit := -1, -1
+		for i, g := range hunters {
+			if _, taken := targets[g]; taken {
+				continue
+			}
+			for j, e := range exits {
+				if bestGhost < 0 || manhattan(g.position, e) < manhattan(hunters[bestGhost].position, exits[bestExit]) {
+					bestGhost, bestExit = i, j
+				}
+			}
+		}
+		if bestGhost < 0 {
+			break // Every ghost has its post
+		}
+		targets[hunters[bestGhost]] = exits[bestExit]
+		exits = append(exits[:bestExit], exits[bestExit+1:]...)
+	}
+	for _, g := range hunters {
+		if _, ok := targets[g]; !ok {
+			targets[g] = ht
+		}
+	}
+	return targets
+}
+
+// wayOuts returns the cell surroundDepth steps down each way out of the haunteed's cell,
+// or the farthest one if the way is shorter. The haunteed's cell itself is never passed.
+func wayOuts(f *floor.Floor, ht Position) []Position {
+	open := func(p Position) bool {
+		tile, err := f.ItemAt(p.X, p.Y)
+		return err == nil && tile != floor.Wall && tile != floor.CrumblingWall && !f.InDen(p.X, p.Y)
+	}
+	var exits []Position
+	for _, d := range []Direction{Up, Down, Left, Right} {
+		first := ht.moveIn(d)
+		if !open(first) {
+			continue
+		}
+		depth := map[Position]int{ht: 0, first: 1}
+		queue := []Position{first}
+		end := first
+		for head := 0; head < len(queue); head++ {
+			cur := queue[head]
+			end = cur
+			if depth[cur] == surroundDepth {
+				continue
+			}
+			for _, dd := range []Direction{Up, Down, Left, Right} {
+				next := cur.moveIn(dd)
+				if _, seen := depth[next]; !seen && open(next) {
+					depth[next] = depth[cur] + 1
+					queue = append(queue, next)
+				}
+			}
+		}
+		exits = append(exits, end)
+	}
+	return exits
+}
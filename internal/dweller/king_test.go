@@ -0,0 +1,54 @@
+package dweller
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/vinser/haunteed/internal/floor"
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestSurroundTargets(t *testing.T) {
+	f := floor.New(1, 1, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeEasy, state.NightNever, false)
+	// A junction with at least three ways out
+	var ht Position
+	for y := 1; y < f.Maze.Height()-1 && ht == (Position{}); y++ {
+		for x := 1; x < f.Maze.Width()-1; x++ {
+			if len(wayOuts(f, Position{X: x, Y: y})) >= 3 && !f.InDen(x, y) {
+				if item, _ := f.ItemAt(x, y); item != floor.Wall && item != floor.CrumblingWall {
+					ht = Position{X: x, Y: y}
+					break
+				}
+			}
+		}
+	}
+	if ht == (Position{}) {
+		t.Fatal("no junction on the floor")
+	}
+	rng := rand.New(rand.NewSource(1))
+	var ghosts []*Ghost
+	for i, p := range []Position{{1, 1}, {f.Maze.Width() - 2, 1}, {1, f.Maze.Height() - 2}, {f.Maze.Width() - 2, f.Maze.Height() - 2}} {
+		g := NewGhost(GhostType(i), p, f.Maze.Width(), f.Maze.Height(), rng)
+		ghosts = append(ghosts, g)
+	}
+	targets := surroundTargets(ghosts, f, ht)
+	if len(targets) != len(ghosts) {
+		t.Fatalf("surroundTargets() posts %d ghosts, want %d", len(targets), len(ghosts))
+	}
+	exits := wayOuts(f, ht)
+	posted := make(map[Position]bool)
+	direct := 0
+	for _, target := range targets {
+		if target == ht {
+			direct++
+			continue
+		}
+		if posted[target] {
+			t.Errorf("two ghosts are posted at %v", target)
+		}
+		posted[target] = true
+	}
+	if want := len(ghosts) - min(len(exits), len(ghosts)-1); direct != want {
+		t.Errorf("%d ghosts go straight for the haunteed, want %d with %d ways out", direct, want, len(exits))
+	}
+}
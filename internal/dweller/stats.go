@@ -0,0 +1,33 @@
+package dweller
+
+// closeRange is how near a ghost has to get to the haunteed for a close call.
+const closeRange = 2
+
+// GhostStats counts what a ghost did on a floor.
+type GhostStats struct {
+	Close    int // times it got within closeRange of the haunteed
+	Eaten    int // times it was eaten
+	Traveled int // cells it moved
+}
+
+// Add returns the sum of both stats.
+func (s GhostStats) Add(o GhostStats) GhostStats {
+	return GhostStats{Close: s.Close + o.Close, Eaten: s.Eaten + o.Eaten, Traveled: s.Traveled + o.Traveled}
+}
+
+// Stats returns what the ghost did so far.
+func (g *Ghost) Stats() GhostStats {
+	return g.stats
+}
+
+// Watch counts a close call when a chasing or scattering ghost comes within closeRange of the haunteed,
+// it is not counted again until the ghost backs off. It returns true for a new close call.
+func (g *Ghost) Watch(ht Position) bool {
+	near := (g.state == Chase || g.state == Scatter) && manhattan(g.position, ht) <= closeRange
+	closeCall := near && !g.near
+	if closeCall {
+		g.stats.Close++
+	}
+	g.near = near
+	return closeCall
+}
@@ -0,0 +1,23 @@
+package dweller
+
+import "testing"
+
+func TestGhostStats(t *testing.T) {
+	g := NewGhost(Curly, Position{X: 5, Y: 1}, 10, 10, nil)
+	ht := Position{X: 1, Y: 1}
+	for range 3 {
+		g.Move() // Left
+		g.Watch(ht)
+	}
+	g.Watch(ht) // Still close, not counted again
+	g.SetState(Frightened)
+	g.SetState(Eaten)
+	g.SetState(Eaten)
+	want := GhostStats{Close: 1, Eaten: 1, Traveled: 3}
+	if got := g.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+	if got := want.Add(want); got != (GhostStats{Close: 2, Eaten: 2, Traveled: 6}) {
+		t.Errorf("Add() = %+v", got)
+	}
+}
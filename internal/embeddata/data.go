@@ -5,7 +5,7 @@ import (
 	"io/fs"
 )
 
-//go:embed about.md bosskey.json glam.json motd.json sounds.zip
+//go:embed about.md bestiary.json bosskey.json challenges.json glam.json motd.json sounds.zip
 var embeddedFS embed.FS
 
 // FS returns the embedded filesystem with access to files in this folder.
@@ -18,11 +18,21 @@ func ReadAboutMD() ([]byte, error) {
 	return embeddedFS.ReadFile("about.md")
 }
 
+// ReadChallenges returns the contents of challenges.json.
+func ReadChallenges() ([]byte, error) {
+	return embeddedFS.ReadFile("challenges.json")
+}
+
 // ReadGlamBytes returns the contents of glam.json.
 func ReadGlamBytes() ([]byte, error) {
 	return embeddedFS.ReadFile("glam.json")
 }
 
+// ReadBestiary returns the contents of bestiary.json.
+func ReadBestiary() ([]byte, error) {
+	return embeddedFS.ReadFile("bestiary.json")
+}
+
 // ReadBoss returns the contents of bosskey.json.
 func ReadBoss() ([]byte, error) {
 	return embeddedFS.ReadFile("bosskey.json")
@@ -0,0 +1,82 @@
+// Package eventlog keeps the latest game events for the event log panel under the maze.
+package eventlog
+
+import "fmt"
+
+const (
+	Size    = 5  // Number of events shown by the panel
+	History = 50 // Number of events kept for issue reports
+)
+
+// Log is a short scrolling log of game events. A nil *Log keeps nothing, all methods are nil-safe.
+type Log struct {
+	events  []string
+	visible bool
+	tees    []func(event string) // see Tee
+}
+
+// New returns an empty log with the panel hidden.
+func New() *Log {
+	return &Log{}
+}
+
+// Add appends a tersely formatted event, the oldest one scrolls out once the log is full.
+func (l *Log) Add(format string, args ...any) {
+	if l == nil {
+		return
+	}
+	event := fmt.Sprintf(format, args...)
+	l.events = append(l.events, event)
+	for _, tee := range l.tees {
+		tee(event)
+	}
+	if len(l.events) > History {
+		l.events = l.events[len(l.events)-History:]
+	}
+}
+
+// Tee passes every event added from now on to fn as well, e.g. to observers or speech.
+// Every call adds another fn.
+func (l *Log) Tee(fn func(event string)) {
+	if l == nil {
+		return
+	}
+	l.tees = append(l.tees, fn)
+}
+
+// Lines returns the events shown by the panel, the latest one last.
+func (l *Log) Lines() []string {
+	if l == nil {
+		return nil
+	}
+	return l.events[max(len(l.events)-Size, 0):]
+}
+
+// All returns all kept events, the latest one last.
+func (l *Log) All() []string {
+	if l == nil {
+		return nil
+	}
+	return l.events
+}
+
+// Clear drops all events, the panel stays as it is.
+func (l *Log) Clear() {
+	if l == nil {
+		return
+	}
+	l.events = nil
+}
+
+// Toggle shows or hides the panel.
+func (l *Log) Toggle() {
+	if l == nil {
+		return
+	}
+	l.visible = !l.visible
+}
+
+// Visible returns true if the panel is shown.
+func (l *Log) Visible() bool {
+	return l != nil && l.visible
+}
@@ -0,0 +1,48 @@
+package eventlog
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestLogScrolls(t *testing.T) {
+	l := New()
+	for i := 1; i <= Size+2; i++ {
+		l.Add("event %d", i)
+	}
+	var want []string
+	for i := 3; i <= Size+2; i++ {
+		want = append(want, fmt.Sprintf("event %d", i))
+	}
+	if got := l.Lines(); !slices.Equal(got, want) {
+		t.Errorf("Lines() = %q, want %q", got, want)
+	}
+	if got := len(l.All()); got != Size+2 {
+		t.Errorf("All() keeps %d events, want %d", got, Size+2)
+	}
+	l.Clear()
+	if got := l.Lines(); len(got) != 0 {
+		t.Errorf("Lines() after Clear() = %q, want none", got)
+	}
+}
+
+func TestLogHistory(t *testing.T) {
+	l := New()
+	for i := 1; i <= History+10; i++ {
+		l.Add("event %d", i)
+	}
+	all := l.All()
+	if len(all) != History || all[0] != "event 11" {
+		t.Errorf("All() = %d events from %q, want %d from %q", len(all), all[0], History, "event 11")
+	}
+}
+
+func TestNilLog(t *testing.T) {
+	var l *Log
+	l.Add("ate pellet")
+	l.Toggle()
+	if l.Visible() || l.Lines() != nil {
+		t.Errorf("nil log keeps events or shows the panel")
+	}
+}
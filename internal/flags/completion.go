@@ -0,0 +1,143 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Completion writes a completion script for the shell generated from the flag registry
+func Completion(shell string, w io.Writer) error {
+	switch shell {
+	case "bash":
+		bashCompletion(w)
+	case "zsh":
+		zshCompletion(w)
+	case "fish":
+		fishCompletion(w)
+	default:
+		return fmt.Errorf("unsupported shell: %s. Use 'bash', 'zsh' or 'fish'", shell)
+	}
+	return nil
+}
+
+// flagNames returns the short and long forms of a flag as typed on the command line
+func flagNames(f FlagInfo) []string {
+	names := []string{"-" + f.Name}
+	if f.Short != "" {
+		names = append([]string{"-" + f.Short}, names...)
+	}
+	return names
+}
+
+func commandNames() []string {
+	var names []string
+	for _, c := range Commands {
+		names = append(names, c.Name)
+	}
+	return names
+}
+
+func bashCompletion(w io.Writer) {
+	var words []string
+	fmt.Fprintln(w, "# bash completion for haunteed")
+	fmt.Fprintln(w, "_haunteed() {")
+	fmt.Fprintln(w, "\tlocal cur prev")
+	fmt.Fprintln(w, "\tcur=\"${COMP_WORDS[COMP_CWORD]}\"")
+	fmt.Fprintln(w, "\tprev=\"${COMP_WORDS[COMP_CWORD-1]}\"")
+	fmt.Fprintln(w, "\tcase \"$prev\" in")
+	for _, f := range Registry() {
+		words = append(words, flagNames(f)...)
+		if len(f.Values) > 0 {
+			fmt.Fprintf(w, "\t%s)\n", strings.Join(flagNames(f), "|"))
+			fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n\t\treturn\n\t\t;;\n", strings.Join(f.Values, " "))
+		}
+	}
+	for _, c := range Commands {
+		if len(c.Args) > 0 {
+			fmt.Fprintf(w, "\t%s)\n", c.Name)
+			fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n\t\treturn\n\t\t;;\n", strings.Join(c.Args, " "))
+		}
+	}
+	fmt.Fprintln(w, "\tesac")
+	fmt.Fprintln(w, "\tif [[ $COMP_CWORD -eq 1 && \"$cur\" != -* ]]; then")
+	fmt.Fprintf(w, "\t\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(commandNames(), " "))
+	fmt.Fprintln(w, "\t\treturn")
+	fmt.Fprintln(w, "\tfi")
+	fmt.Fprintf(w, "\tCOMPREPLY=($(compgen -W %q -- \"$cur\"))\n", strings.Join(words, " "))
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -F _haunteed haunteed")
+}
+
+// zshEscape escapes text for zsh _arguments and _describe specs in single quotes
+func zshEscape(s string) string {
+	s = strings.NewReplacer("'", `'\''`, "[", `\[`, "]", `\]`, ":", `\:`).Replace(s)
+	return s
+}
+
+func zshCompletion(w io.Writer) {
+	fmt.Fprintln(w, "#compdef haunteed")
+	fmt.Fprintln(w, "_haunteed() {")
+	fmt.Fprintln(w, "\tlocal -a commands")
+	fmt.Fprint(w, "\tcommands=(")
+	for _, c := range Commands {
+		fmt.Fprintf(w, " '%s:%s'", c.Name, zshEscape(c.Usage))
+	}
+	fmt.Fprintln(w, " )")
+	fmt.Fprintln(w, "\t_arguments \\")
+	for _, f := range Registry() {
+		names := flagNames(f)
+		spec := fmt.Sprintf("'%s[%s]'", names[len(names)-1], zshEscape(f.Usage))
+		if len(names) > 1 {
+			spec = fmt.Sprintf("'(%s)'{%s}'[%s]'", strings.Join(names, " "), strings.Join(names, ","), zshEscape(f.Usage))
+		}
+		if !f.IsBool {
+			spec += fmt.Sprintf("':%s:(%s)'", f.Name, strings.Join(f.Values, " "))
+		}
+		fmt.Fprintf(w, "\t\t%s \\\n", spec)
+	}
+	fmt.Fprintln(w, "\t\t'1: :->command' \\")
+	fmt.Fprintln(w, "\t\t'*:: :->args'")
+	fmt.Fprintln(w, "\tcase $state in")
+	fmt.Fprintln(w, "\tcommand)")
+	fmt.Fprintln(w, "\t\t_describe 'command' commands")
+	fmt.Fprintln(w, "\t\t;;")
+	fmt.Fprintln(w, "\targs)")
+	fmt.Fprintln(w, "\t\tcase $words[1] in")
+	for _, c := range Commands {
+		if len(c.Args) > 0 {
+			fmt.Fprintf(w, "\t\t%s)\n\t\t\t_values '%s' %s\n\t\t\t;;\n", c.Name, c.Name, strings.Join(c.Args, " "))
+		}
+	}
+	fmt.Fprintln(w, "\t\tesac")
+	fmt.Fprintln(w, "\t\t;;")
+	fmt.Fprintln(w, "\tesac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "_haunteed \"$@\"")
+}
+
+// fishEscape escapes text for single quoted fish strings
+func fishEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s)
+}
+
+func fishCompletion(w io.Writer) {
+	fmt.Fprintln(w, "# fish completion for haunteed")
+	fmt.Fprintln(w, "complete -c haunteed -f")
+	for _, c := range Commands {
+		fmt.Fprintf(w, "complete -c haunteed -n __fish_use_subcommand -a %s -d '%s'\n", c.Name, fishEscape(c.Usage))
+		if len(c.Args) > 0 {
+			fmt.Fprintf(w, "complete -c haunteed -n '__fish_seen_subcommand_from %s' -a '%s'\n", c.Name, strings.Join(c.Args, " "))
+		}
+	}
+	for _, f := range Registry() {
+		spec := "-o " + f.Name
+		if f.Short != "" {
+			spec = "-o " + f.Short + " " + spec
+		}
+		if len(f.Values) > 0 {
+			spec += fmt.Sprintf(" -x -a '%s'", strings.Join(f.Values, " "))
+		}
+		fmt.Fprintf(w, "complete -c haunteed %s -d '%s'\n", spec, fishEscape(f.Usage))
+	}
+}
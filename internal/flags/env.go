@@ -0,0 +1,87 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+)
+
+// EnvVar maps an environment variable to the global flag it stands for
+type EnvVar struct {
+	Name string
+	Flag string
+}
+
+// EnvVars lists environment variables honored between the saved settings and command-line flags,
+// handy for containers and kiosks where editing flags is awkward
+var EnvVars = []EnvVar{
+	{Name: "HAUNTEED_MODE", Flag: "game-mode"},
+	{Name: "HAUNTEED_NIGHT", Flag: "night-option"},
+	{Name: "HAUNTEED_SPRITE", Flag: "sprite-size"},
+	{Name: "HAUNTEED_MUTE", Flag: "mute"},
+	{Name: "HAUNTEED_DEV", Flag: "dev"},
+	{Name: "HAUNTEED_NO_NETWORK", Flag: "no-network"},
+	{Name: "HAUNTEED_NO_SPLASH", Flag: "no-splash"},
+	{Name: "HAUNTEED_PLAINTEXT_STATE", Flag: "plaintext-state"},
+	{Name: "HAUNTEED_STREAMER", Flag: "streamer"},
+}
+
+// Env reads global flags from the environment, it returns nil if none of EnvVars is set
+func Env() (*Flags, error) {
+	f := &Flags{}
+	fs := NewFlagSetWithVisit()
+	define(fs, f)
+	set := false
+	for _, v := range EnvVars {
+		value, ok := os.LookupEnv(v.Name)
+		if !ok || value == "" {
+			continue
+		}
+		if err := fs.fs.Set(v.Flag, value); err != nil {
+			return nil, fmt.Errorf("Invalid %s: %s", v.Name, value)
+		}
+		set = true
+	}
+	if !set {
+		return nil, nil
+	}
+	if err := normalize(f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// overrides copies each global flag from the flags given to the ones taken, see Merge
+var overrides = map[string]func(dst, src *Flags){
+	"game-mode":        func(dst, src *Flags) { dst.Mode = src.Mode },
+	"night-option":     func(dst, src *Flags) { dst.Night = src.Night },
+	"sprite-size":      func(dst, src *Flags) { dst.Sprite = src.Sprite },
+	"mute":             func(dst, src *Flags) { dst.Mute = src.Mute },
+	"reset":            func(dst, src *Flags) { dst.Reset = src.Reset },
+	"version":          func(dst, src *Flags) { dst.Version = src.Version },
+	"dev":              func(dst, src *Flags) { dst.Dev = src.Dev },
+	"no-network":       func(dst, src *Flags) { dst.NoNetwork = src.NoNetwork },
+	"telemetry-export": func(dst, src *Flags) { dst.TelemetryExport = src.TelemetryExport },
+	"no-splash":        func(dst, src *Flags) { dst.NoSplash = src.NoSplash },
+	"plaintext-state":  func(dst, src *Flags) { dst.PlaintextState = src.PlaintextState },
+	"streamer":         func(dst, src *Flags) { dst.Streamer = src.Streamer },
+	"observer":         func(dst, src *Flags) { dst.Observer = src.Observer },
+	"record-cast":      func(dst, src *Flags) { dst.RecordCast = src.RecordCast },
+}
+
+// Merge returns base with the flags that set reports as given taken from over, either may be nil.
+// Only flags given explicitly override, so -mute=false turns off HAUNTEED_MUTE=1.
+func Merge(base, over *Flags, set func(name string) bool) *Flags {
+	if base == nil {
+		return over
+	}
+	if over == nil {
+		return base
+	}
+	merged := *base
+	for name, override := range overrides {
+		if set(name) {
+			override(&merged, over)
+		}
+	}
+	return &merged
+}
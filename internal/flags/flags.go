@@ -3,6 +3,7 @@ package flags
 import (
 	"fmt"
 	"os"
+	"slices"
 	"strings"
 )
 
@@ -14,72 +15,187 @@ type Flags struct {
 	Mute    bool
 	Reset   bool
 	Version bool
+	Dev     bool
+	// NoNetwork disables location lookups and update checks
+	NoNetwork bool
+	// TelemetryExport prints collected gameplay stats and exits
+	TelemetryExport bool
+	// RecordCast is the asciinema cast file to record the session to
+	RecordCast string
+	// NoSplash starts the game right away without the splash screen
+	NoSplash bool
+	// PlaintextState saves the state as readable JSON from now on
+	PlaintextState bool
+	// Observer is the Unix socket to serve game events and take commands on
+	Observer string
+	// Streamer hides personal details on screen: the location, the nickname being typed and home paths
+	Streamer bool
 }
 
-// Parse parses command-line flags and returns the resulting config
-func Parse() (*Flags, bool) {
-	// Custom flag variables
-	var mode string
-	var night string
-	var sprite string
-	var mute bool
-	var reset bool
-	var version bool
+// Command describes a subcommand for usage, completion and man page output
+type Command struct {
+	Name  string
+	Usage string
+	Args  []string // Allowed values of the single argument, if any, they may look like flags
+}
+
+// DefaultCommand runs when no subcommand is given
+const DefaultCommand = "play"
+
+// Commands lists subcommands, global flags are shared by all of them
+var Commands = []Command{
+	{Name: "play", Usage: "Play the game (default)"},
+	{Name: "simulate", Usage: "Run ghosts on a floor headless and report captures: simulate [floor] [moves]"},
+	{Name: "balance", Usage: "Play seeded bot games per mode and compare with the baseline: balance [--save] [baseline]", Args: []string{"--save"}},
+	{Name: "config", Usage: "Show settings or change one: config [set key value]", Args: []string{"show", "set"}},
+	{Name: "export", Usage: "Print high scores and floor seeds as JSON"},
+	{Name: "snapshot", Usage: "Print a reached floor as plain text: snapshot [floor]"},
+	{Name: "card", Usage: "Print the share card of the last finished run: card [--last]", Args: []string{"--last"}},
+	{Name: "doctor", Usage: "Check terminal, audio, network and data directory"},
+	{Name: "paths", Usage: "Print the config, data and cache directories"},
+	{Name: "update", Usage: "Download the latest release and replace the running binary"},
+	{Name: "completion", Usage: "Print shell completion script", Args: []string{"bash", "zsh", "fish"}},
+	{Name: "man", Usage: "Print man page"},
+}
+
+// Invocation is the parsed command line
+type Invocation struct {
+	Command string   // Subcommand, DefaultCommand if none is given
+	Args    []string // Subcommand arguments
+	Flags   *Flags   // Global flags, nil if none were set
+}
+
+func isCommand(name string) bool {
+	for _, c := range Commands {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// isCommandArg returns true if arg is one of the allowed argument values of the command
+func isCommandArg(command, arg string) bool {
+	for _, c := range Commands {
+		if c.Name == command {
+			return slices.Contains(c.Args, arg)
+		}
+	}
+	return false
+}
+
+// define registers game flags bound to f
+func define(fs *FlagSetWithVisit, f *Flags) {
+	// Define flags with both short and long forms
+	fs.EnumVar(&f.Mode, "game-mode", "g", "", []string{"easy", "noisy", "crazy"}, "Game mode: easy (default), noisy, or crazy")
+	fs.EnumVar(&f.Night, "night-option", "n", "", []string{"never", "always", "real"}, "Night option for crazy mode: never, always or real (default)")
+	fs.EnumVar(&f.Sprite, "sprite-size", "s", "", []string{"small", "medium", "large"}, "Sprite size: small, medium (default), or large")
+	fs.BoolVar(&f.Mute, "mute", "m", false, "Mute all sounds")
+	fs.BoolVar(&f.Reset, "reset", "r", false, "Reset saved progress and settings")
+	fs.BoolVar(&f.Version, "version", "v", false, "Show application version")
+	fs.BoolVar(&f.Dev, "dev", "", false, "Enable developer tools such as the ghost view overlay (g) and simulated color vision (F7)")
+	fs.BoolVar(&f.NoNetwork, "no-network", "", false, "Never touch the network: no location lookup and no update check")
+	fs.BoolVar(&f.TelemetryExport, "telemetry-export", "", false, "Print locally collected gameplay stats as JSON to share them")
+	fs.BoolVar(&f.NoSplash, "no-splash", "", false, "Skip the splash screen and start playing right away")
+	fs.BoolVar(&f.PlaintextState, "plaintext-state", "", false, "Save progress and settings as readable JSON instead of encrypting them")
+	fs.BoolVar(&f.Streamer, "streamer", "", false, "Streamer mode: hide the location, the nickname being typed and home paths on screen")
+	fs.StringVar(&f.Observer, "observer", "", "", "Stream game events as JSON lines and take commands on a Unix socket, e.g. /tmp/haunteed.sock")
+	fs.StringVar(&f.RecordCast, "record-cast", "", "", "Record the session to an asciinema cast file, e.g. out.cast")
+}
+
+// Registry returns descriptions of all game flags sorted by name
+func Registry() []FlagInfo {
+	fs := NewFlagSetWithVisit()
+	define(fs, &Flags{})
+	return fs.Flags()
+}
+
+// Parse parses the command line without the program name.
+// Global flags may come before or after the subcommand, they override environment variables (see EnvVars).
+func Parse(args []string) *Invocation {
+	f := &Flags{}
 
 	// Create custom FlagSet to allow custom usage output
 	fs := NewFlagSetWithVisit()
+	define(fs, f)
 
-	// Define flags with both short and long forms
-	fs.StringVar(&mode, "game-mode", "g", "", "Game mode: easy (default), noisy, or crazy")
-	fs.StringVar(&night, "night-option", "n", "", "Night option for crazy mode: never, always or real (default)")
-	fs.StringVar(&sprite, "sprite-size", "s", "", "Sprite size: small, medium (default), or large")
-	fs.BoolVar(&mute, "mute", "m", false, "Mute all sounds")
-	fs.BoolVar(&reset, "reset", "r", false, "Reset saved progress and settings")
-	fs.BoolVar(&version, "version", "v", false, "Show application version")
+	inv := &Invocation{Command: DefaultCommand}
+	var flagArgs []string
+	commandSet := false
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		switch {
+		case arg == "--":
+			inv.Args = append(inv.Args, args[i+1:]...)
+			i = len(args)
+		case commandSet && isCommandArg(inv.Command, arg):
+			inv.Args = append(inv.Args, arg)
+		case strings.HasPrefix(arg, "-"):
+			flagArgs = append(flagArgs, arg)
+			if fs.needsValue(arg) && i+1 < len(args) {
+				i++
+				flagArgs = append(flagArgs, args[i])
+			}
+		case !commandSet:
+			if !isCommand(arg) {
+				fmt.Fprintf(os.Stderr, "Unknown command: %s\n", arg)
+				fs.Usage()
+				os.Exit(1)
+			}
+			inv.Command = arg
+			commandSet = true
+		default:
+			inv.Args = append(inv.Args, arg)
+		}
+	}
 
 	// Parse command-line flags
-	fs.Parse(os.Args[1:])
+	fs.Parse(flagArgs)
+
+	env, err := Env()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if len(fs.visited) == 0 {
+		inv.Flags = env
+		return inv
+	}
 
-	if !fs.HasCustom() {
-		return nil, false
+	if err := normalize(f); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		fs.Usage()
+		os.Exit(1)
 	}
+	inv.Flags = Merge(env, f, fs.IsCustom)
 
+	return inv
+}
+
+// normalize lower-cases enum values and checks them
+func normalize(f *Flags) error {
 	// Normalize mode value
-	if fs.IsCustom("game-mode") {
-		mode = strings.ToLower(mode)
-		if mode != "easy" && mode != "noisy" && mode != "crazy" && mode != "test" {
-			fmt.Fprintf(os.Stderr, "Invalid game mode: %s. Use 'easy', 'noisy', or 'crazy'.\n", mode)
-			fs.Usage()
-			os.Exit(1)
+	if f.Mode != "" {
+		f.Mode = strings.ToLower(f.Mode)
+		if f.Mode != "easy" && f.Mode != "noisy" && f.Mode != "crazy" && f.Mode != "test" {
+			return fmt.Errorf("Invalid game mode: %s. Use 'easy', 'noisy', or 'crazy'.", f.Mode)
 		}
 	}
 
 	// Normalize crazy-night value
-	if fs.IsCustom("night-option") {
-		night = strings.ToLower(night)
-		if night != "never" && night != "always" && night != "real" {
-			fmt.Fprintf(os.Stderr, "Invalid night option: %s. Use 'never', 'always', or 'real'.\n", night)
-			fs.Usage()
-			os.Exit(1)
+	if f.Night != "" {
+		f.Night = strings.ToLower(f.Night)
+		if f.Night != "never" && f.Night != "always" && f.Night != "real" {
+			return fmt.Errorf("Invalid night option: %s. Use 'never', 'always', or 'real'.", f.Night)
 		}
 	}
 
 	// Normalize sprite size value
-	if fs.IsCustom("sprite-size") {
-		sprite = strings.ToLower(sprite)
-		if sprite != "" && sprite != "small" && sprite != "medium" && sprite != "large" {
-			fmt.Fprintf(os.Stderr, "Invalid sprite size: %s. Use 'small', 'medium' or 'large'.\n", sprite)
-			fs.Usage()
-			os.Exit(1)
+	if f.Sprite != "" {
+		f.Sprite = strings.ToLower(f.Sprite)
+		if f.Sprite != "small" && f.Sprite != "medium" && f.Sprite != "large" {
+			return fmt.Errorf("Invalid sprite size: %s. Use 'small', 'medium' or 'large'.", f.Sprite)
 		}
 	}
-
-	return &Flags{
-		Mode:    mode,
-		Night:   night,
-		Sprite:  sprite,
-		Mute:    mute,
-		Reset:   reset,
-		Version: version,
-	}, true
+	return nil
 }
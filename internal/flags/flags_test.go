@@ -0,0 +1,90 @@
+package flags
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		command  string
+		cmdArgs  []string
+		wantMode string
+	}{
+		{"default command", nil, DefaultCommand, nil, ""},
+		{"flags only", []string{"-g", "crazy"}, DefaultCommand, nil, "crazy"},
+		{"flags before command", []string{"-game-mode", "noisy", "snapshot", "3"}, "snapshot", []string{"3"}, "noisy"},
+		{"flags after command", []string{"simulate", "2", "-m", "-g=crazy", "100"}, "simulate", []string{"2", "100"}, "crazy"},
+		{"terminator", []string{"config", "--", "set", "-x"}, "config", []string{"set", "-x"}, ""},
+		{"command option", []string{"card", "--last", "-g", "noisy"}, "card", []string{"--last"}, "noisy"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv := Parse(tt.args)
+			if inv.Command != tt.command {
+				t.Errorf("Command = %q, want %q", inv.Command, tt.command)
+			}
+			if !slices.Equal(inv.Args, tt.cmdArgs) {
+				t.Errorf("Args = %q, want %q", inv.Args, tt.cmdArgs)
+			}
+			mode := ""
+			if inv.Flags != nil {
+				mode = inv.Flags.Mode
+			}
+			if mode != tt.wantMode {
+				t.Errorf("Mode = %q, want %q", mode, tt.wantMode)
+			}
+		})
+	}
+}
+
+func TestEnvLayer(t *testing.T) {
+	t.Setenv("HAUNTEED_MODE", "Crazy")
+	t.Setenv("HAUNTEED_MUTE", "1")
+	t.Setenv("HAUNTEED_SPRITE", "large")
+
+	inv := Parse([]string{"-s", "small"})
+	if inv.Flags == nil {
+		t.Fatal("expected flags from the environment")
+	}
+	if inv.Flags.Mode != "crazy" || !inv.Flags.Mute {
+		t.Errorf("environment not applied: %+v", *inv.Flags)
+	}
+	if inv.Flags.Sprite != "small" {
+		t.Errorf("Sprite = %q, flag should override the environment", inv.Flags.Sprite)
+	}
+
+	t.Setenv("HAUNTEED_NO_SPLASH", "true")
+	if inv := Parse(nil); inv.Flags == nil || !inv.Flags.NoSplash {
+		t.Error("HAUNTEED_NO_SPLASH not applied")
+	}
+
+	t.Setenv("HAUNTEED_PLAINTEXT_STATE", "true")
+	if inv := Parse(nil); inv.Flags == nil || !inv.Flags.PlaintextState {
+		t.Error("HAUNTEED_PLAINTEXT_STATE not applied")
+	}
+
+	t.Setenv("HAUNTEED_DEV", "1")
+	inv = Parse([]string{"-mute=false", "-dev=false", "-no-splash=false"})
+	if inv.Flags == nil || inv.Flags.Mute || inv.Flags.Dev || inv.Flags.NoSplash {
+		t.Errorf("false flags should override the environment: %+v", inv.Flags)
+	}
+	if !inv.Flags.PlaintextState || inv.Flags.Mode != "crazy" {
+		t.Errorf("environment not given on the command line should stay: %+v", *inv.Flags)
+	}
+
+	t.Setenv("HAUNTEED_MUTE", "maybe")
+	if _, err := Env(); err == nil {
+		t.Error("expected error for invalid HAUNTEED_MUTE")
+	}
+}
+
+func TestMergeCoversFlags(t *testing.T) {
+	for _, f := range Registry() {
+		if _, ok := overrides[f.Name]; !ok {
+			t.Errorf("flag %s can't override the environment", f.Name)
+		}
+	}
+}
@@ -5,13 +5,25 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strings"
 )
 
 type FlagSetWithVisit struct {
 	fs       *flag.FlagSet
 	visited  map[string]bool
-	aliases  map[string]string // short name → long name
-	usageMap map[string]string // long name → usage string
+	aliases  map[string]string   // short name → long name
+	usageMap map[string]string   // long name → usage string
+	enums    map[string][]string // long name → allowed values
+	bools    map[string]bool     // long names of bool flags
+}
+
+// FlagInfo describes a registered flag for completion and man page generators
+type FlagInfo struct {
+	Name   string
+	Short  string
+	Usage  string
+	Values []string // Allowed values of an enum flag
+	IsBool bool
 }
 
 func NewFlagSetWithVisit() *FlagSetWithVisit {
@@ -22,6 +34,8 @@ func NewFlagSetWithVisit() *FlagSetWithVisit {
 		visited:  make(map[string]bool),
 		aliases:  make(map[string]string),
 		usageMap: make(map[string]string),
+		enums:    make(map[string][]string),
+		bools:    make(map[string]bool),
 	}
 
 	// Override default usage
@@ -40,6 +54,7 @@ func (fsv *FlagSetWithVisit) BoolVar(p *bool, name, short string, value bool, us
 		fsv.aliases[short] = name
 	}
 	fsv.usageMap[name] = usage
+	fsv.bools[name] = true
 }
 
 // Register a string flag with optional short alias
@@ -51,6 +66,53 @@ func (fsv *FlagSetWithVisit) StringVar(p *string, name, short, value, usage stri
 	fsv.usageMap[name] = usage
 }
 
+// Register a string flag with a fixed set of values and optional short alias
+func (fsv *FlagSetWithVisit) EnumVar(p *string, name, short, value string, values []string, usage string) {
+	fsv.StringVar(p, name, short, value, usage)
+	fsv.enums[name] = values
+}
+
+// Flags returns descriptions of registered flags sorted by name
+func (fsv *FlagSetWithVisit) Flags() []FlagInfo {
+	var infos []FlagInfo
+	for name, usage := range fsv.usageMap {
+		infos = append(infos, FlagInfo{
+			Name:   name,
+			Short:  fsv.shortName(name),
+			Usage:  usage,
+			Values: fsv.enums[name],
+			IsBool: fsv.bools[name],
+		})
+	}
+	sort.Slice(infos, func(i, j int) bool {
+		return infos[i].Name < infos[j].Name
+	})
+	return infos
+}
+
+// shortName returns the short alias of a flag or empty string
+func (fsv *FlagSetWithVisit) shortName(name string) string {
+	for s, full := range fsv.aliases {
+		if full == name {
+			return s
+		}
+	}
+	return ""
+}
+
+// needsValue reports whether the flag argument takes the next argument as its value
+func (fsv *FlagSetWithVisit) needsValue(arg string) bool {
+	name := strings.TrimLeft(arg, "-")
+	if strings.Contains(name, "=") {
+		return false
+	}
+	if full, ok := fsv.aliases[name]; ok {
+		name = full
+	}
+	_, known := fsv.usageMap[name]
+	return known && !fsv.bools[name]
+}
+
 // Expand short aliases and parse args
 func (fsv *FlagSetWithVisit) Parse(args []string) error {
 	args = fsv.expandAliases(args)
@@ -132,17 +194,15 @@ func (fsv *FlagSetWithVisit) printUsage() {
 	sort.Strings(names)
 	for _, name := range names {
 		usage := fsv.usageMap[name]
-		short := ""
-		for s, full := range fsv.aliases {
-			if full == name {
-				short = s
-				break
-			}
-		}
+		short := fsv.shortName(name)
 		if short != "" {
 			fmt.Fprintf(os.Stderr, "  -%s, -%-*s\t%s\n", short, nameLen, name, usage)
 		} else {
 			fmt.Fprintf(os.Stderr, "      -%-*s\t%s\n", nameLen, name, usage)
 		}
 	}
+	fmt.Fprintf(os.Stderr, "Commands:\n")
+	for _, c := range Commands {
+		fmt.Fprintf(os.Stderr, "  %-*s\t%s\n", nameLen+4, c.Name, c.Usage)
+	}
 }
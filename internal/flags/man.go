@@ -0,0 +1,82 @@
+package flags
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// roffEscape escapes text for a roff line
+func roffEscape(s string) string {
+	s = strings.NewReplacer(`\`, `\e`, "-", `\-`).Replace(s)
+	if strings.HasPrefix(s, ".") || strings.HasPrefix(s, "'") {
+		s = `\&` + s
+	}
+	return s
+}
+
+// Man writes the haunteed(6) man page generated from the flag registry
+func Man(version string, w io.Writer) {
+	fmt.Fprintf(w, ".TH HAUNTEED 6 \"\" \"haunteed %s\" \"Games\"\n", roffEscape(version))
+	fmt.Fprintln(w, ".SH NAME")
+	fmt.Fprintln(w, `haunteed \- TUI night\-shift horror for sysadmins`)
+	fmt.Fprintln(w, ".SH SYNOPSIS")
+	fmt.Fprintln(w, ".B haunteed")
+	fmt.Fprintln(w, `[\fIoptions\fR]`)
+	fmt.Fprintln(w, ".br")
+	fmt.Fprintln(w, ".B haunteed")
+	fmt.Fprintln(w, `\fIcommand\fR [\fIargument\fR]`)
+	fmt.Fprintln(w, ".SH DESCRIPTION")
+	fmt.Fprintln(w, "Haunteed is a retro\\-styled maze game running in the terminal.")
+	fmt.Fprintln(w, "You are the night guard of a haunted IT facility: collect crumbs and pellets,")
+	fmt.Fprintln(w, "avoid ghosts and find the stairs to the next floor.")
+	fmt.Fprintln(w, ".SH OPTIONS")
+	for _, f := range Registry() {
+		fmt.Fprintln(w, ".TP")
+		var names []string
+		for _, n := range flagNames(f) {
+			names = append(names, `\fB`+roffEscape(n)+`\fR`)
+		}
+		line := strings.Join(names, ", ")
+		if !f.IsBool {
+			line += ` \fIvalue\fR`
+		}
+		fmt.Fprintln(w, line)
+		usage := roffEscape(f.Usage)
+		if len(f.Values) > 0 {
+			usage += ". Values: " + strings.Join(f.Values, ", ") + "."
+		}
+		fmt.Fprintln(w, usage)
+	}
+	fmt.Fprintln(w, ".SH COMMANDS")
+	for _, c := range Commands {
+		fmt.Fprintln(w, ".TP")
+		line := `\fB` + c.Name + `\fR`
+		if len(c.Args) > 0 {
+			line += ` \fI` + strings.Join(c.Args, "|") + `\fR`
+		}
+		fmt.Fprintln(w, line)
+		fmt.Fprintln(w, roffEscape(c.Usage))
+	}
+	fmt.Fprintln(w, ".SH ENVIRONMENT")
+	fmt.Fprintln(w, "Environment variables override saved settings and are overridden by command\\-line flags.")
+	for _, v := range EnvVars {
+		fmt.Fprintln(w, ".TP")
+		fmt.Fprintf(w, "\\fB%s\\fR\n", roffEscape(v.Name))
+		fmt.Fprintf(w, "Same as \\fB\\-%s\\fR.\n", roffEscape(v.Flag))
+	}
+	fmt.Fprintln(w, ".SH FILES")
+	fmt.Fprintln(w, "Files are kept in the XDG base directories, \\fBhaunteed paths\\fR prints them.")
+	fmt.Fprintln(w, "\\fBHAUNTEED_CONFIG_DIR\\fR, \\fBHAUNTEED_DATA_DIR\\fR and \\fBHAUNTEED_CACHE_DIR\\fR move them elsewhere.")
+	fmt.Fprintln(w, ".TP")
+	fmt.Fprintln(w, `\fI$XDG_DATA_HOME/haunteed/state.dat\fR`)
+	fmt.Fprintln(w, "Encrypted settings, floor seeds and high scores, the previous three are kept as state.dat.1 to state.dat.3.")
+	fmt.Fprintln(w, ".TP")
+	fmt.Fprintln(w, `\fI$XDG_DATA_HOME/haunteed/telemetry.json\fR`)
+	fmt.Fprintln(w, "Opt\\-in local gameplay stats.")
+	fmt.Fprintln(w, ".TP")
+	fmt.Fprintln(w, `\fI$XDG_DATA_HOME/haunteed/report\-*.md\fR`)
+	fmt.Fprintln(w, "Issue reports saved with \\fB?\\fR from the pause menu or the settings.")
+	fmt.Fprintln(w, ".SH BUGS")
+	fmt.Fprintln(w, "https://github.com/vinser/haunteed/issues")
+}
@@ -0,0 +1,36 @@
+package floor
+
+import (
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestBeam(t *testing.T) {
+	f := New(1, 1, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeNoisy, state.NightNever, false)
+	start := f.Maze.Start()
+	for _, dir := range [][2]int{{0, -1}, {0, 1}, {-1, 0}, {1, 0}} {
+		f.SetBeam(start.X, start.Y, dir[0], dir[1], 5)
+		if f.InBeam(start.X, start.Y) {
+			t.Errorf("beam %v lights the cell it is shone from", dir)
+		}
+		lit := true
+		for i := 1; i <= 6; i++ {
+			x, y := start.X+dir[0]*i, start.Y+dir[1]*i
+			if item, err := f.ItemAt(x, y); err != nil || item == Wall || item == CrumblingWall || i > 5 {
+				lit = false
+			}
+			if got := f.InBeam(x, y); got != lit {
+				t.Errorf("beam %v lights cell %d: %v, want %v", dir, i, got, lit)
+			}
+		}
+	}
+	f.ClearBeam()
+	for y := range f.Items {
+		for x := range f.Items[y] {
+			if f.InBeam(x, y) {
+				t.Fatalf("cell (%d, %d) is lit after the beam is put out", x, y)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package floor
+
+import "github.com/vinser/maze"
+
+// Den is a room where ghosts wait to be released.
+// The first den of a floor is the one in the middle of the maze, the others send reinforcements.
+type Den struct {
+	X, Y          int        // top-left cell of the area the ghosts are placed in
+	Width, Height int        // size of the area the ghosts are placed in
+	Exit          maze.Point // where the ghosts head for to leave the den
+	door          maze.Point // opened once the floor is ready, see openDen
+}
+
+// Contains returns true if the cell is inside the den.
+func (d Den) Contains(x, y int) bool {
+	return x >= d.X && x < d.X+d.Width && y >= d.Y && y < d.Y+d.Height
+}
+
+// cornerDenWidth is the width of a reinforcement den, it is a single row of cells.
+const cornerDenWidth = 3
+
+// mainDen returns the den in the middle of the maze of the given size.
+// The ghosts are placed in a row of its inner area and leave it through the top.
+func mainDen(width, height int) Den {
+	return Den{
+		X:      (width-DenWidth)/2 + 1,
+		Y:      (height-DenHeight)/2 + 1,
+		Width:  DenWidth - 2,
+		Height: DenHeight - 2,
+		Exit:   maze.Point{X: width / 2, Y: (height-DenHeight)/2 - 1},
+	}
+}
+
+// closeCornerDen walls off a reinforcement den in the corner of the maze farthest from the start.
+// Corners with any of the keep points nearby are skipped, it returns false if none is left.
+// The den stays closed, so nothing is placed in it, until openDen opens it on the finished floor.
+func closeCornerDen(items [][]ItemType, m *maze.Maze, keep ...*maze.Point) (Den, bool) {
+	w, h := m.Width(), m.Height()
+	corners := []Den{
+		{X: 1, Y: 1, door: maze.Point{X: cornerDenWidth, Y: 2}},
+		{X: w - 1 - cornerDenWidth, Y: 1, door: maze.Point{X: w - 1 - cornerDenWidth, Y: 2}},
+		{X: 1, Y: h - 2, door: maze.Point{X: cornerDenWidth, Y: h - 3}},
+		{X: w - 1 - cornerDenWidth, Y: h - 2, door: maze.Point{X: w - 1 - cornerDenWidth, Y: h - 3}},
+	}
+	start := m.Start()
+	best, found := Den{}, false
+	for _, d := range corners {
+		d.Width, d.Height = cornerDenWidth, 1
+		d.Exit = maze.Point{X: d.door.X, Y: 2*d.door.Y - d.Y}
+		if !clearOf(d, keep) {
+			continue
+		}
+		if !found || manhattan(d.X, d.Y, start.X, start.Y) > manhattan(best.X, best.Y, start.X, start.Y) {
+			best, found = d, true
+		}
+	}
+	if !found {
+		return Den{}, false
+	}
+
+	// The den and its walls up to the border, the cells beyond them are joined to the rest of the maze again
+	for y := best.Y - 1; y <= best.Y+best.Height; y++ {
+		for x := best.X - 1; x <= best.X+best.Width; x++ {
+			items[y][x] = Wall
+		}
+	}
+	connect(items, m, false)
+	return best, true
+}
+
+// clearOf returns true if none of the points is in the den, its walls or in front of its door.
+func clearOf(d Den, points []*maze.Point) bool {
+	for _, p := range points {
+		if p == nil {
+			continue
+		}
+		if (p.X >= d.X-1 && p.X <= d.X+d.Width && p.Y >= d.Y-1 && p.Y <= d.Y+d.Height) || *p == d.Exit {
+			return false
+		}
+	}
+	return true
+}
+
+// openDen carves the den and its door into the finished floor.
+func openDen(items [][]ItemType, d Den) {
+	for y := d.Y; y < d.Y+d.Height; y++ {
+		for x := d.X; x < d.X+d.Width; x++ {
+			items[y][x] = Empty
+		}
+	}
+	items[d.door.Y][d.door.X] = Empty
+}
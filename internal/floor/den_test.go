@@ -0,0 +1,48 @@
+package floor
+
+import (
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestGenerateCornerDen(t *testing.T) {
+	tests := []struct {
+		mode  string
+		retro bool
+		dens  int
+	}{
+		{state.ModeEasy, false, 1},
+		{state.ModeNoisy, false, 2},
+		{state.ModeCrazy, false, 2},
+		{state.ModeCrazy, true, 1},
+	}
+	for _, tt := range tests {
+		for seed := int64(1); seed <= 20; seed++ {
+			f := New(3, seed, nil, nil, nil, nil, 0, 0, state.SpriteSmall, tt.mode, state.NightNever, tt.retro)
+			if len(f.Dens) != tt.dens {
+				t.Fatalf("%s floor with seed %d has %d dens, want %d", tt.mode, seed, len(f.Dens), tt.dens)
+			}
+			if f.Dots == 0 || f.Dots != f.DotsLeft() {
+				t.Errorf("%s floor with seed %d has %d dots, %d left", tt.mode, seed, f.Dots, f.DotsLeft())
+			}
+			reached := reachable(f.Items, f.Maze.Start())
+			for _, d := range f.Dens[1:] {
+				for y := d.Y; y < d.Y+d.Height; y++ {
+					for x := d.X; x < d.X+d.Width; x++ {
+						if f.Items[y][x] != Empty || !reached[y][x] || !f.InDen(x, y) {
+							t.Errorf("%s floor with seed %d has den cell (%d, %d) = %v, reachable %v", tt.mode, seed, x, y, f.Items[y][x], reached[y][x])
+						}
+					}
+				}
+				if !reached[d.Exit.Y][d.Exit.X] {
+					t.Errorf("%s floor with seed %d has the den exit %v cut off", tt.mode, seed, d.Exit)
+				}
+				start := f.Maze.Start()
+				if d.Contains(start.X, start.Y) {
+					t.Errorf("%s floor with seed %d has the start in the den", tt.mode, seed)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package floor
+
+import (
+	"fmt"
+
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/maze"
+)
+
+// Difficulty sums up how much of a maze the way to the stairs is.
+type Difficulty struct {
+	PathLength int     // cells on the shortest way from the start to the stairs
+	Branching  float64 // share of the cells on the way where it forks
+	DeadEnds   int     // cells of the maze with a single way out
+}
+
+// Score weighs the difficulty measures into a single number, the higher the harder.
+func (d Difficulty) Score() int {
+	return d.PathLength + d.DeadEnds/2 + int(d.Branching*100)
+}
+
+// String returns the difficulty for the debug HUD.
+func (d Difficulty) String() string {
+	return fmt.Sprintf("%d (path %d, forks %.0f%%, dead ends %d)", d.Score(), d.PathLength, d.Branching*100, d.DeadEnds)
+}
+
+const (
+	// maxAttempts limits the mazes tried for a floor, the hardest one is taken if none is good enough
+	maxAttempts = 8
+	// derivedSeedStep separates the seeds of the retries from the seeds of other floors
+	derivedSeedStep = 7919
+)
+
+// minDifficulty returns the lowest difficulty score accepted for a floor of the game mode.
+func minDifficulty(gameMode string) int {
+	switch gameMode {
+	case state.ModeNoisy:
+		return 145
+	case state.ModeCrazy:
+		return 230
+	default: // state.ModeEasy
+		return 75
+	}
+}
+
+// derivedSeed returns the seed of the retry attempt, the first attempt uses the floor seed itself.
+func derivedSeed(seed int64, attempt int) int64 {
+	return seed + int64(attempt)*derivedSeedStep
+}
+
+// rate measures the difficulty of the items grid of the maze with the solution from the start to the stairs.
+func rate(items [][]ItemType, m *maze.Maze, solution []maze.Point) Difficulty {
+	open := func(x, y int) bool {
+		return y >= 0 && y < len(items) && x >= 0 && x < len(items[y]) && items[y][x] != Wall
+	}
+	exits := func(p maze.Point) int {
+		n := 0
+		for _, dir := range neighbors {
+			if open(p.X+dir.X, p.Y+dir.Y) {
+				n++
+			}
+		}
+		return n
+	}
+
+	d := Difficulty{PathLength: len(solution)}
+	forks := 0
+	for _, p := range solution {
+		if exits(p) > 2 {
+			forks++
+		}
+	}
+	if len(solution) > 0 {
+		d.Branching = float64(forks) / float64(len(solution))
+	}
+	for y := 0; y < m.Height(); y++ {
+		for x := 0; x < m.Width(); x++ {
+			p := maze.Point{X: x, Y: y}
+			if open(x, y) && !m.IsInsideDen(p) && exits(p) == 1 {
+				d.DeadEnds++
+			}
+		}
+	}
+	return d
+}
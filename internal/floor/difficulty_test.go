@@ -0,0 +1,36 @@
+package floor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestGenerateDifficulty(t *testing.T) {
+	for _, mode := range []string{state.ModeEasy, state.ModeNoisy, state.ModeCrazy} {
+		for seed := int64(1); seed <= 20; seed++ {
+			f := New(3, seed, nil, nil, nil, nil, 0, 0, state.SpriteSmall, mode, state.NightNever, false)
+			if got := f.Difficulty.Score(); got < minDifficulty(mode) {
+				t.Errorf("%s floor with seed %d has difficulty %s, want at least %d", mode, seed, f.Difficulty, minDifficulty(mode))
+			}
+			if f.Difficulty.PathLength == 0 || f.Difficulty.DeadEnds == 0 {
+				t.Errorf("%s floor with seed %d is not rated: %s", mode, seed, f.Difficulty)
+			}
+		}
+	}
+}
+
+func TestGenerateCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if f := Generate(ctx, nil, 0, 1, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeEasy, state.NightNever, false); f != nil {
+		t.Error("Generate() with a cancelled context returned a floor")
+	}
+
+	var reports []Progress
+	Generate(context.Background(), func(p Progress) { reports = append(reports, p) }, 0, 1, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeEasy, state.NightNever, false)
+	if last := reports[len(reports)-1]; last.Done != last.Total {
+		t.Errorf("last progress = %+v, want all stages done", last)
+	}
+}
@@ -52,6 +52,19 @@ func placeDots(items [][]ItemType, solution []maze.Point) [][]ItemType {
 	return items
 }
 
+// placeLadder puts a ladder tile at the given point. A ladder never replaces stairs,
+// in that case it is dropped and nil is returned.
+func placeLadder(items [][]ItemType, p *maze.Point, ladder ItemType) *maze.Point {
+	if p == nil || p.Y < 0 || p.Y >= len(items) || p.X < 0 || p.X >= len(items[p.Y]) {
+		return nil
+	}
+	if item := items[p.Y][p.X]; item != Empty && item != Dot {
+		return nil
+	}
+	items[p.Y][p.X] = ladder
+	return p
+}
+
 // placePowerPellets places requested number of power pellets in the items grid
 // at maximum distance from the maze center and between them.
 func placePowerPellets(items [][]ItemType, m *maze.Maze, requested int) [][]ItemType {
@@ -152,6 +165,43 @@ func placeFuse(items [][]ItemType, m *maze.Maze, rng *rand.Rand) [][]ItemType {
 	return items
 }
 
+// placeRare places a single rare pickup at a random empty location.
+func placeRare(items [][]ItemType, m *maze.Maze, rng *rand.Rand, item ItemType) [][]ItemType {
+	var candidates []maze.Point
+	for y := 0; y < m.Height(); y++ {
+		for x := 0; x < m.Width(); x++ {
+			if items[y][x] == Empty && !m.IsInsideDen(maze.Point{X: x, Y: y}) {
+				candidates = append(candidates, maze.Point{X: x, Y: y})
+			}
+		}
+	}
+
+	if len(candidates) > 0 {
+		p := candidates[rng.Intn(len(candidates))]
+		items[p.Y][p.X] = item
+	}
+
+	return items
+}
+
+// placeCheckpoint puts a checkpoint on the dot closest to the middle of the solution path.
+func placeCheckpoint(items [][]ItemType, solution []maze.Point) [][]ItemType {
+	mid := len(solution) / 2
+	for offset := 0; offset <= mid; offset++ {
+		for _, i := range []int{mid + offset, mid - offset} {
+			if i < 0 || i >= len(solution) {
+				continue
+			}
+			p := solution[i]
+			if items[p.Y][p.X] == Dot {
+				items[p.Y][p.X] = Checkpoint
+				return items
+			}
+		}
+	}
+	return items
+}
+
 // placeCrumblingWalls finds suitable wall locations and converts them to CrumblingWall type.
 func placeCrumblingWalls(items [][]ItemType, m *maze.Maze, rng *rand.Rand, requested int) [][]ItemType {
 	var candidates []maze.Point
@@ -186,6 +236,106 @@ func placeCrumblingWalls(items [][]ItemType, m *maze.Maze, rng *rand.Rand, reque
 	return items
 }
 
+// placeGates picks requested walls between two passages to become gates, they stay closed until openGates.
+// Only walls of the carved maze are taken, none next to a den, so no gate ever cuts the way to the stairs.
+func placeGates(items [][]ItemType, m *maze.Maze, rng *rand.Rand, requested int, dens []Den) []maze.Point {
+	nearDen := func(x, y int) bool {
+		for dy := -1; dy <= 1; dy++ {
+			for dx := -1; dx <= 1; dx++ {
+				if m.IsInsideDen(maze.Point{X: x + dx, Y: y + dy}) {
+					return true
+				}
+				for _, d := range dens {
+					if d.Contains(x+dx, y+dy) {
+						return true
+					}
+				}
+			}
+		}
+		return false
+	}
+	var candidates []maze.Point
+	for y := 1; y < m.Height()-1; y++ {
+		for x := 1; x < m.Width()-1; x++ {
+			if items[y][x] != Wall || nearDen(x, y) {
+				continue
+			}
+			if (items[y-1][x] != Wall && items[y+1][x] != Wall) || (items[y][x-1] != Wall && items[y][x+1] != Wall) {
+				candidates = append(candidates, maze.Point{X: x, Y: y})
+			}
+		}
+	}
+
+	rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	return candidates[:min(requested, len(candidates))]
+}
+
+// openGates opens every other gate and returns the set of all of them.
+func openGates(items [][]ItemType, gates []maze.Point) map[maze.Point]bool {
+	set := make(map[maze.Point]bool)
+	for i, p := range gates {
+		set[p] = true
+		if i%2 == 0 {
+			items[p.Y][p.X] = Empty
+		}
+	}
+	return set
+}
+
+// placeUnstableFloors turns random empty cells off the solution path into unstable floor tiles.
+func placeUnstableFloors(items [][]ItemType, m *maze.Maze, rng *rand.Rand, requested int) [][]ItemType {
+	var candidates []maze.Point
+	for y := 0; y < m.Height(); y++ {
+		for x := 0; x < m.Width(); x++ {
+			if items[y][x] == Empty && !m.IsInsideDen(maze.Point{X: x, Y: y}) {
+				candidates = append(candidates, maze.Point{X: x, Y: y})
+			}
+		}
+	}
+
+	rng.Shuffle(len(candidates), func(i, j int) { candidates[i], candidates[j] = candidates[j], candidates[i] })
+	for i := 0; i < requested && i < len(candidates); i++ {
+		p := candidates[i]
+		items[p.Y][p.X] = UnstableFloor
+	}
+	return items
+}
+
+// residueCount is the number of cells with spectral residue on a floor
+const residueCount = 3
+
+// placeResidue hides spectral residue in open cells outside the dens. It has a generator of its own
+// seeded by the floor, so the residue doesn't change the rest of the floor.
+func placeResidue(items [][]ItemType, m *maze.Maze, seed int64) map[maze.Point]bool {
+	rng := rand.New(rand.NewSource(seed ^ 0x5EC7))
+	var candidates []maze.Point
+	for y := 0; y < m.Height(); y++ {
+		for x := 0; x < m.Width(); x++ {
+			if items[y][x] != Wall && items[y][x] != CrumblingWall && !m.IsInsideDen(maze.Point{X: x, Y: y}) {
+				candidates = append(candidates, maze.Point{X: x, Y: y})
+			}
+		}
+	}
+	residue := make(map[maze.Point]bool)
+	for _, i := range rng.Perm(len(candidates))[:min(residueCount, len(candidates))] {
+		residue[candidates[i]] = true
+	}
+	return residue
+}
+
+// count returns the number of cells of the items grid holding the item.
+func count(items [][]ItemType, item ItemType) int {
+	n := 0
+	for _, row := range items {
+		for _, it := range row {
+			if it == item {
+				n++
+			}
+		}
+	}
+	return n
+}
+
 // Manhattan distance between two points.
 func manhattan(x1, y1, x2, y2 int) int {
 	return abs(x1-x2) + abs(y1-y2)
@@ -1,13 +1,16 @@
 package floor
 
 import (
+	"context"
 	"errors"
 	"log"
 	"math"
 	"math/rand"
+	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/mutator"
 	"github.com/vinser/haunteed/internal/state"
 	"github.com/vinser/haunteed/internal/style"
 	"github.com/vinser/maze"
@@ -25,6 +28,18 @@ const (
 	Start
 	End
 	Fuse
+	LadderUp      // secondary connection to the floor above
+	LadderDown    // secondary connection to the floor below
+	UnstableFloor // cracks when stepped on
+	CrackedFloor  // gives way on the next step
+	Hole          // drops the haunteed to the floor below
+	RewindCharge  // rare pickup that rolls the world back a few seconds
+	Checkpoint    // respawn point halfway through a huge floor
+	Trap          // set by the haunteed, sends a ghost home
+	Camera        // pickup to photograph a ghost
+	Lever         // opens the closed gates of the floor and closes the open ones
+	Flashlight    // pickup to shine a beam ghosts keep out of
+	Colleague     // trapped colleague to escort to the stairs
 )
 
 type Floor struct {
@@ -35,7 +50,21 @@ type Floor struct {
 	GhostTickInterval time.Duration
 	Sprites           map[ItemType][]string
 	DimFuseSprite     []string
+	CrackSprites      [][]string // crumbling wall sprites by damage level, see WallDamage
+	GateSprites       [][]string // closed and open gate sprites, see IsGate
+	BeamSprite        []string   // sprite of empty cells in the flashlight beam, see InBeam
 	VisibilityRadius  int
+	LadderUp          *maze.Point // ladder to the floor above if any
+	LadderDown        *maze.Point // ladder to the floor below if any
+	Mutators          mutator.Set // rule modifiers announced on entry
+	Difficulty        Difficulty  // how much of a maze the way to the stairs is
+	Dens              []Den       // the den in the middle and the reinforcement dens, if any
+	Dots              int         // dots on the floor when it is entered
+	wallDamage        [][]int     // bumps taken by each crumbling wall outside power mode
+	gates             map[maze.Point]bool
+	beam              map[maze.Point]bool // cells lit by the flashlight, see SetBeam
+	scent             [][]int             // scent left by the haunteed in each cell, see LayScent
+	residue           map[maze.Point]bool // spectral residue not photographed yet, see Photograph
 }
 
 func (f *Floor) FullVisibilityRadius() int {
@@ -54,10 +83,61 @@ const (
 	ModeNoisyHeight = 21
 	ModeCrazyWidth  = 41
 	ModeCrazyHeight = 25
+
+	// Number of bumps a crumbling wall takes before it breaks without power mode
+	CrumblingWallStrength = 5
+
+	// Number of the haunteed's steps the scent of a cell lasts
+	ScentTrail = 24
+
+	// Chance that a floor has a rewind charge
+	rewindChargeChance = 0.2
+	// Chance that a floor has a camera
+	cameraChance = 0.25
+	// Chance that a noisy or crazy floor has a lever with gates
+	leverChance = 0.3
+	// Chance that a noisy or crazy floor has a flashlight
+	flashlightChance = 0.3
+	// Chance that a deep floor has a trapped colleague
+	colleagueChance = 0.25
+	// Shallowest floor with trapped colleagues
+	colleagueFloor = 5
 )
 
+// Progress reports a stage of the floor generation.
+type Progress struct {
+	Stage string // what is being done
+	Done  int    // stages done so far
+	Total int
+}
+
+// generationStages is the number of stages reported by Generate.
+const generationStages = 7
+
 // New initializes a new floor with its configuration and dot count.
-func New(index int, seed int64, startPoint, endPoint *maze.Point, width, height int, spriteSize, gameMode, crazyNight string) *Floor {
+// Optional ladder points add secondary connections to the adjacent floors, they must have odd coordinates
+// outside the den, so they are open on every floor.
+// The retro layout makes the maze left-right symmetric with the den in the middle, like the arcade one.
+func New(index int, seed int64, startPoint, endPoint, ladderUp, ladderDown *maze.Point, width, height int, spriteSize, gameMode, crazyNight string, retro bool) *Floor {
+	return Generate(context.Background(), nil, index, seed, startPoint, endPoint, ladderUp, ladderDown, width, height, spriteSize, gameMode, crazyNight, retro)
+}
+
+// Generate builds a floor like New, reporting every stage to the optional progress function.
+// It returns nil if the context is cancelled before the floor is ready.
+func Generate(ctx context.Context, progress func(Progress), index int, seed int64, startPoint, endPoint, ladderUp, ladderDown *maze.Point, width, height int, spriteSize, gameMode, crazyNight string, retro bool) *Floor {
+	done, total := 0, generationStages
+	// stage reports the stage about to start, it returns false if the generation is cancelled
+	stage := func(name string) bool {
+		if ctx.Err() != nil {
+			return false
+		}
+		if progress != nil {
+			progress(Progress{Stage: name, Done: done, Total: total})
+		}
+		done++
+		return true
+	}
+
 	// Determine maze dimensions based on game mode
 	switch gameMode {
 	case state.ModeNoisy:
@@ -73,26 +153,76 @@ func New(index int, seed int64, startPoint, endPoint *maze.Point, width, height
 		seed = int64(index)
 	}
 	rng := rand.New(rand.NewSource(seed))
-	m, err := maze.New(width, height, DenWidth, DenHeight)
-	if err != nil {
-		log.Fatal(err)
-	}
-	m.Generate(seed, startPoint, endPoint, nil, "top", getBias(gameMode, index))
 
-	items := newItems(m)
+	// Boring mazes are carved again with derived seeds, the hardest one is kept if none is good enough
+	var (
+		m          *maze.Maze
+		items      [][]ItemType
+		solution   []maze.Point
+		difficulty Difficulty
+		cornerDens []Den
+	)
+	for attempt := 0; attempt < maxAttempts && (m == nil || difficulty.Score() < minDifficulty(gameMode)); attempt++ {
+		if attempt > 0 {
+			total += 2 // carving and solving once more
+		}
+		if !stage("Carving the maze") {
+			return nil
+		}
+		candidate, err := maze.New(width, height, DenWidth, DenHeight)
+		if err != nil {
+			log.Fatal(err)
+		}
+		candidate.Generate(derivedSeed(seed, attempt), startPoint, endPoint, nil, "top", getBias(gameMode, index))
 
-	solution, ok := m.Solve()
-	if !ok {
-		log.Fatalf("no solution for width=%d, height=%d, denWidth=%d, denHeight=%d, seed=%d", width, height, DenWidth, DenHeight, seed)
+		if !stage("Finding the way up") {
+			return nil
+		}
+		cells := newItems(candidate)
+		var (
+			path  []maze.Point
+			ok    bool
+			extra []Den
+		)
+		switch {
+		case retro:
+			symmetrize(cells, candidate)
+		case width >= ModeNoisyWidth:
+			// Larger floors get a reinforcement den, it would break the symmetry of the retro layout
+			start, end := candidate.Start(), candidate.End()
+			if d, ok := closeCornerDen(cells, candidate, &start, &end, ladderUp, ladderDown); ok {
+				extra = append(extra, d)
+			}
+		}
+		if retro || len(extra) > 0 {
+			path, ok = solve(cells, candidate.Start(), candidate.End())
+		} else {
+			path, ok = candidate.Solve()
+		}
+		if !ok {
+			log.Fatalf("no solution for width=%d, height=%d, denWidth=%d, denHeight=%d, seed=%d", width, height, DenWidth, DenHeight, derivedSeed(seed, attempt))
+		}
+		path = path[1 : len(path)-1]
+		if d := rate(cells, candidate, path); m == nil || d.Score() > difficulty.Score() {
+			m, items, solution, difficulty, cornerDens = candidate, cells, path, d, extra
+		}
+	}
+
+	if !stage("Scattering crumbs") {
+		return nil
 	}
-	solution = solution[1 : len(solution)-1]
 	items = placeDots(items, solution)
+	ladderUp = placeLadder(items, ladderUp, LadderUp)
+	ladderDown = placeLadder(items, ladderDown, LadderDown)
 
 	// Scale item counts based on maze area
 	baseArea := 21.0 * 15.0
 	currentArea := float64(width * height)
 	scaleFactor := currentArea / baseArea
 
+	if !stage("Hiding pellets") {
+		return nil
+	}
 	pelletCount := int(math.Max(4, float64(rng.Intn(2)+4)*scaleFactor))
 	items = placePowerPellets(items, m, pelletCount)
 
@@ -101,10 +231,60 @@ func New(index int, seed int64, startPoint, endPoint *maze.Point, width, height
 		items = placeFuse(items, m, rng)
 	}
 
+	if !stage("Cracking walls and floors") {
+		return nil
+	}
 	crumblingWallCount := int(math.Max(5, float64(5)*scaleFactor))
 	items = placeCrumblingWalls(items, m, rng, crumblingWallCount)
 
+	unstableFloorCount := int(math.Max(2, float64(2)*scaleFactor))
+	items = placeUnstableFloors(items, m, rng, unstableFloorCount)
+
+	if !stage("Hiding rare finds") {
+		return nil
+	}
+	// Placed last, so floors of earlier versions stay the same
+	if rng.Float64() < rewindChargeChance {
+		items = placeRare(items, m, rng, RewindCharge)
+	}
+
+	// Huge crazy floors get a checkpoint halfway along the way to the stairs
+	if gameMode == state.ModeCrazy {
+		items = placeCheckpoint(items, solution)
+	}
+
+	if rng.Float64() < cameraChance {
+		items = placeRare(items, m, rng, Camera)
+	}
+
+	// Gates would break the symmetry of the retro layout
+	var gates map[maze.Point]bool
+	if !retro && width >= ModeNoisyWidth && rng.Float64() < leverChance {
+		gateCount := int(math.Max(4, float64(3)*scaleFactor))
+		if walls := placeGates(items, m, rng, gateCount, cornerDens); len(walls) > 0 {
+			items = placeRare(items, m, rng, Lever) // Before the gates open, so the lever is never in one
+			gates = openGates(items, walls)
+		}
+	}
+
+	if gameMode != state.ModeEasy && rng.Float64() < flashlightChance {
+		items = placeRare(items, m, rng, Flashlight)
+	}
+
+	if index >= colleagueFloor && rng.Float64() < colleagueChance {
+		items = placeRare(items, m, rng, Colleague)
+	}
+
+	if !stage("Painting the walls") {
+		return nil
+	}
+	for _, d := range cornerDens {
+		openDen(items, d)
+	}
 	sprites, dimFuseSprite := setFloorSprites(index, spriteSize, gameMode)
+	if progress != nil {
+		progress(Progress{Stage: "Ready", Done: done, Total: generationStages})
+	}
 	return &Floor{
 		Index:             index,
 		Seed:              seed,
@@ -113,7 +293,36 @@ func New(index int, seed int64, startPoint, endPoint *maze.Point, width, height
 		GhostTickInterval: ghostInterval(index),
 		Sprites:           sprites,
 		DimFuseSprite:     dimFuseSprite,
+		CrackSprites:      setCrackSprites(index, spriteSize),
+		GateSprites:       setGateSprites(index, spriteSize),
+		BeamSprite:        setBeamSprite(index, spriteSize),
+		LadderUp:          ladderUp,
+		LadderDown:        ladderDown,
+		Mutators:          mutator.ForFloor(index, seed),
+		Difficulty:        difficulty,
+		Dens:              append([]Den{mainDen(width, height)}, cornerDens...),
+		Dots:              count(items, Dot),
+		wallDamage:        newGrid(width, height),
+		gates:             gates,
+		residue:           placeResidue(items, m, seed),
+	}
+}
+
+// SetMutators replaces the floor mutators, e.g. with the rules of a challenge.
+// Power pellets turn into dots without pellets and speed hooks change the ghost tick.
+func (f *Floor) SetMutators(set mutator.Set) {
+	f.Mutators = set
+	if set.NoPellets() {
+		for y := range f.Items {
+			for x, item := range f.Items[y] {
+				if item == PowerPellet {
+					f.Items[y][x] = Dot
+				}
+			}
+		}
+		f.Dots = f.DotsLeft()
 	}
+	f.GhostTickInterval = set.GhostTick(f.GhostTickInterval)
 }
 
 // getBias calculates bias that controls the straightness of paths
@@ -145,24 +354,235 @@ func (f *Floor) ItemAt(x, y int) (ItemType, error) {
 	return f.Items[y][x], nil
 }
 
-// EatItem replaces a dot or power pellet with empty space and returns the eaten tile type.
+// EatItem replaces a dot, power pellet or pickup with empty space and returns the eaten tile type.
 func (f *Floor) EatItem(x, y int) ItemType {
 	if x < 0 || x >= f.Maze.Width() || y < 0 || y >= f.Maze.Height() {
 		return Empty
 	}
 	originalTile := f.Items[y][x]
-	if originalTile == Dot || originalTile == PowerPellet {
+	if originalTile == Dot || originalTile == PowerPellet || originalTile == RewindCharge || originalTile == Camera || originalTile == Flashlight || originalTile == Colleague {
 		f.Items[y][x] = Empty
 	}
 	return originalTile
 }
 
+// PutPellet puts a power pellet back on an empty tile, it returns false if the tile is taken.
+func (f *Floor) PutPellet(x, y int) bool {
+	if item, err := f.ItemAt(x, y); err != nil || item != Empty {
+		return false
+	}
+	f.Items[y][x] = PowerPellet
+	return true
+}
+
+// OpenUp breaks every crumbling wall and opens every gate for a roomier floor, e.g. a sandbox.
+func (f *Floor) OpenUp() {
+	for y := range f.Items {
+		for x, item := range f.Items[y] {
+			if item == CrumblingWall || (item == Wall && f.IsGate(x, y)) {
+				f.BreakWall(x, y)
+			}
+		}
+	}
+}
+
+// SetTrap puts a trap on an empty tile, it returns false if the tile is taken.
+func (f *Floor) SetTrap(x, y int) bool {
+	if item, err := f.ItemAt(x, y); err != nil || item != Empty {
+		return false
+	}
+	f.Items[y][x] = Trap
+	return true
+}
+
+// SpringTrap removes the trap at the specified coordinates, it returns false if there is none.
+func (f *Floor) SpringTrap(x, y int) bool {
+	if item, err := f.ItemAt(x, y); err != nil || item != Trap {
+		return false
+	}
+	f.Items[y][x] = Empty
+	return true
+}
+
 // BreakWall changes a crumbling wall into an empty space.
 func (f *Floor) BreakWall(x, y int) {
 	if x < 0 || x >= f.Maze.Width() || y < 0 || y >= f.Maze.Height() {
 		return
 	}
 	f.Items[y][x] = Empty
+	f.wallDamage[y][x] = 0
+}
+
+// Tread is called when the haunteed steps onto a tile. Unstable floor cracks on the first step
+// and turns into a hole on the next one. It returns the tile type after the step.
+func (f *Floor) Tread(x, y int) ItemType {
+	item, err := f.ItemAt(x, y)
+	if err != nil {
+		return Empty
+	}
+	switch item {
+	case UnstableFloor:
+		f.Items[y][x] = CrackedFloor
+	case CrackedFloor:
+		f.Items[y][x] = Hole
+	}
+	return f.Items[y][x]
+}
+
+// IsGate returns true if the cell is a gate switched by the lever of the floor, open or closed.
+func (f *Floor) IsGate(x, y int) bool {
+	return f.gates[maze.Point{X: x, Y: y}]
+}
+
+// PullLever closes the open gates of the floor and opens the closed ones.
+// Gates are extra walls of the maze, so the stairs stay reachable either way.
+// A dweller caught in a closing gate steps out of it on its next move.
+func (f *Floor) PullLever() {
+	for p := range f.gates {
+		if f.Items[p.Y][p.X] == Wall {
+			f.Items[p.Y][p.X] = Empty
+		} else {
+			f.Items[p.Y][p.X] = Wall
+		}
+	}
+}
+
+// SetBeam lights up to length cells from (x, y) in the direction (dx, dy), the beam stops at the first wall.
+// The cell at (x, y) itself stays dark. An earlier beam is put out.
+func (f *Floor) SetBeam(x, y, dx, dy, length int) {
+	f.beam = make(map[maze.Point]bool)
+	if dx == 0 && dy == 0 {
+		return
+	}
+	for i := 1; i <= length; i++ {
+		item, err := f.ItemAt(x+dx*i, y+dy*i)
+		if err != nil || item == Wall || item == CrumblingWall {
+			return
+		}
+		f.beam[maze.Point{X: x + dx*i, Y: y + dy*i}] = true
+	}
+}
+
+// ClearBeam puts the flashlight beam out.
+func (f *Floor) ClearBeam() {
+	f.beam = nil
+}
+
+// InBeam returns true if the cell is lit by the flashlight beam.
+func (f *Floor) InBeam(x, y int) bool {
+	return f.beam[maze.Point{X: x, Y: y}]
+}
+
+// LayScent leaves the freshest scent in the cell the haunteed steps onto, the scent of every other cell fades a bit.
+// The trail is the last ScentTrail cells, growing stronger toward the haunteed.
+func (f *Floor) LayScent(x, y int) {
+	if x < 0 || x >= f.Maze.Width() || y < 0 || y >= f.Maze.Height() {
+		return
+	}
+	if f.scent == nil {
+		f.scent = newGrid(f.Maze.Width(), f.Maze.Height())
+	}
+	for _, row := range f.scent {
+		for i := range row {
+			row[i] = max(row[i]-1, 0)
+		}
+	}
+	f.scent[y][x] = ScentTrail
+}
+
+// Scent returns the scent left in the cell, 0 if the trail doesn't pass it.
+func (f *Floor) Scent(x, y int) int {
+	if f.scent == nil || x < 0 || x >= f.Maze.Width() || y < 0 || y >= f.Maze.Height() {
+		return 0
+	}
+	return f.scent[y][x]
+}
+
+// Photograph develops the spectral residue hidden in the cell, it returns false if there is none left.
+// Every residue develops once.
+func (f *Floor) Photograph(x, y int) bool {
+	p := maze.Point{X: x, Y: y}
+	if !f.residue[p] {
+		return false
+	}
+	delete(f.residue, p)
+	return true
+}
+
+// InDen returns true if the cell is inside any den of the floor.
+func (f *Floor) InDen(x, y int) bool {
+	if f.Maze.IsInsideDen(maze.Point{X: x, Y: y}) {
+		return true
+	}
+	for _, d := range f.Dens {
+		if d.Contains(x, y) {
+			return true
+		}
+	}
+	return false
+}
+
+// DotsLeft returns the number of dots not eaten yet.
+func (f *Floor) DotsLeft() int {
+	return count(f.Items, Dot)
+}
+
+// NearestOpen returns the walkable cell closest to (x, y), searching in growing rings.
+// It is used to map coordinates between floors when the same cell is a wall on the other floor.
+func (f *Floor) NearestOpen(x, y int) (int, int) {
+	x = max(0, min(x, f.Maze.Width()-1))
+	y = max(0, min(y, f.Maze.Height()-1))
+	for r := 0; r < max(f.Maze.Width(), f.Maze.Height()); r++ {
+		for dy := -r; dy <= r; dy++ {
+			for dx := -r; dx <= r; dx++ {
+				if abs(dx) != r && abs(dy) != r {
+					continue // only the ring border
+				}
+				px, py := x+dx, y+dy
+				if f.InDen(px, py) {
+					continue
+				}
+				item, err := f.ItemAt(px, py)
+				if err == nil && item != Wall && item != CrumblingWall && item != Hole {
+					return px, py
+				}
+			}
+		}
+	}
+	return f.Maze.Start().X, f.Maze.Start().Y
+}
+
+// BumpWall adds one bump of damage to a crumbling wall and breaks it once
+// CrumblingWallStrength bumps are reached. It returns the damage level after the bump
+// and whether the wall is broken. Bumping any other tile does nothing.
+func (f *Floor) BumpWall(x, y int) (int, bool) {
+	item, err := f.ItemAt(x, y)
+	if err != nil || item != CrumblingWall {
+		return 0, false
+	}
+	f.wallDamage[y][x]++
+	if f.wallDamage[y][x] >= CrumblingWallStrength {
+		f.BreakWall(x, y)
+		return CrumblingWallStrength, true
+	}
+	return f.wallDamage[y][x], false
+}
+
+// WallDamage returns the number of bumps a crumbling wall has taken so far.
+func (f *Floor) WallDamage(x, y int) int {
+	if x < 0 || x >= f.Maze.Width() || y < 0 || y >= f.Maze.Height() {
+		return 0
+	}
+	return f.wallDamage[y][x]
+}
+
+// newGrid returns a zeroed grid of per-cell counters.
+func newGrid(width, height int) [][]int {
+	grid := make([][]int, height)
+	for y := range grid {
+		grid[y] = make([]int, width)
+	}
+	return grid
 }
 
 // RenderAt renders the tile at the specified coordinates using the given sprite size.
@@ -176,14 +596,59 @@ func (f *Floor) RenderAt(x, y int) []string {
 	return sprite
 }
 
-// ShowCrumbs make crumbs look like in easy mode
-func (f *Floor) ShowCrumbs(floorNum int, spriteSize string) {
-	brightStyle, _ := getFloorItemStyle(floorNum, Dot)
-	var sprite []string
-	for _, s := range getFloorSprite(spriteSize, state.ModeEasy, Dot) {
-		sprite = append(sprite, brightStyle.Render(s))
+// Sketch renders the cells around (x, y) as plain text rows with small sprites, cells outside the floor are blank.
+func (f *Floor) Sketch(x, y, radius int) []string {
+	var rows []string
+	for py := y - radius; py <= y+radius; py++ {
+		var b strings.Builder
+		for px := x - radius; px <= x+radius; px++ {
+			if item, err := f.ItemAt(px, py); err == nil {
+				b.WriteString(getFloorSprite(state.SpriteSmall, state.ModeEasy, item)[0])
+			} else {
+				b.WriteString(" ")
+			}
+		}
+		rows = append(rows, b.String())
 	}
-	f.Sprites[Dot] = sprite
+	return rows
+}
+
+// String renders the floor as plain text with small sprites, crumbs are always shown.
+func (f *Floor) String() string {
+	var b strings.Builder
+	for y := range f.Items {
+		for _, item := range f.Items[y] {
+			b.WriteString(getFloorSprite(state.SpriteSmall, state.ModeEasy, item)[0])
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// Minimap renders the walls of the floor at half height, two rows of cells to a line of half blocks.
+func (f *Floor) Minimap() []string {
+	wall := func(x, y int) bool {
+		item, err := f.ItemAt(x, y)
+		return err == nil && (item == Wall || item == CrumblingWall)
+	}
+	var lines []string
+	for y := 0; y < len(f.Items); y += 2 {
+		var b strings.Builder
+		for x := range f.Items[y] {
+			switch top, bottom := wall(x, y), wall(x, y+1); {
+			case top && bottom:
+				b.WriteString("█")
+			case top:
+				b.WriteString("▀")
+			case bottom:
+				b.WriteString("▄")
+			default:
+				b.WriteString(" ")
+			}
+		}
+		lines = append(lines, b.String())
+	}
+	return lines
 }
 
 func setFloorSprites(floorNum int, spriteSize, gameMode string) (map[ItemType][]string, []string) {
@@ -195,6 +660,18 @@ func setFloorSprites(floorNum int, spriteSize, gameMode string) (map[ItemType][]
 		Start:         nil,
 		End:           nil,
 		Fuse:          nil,
+		LadderUp:      nil,
+		LadderDown:    nil,
+		UnstableFloor: nil,
+		CrackedFloor:  nil,
+		Hole:          nil,
+		RewindCharge:  nil,
+		Checkpoint:    nil,
+		Trap:          nil,
+		Camera:        nil,
+		Lever:         nil,
+		Flashlight:    nil,
+		Colleague:     nil,
 		Empty:         nil,
 	}
 	var dimFuseSprite []string
@@ -220,6 +697,92 @@ func setFloorSprites(floorNum int, spriteSize, gameMode string) (map[ItemType][]
 	return sprites, dimFuseSprite
 }
 
+// setCrackSprites returns crumbling wall sprites for damage levels 1..CrumblingWallStrength-1.
+func setCrackSprites(floorNum int, spriteSize string) [][]string {
+	brightStyle, _ := getFloorItemStyle(floorNum, CrumblingWall)
+	var sprites [][]string
+	for level := 1; level < CrumblingWallStrength; level++ {
+		var sprite []string
+		for _, s := range getCrackSprite(spriteSize, level) {
+			sprite = append(sprite, brightStyle.Render(s))
+		}
+		sprites = append(sprites, sprite)
+	}
+	return sprites
+}
+
+// setGateSprites returns the bright closed gate sprite and the dim open gate sprite.
+func setGateSprites(floorNum int, spriteSize string) [][]string {
+	brightStyle, dimStyle := getFloorItemStyle(floorNum, Lever)
+	var closed, open []string
+	for _, s := range getGateSprite(spriteSize, false) {
+		closed = append(closed, brightStyle.Render(s))
+	}
+	for _, s := range getGateSprite(spriteSize, true) {
+		open = append(open, dimStyle.Render(s))
+	}
+	return [][]string{closed, open}
+}
+
+// setBeamSprite returns the sprite of an empty cell in the flashlight beam.
+func setBeamSprite(floorNum int, spriteSize string) []string {
+	brightStyle, _ := getFloorItemStyle(floorNum, Flashlight)
+	var sprite []string
+	for _, s := range getBeamSprite(spriteSize) {
+		sprite = append(sprite, brightStyle.Render(s))
+	}
+	return sprite
+}
+
+func getBeamSprite(size string) []string {
+	switch size {
+	case state.SpriteSmall:
+		return []string{"∙"}
+	case state.SpriteLarge:
+		return []string{" ∙∙ ", " ∙∙ "}
+	default: // state.SpriteMedium
+		return []string{"∙∙"}
+	}
+}
+
+// getGateSprite returns the gate sprite, an open gate is a dashed line across the passage.
+func getGateSprite(size string, open bool) []string {
+	switch size {
+	case state.SpriteSmall:
+		if open {
+			return []string{"┄"}
+		}
+		return []string{"▤"}
+	case state.SpriteLarge:
+		if open {
+			return []string{"    ", "┄┄┄┄"}
+		}
+		return []string{"▤▤▤▤", "▤▤▤▤"}
+	default: // state.SpriteMedium
+		if open {
+			return []string{"┄┄"}
+		}
+		return []string{"▤▤"}
+	}
+}
+
+// getCrackSprite returns the crumbling wall sprite with cracks growing with the damage level.
+func getCrackSprite(size string, level int) []string {
+	switch size {
+	case state.SpriteSmall:
+		return [][]string{{"▓"}, {"▚"}, {"╳"}, {"░"}}[level-1]
+	case state.SpriteLarge:
+		return [][]string{
+			{"▒▒▒▒", "▒▚▒▒"},
+			{"▒▚▒▒", "▒▒▞▒"},
+			{"╲▚▞▒", "▒╳▞╱"},
+			{"╲╳╳╱", "╱╳╳╲"},
+		}[level-1]
+	default: // state.SpriteMedium
+		return [][]string{{"▒▚"}, {"▚▞"}, {"╳▞"}, {"╳╳"}}[level-1]
+	}
+}
+
 func getFloorItemStyle(floorNum int, item ItemType) (brightStyle, dimStyle lipgloss.Style) {
 	var color style.RGB
 	switch item {
@@ -231,12 +794,28 @@ func getFloorItemStyle(floorNum int, item ItemType) (brightStyle, dimStyle lipgl
 		color = style.RGBColor["white"]
 	case PowerPellet:
 		color = style.RGBColor["white"]
-	case Start:
+	case Start, LadderDown:
 		color = style.RGBColor["red"]
-	case End:
+	case End, LadderUp:
 		color = style.RGBColor["green"]
-	case Fuse:
+	case Fuse, Flashlight:
 		color = style.RGBColor["yellow"]
+	case UnstableFloor, CrackedFloor:
+		color = style.RGBColor["grey"]
+	case Hole:
+		color = style.RGBColor["brown"]
+	case RewindCharge:
+		color = style.RGBColor["cyan"]
+	case Checkpoint:
+		color = style.RGBColor["magenta"]
+	case Trap:
+		color = style.RGBColor["red"]
+	case Camera:
+		color = style.RGBColor["blue"]
+	case Lever:
+		color = style.RGBColor["orange"]
+	case Colleague:
+		color = style.RGBColor["pink"]
 	default:
 		color = style.RGBColor["white"]
 	}
@@ -272,6 +851,28 @@ func getFloorSprite(size string, mode string, item ItemType) []string {
 			return []string{"▴"}
 		case Fuse:
 			return []string{"↯"}
+		case LadderUp, LadderDown:
+			return []string{"╫"}
+		case UnstableFloor:
+			return []string{"╌"}
+		case CrackedFloor:
+			return []string{"⁘"}
+		case Hole:
+			return []string{"◌"}
+		case RewindCharge:
+			return []string{"↺"}
+		case Checkpoint:
+			return []string{"⚑"}
+		case Trap:
+			return []string{"⊗"}
+		case Camera:
+			return []string{"◙"}
+		case Lever:
+			return []string{"⌐"}
+		case Flashlight:
+			return []string{"▷"}
+		case Colleague:
+			return []string{"☺"}
 		default:
 			return []string{" "}
 		}
@@ -294,6 +895,28 @@ func getFloorSprite(size string, mode string, item ItemType) []string {
 			return []string{"◢◣"}
 		case Fuse:
 			return []string{"↯↯"}
+		case LadderUp, LadderDown:
+			return []string{"╟╢"}
+		case UnstableFloor:
+			return []string{"╌╌"}
+		case CrackedFloor:
+			return []string{"⁘⁘"}
+		case Hole:
+			return []string{"◖◗"}
+		case RewindCharge:
+			return []string{"↶↷"}
+		case Checkpoint:
+			return []string{"▕⚑"}
+		case Trap:
+			return []string{"⊗⊗"}
+		case Camera:
+			return []string{"▞◙"}
+		case Lever:
+			return []string{"⌐╨"}
+		case Flashlight:
+			return []string{"═▷"}
+		case Colleague:
+			return []string{"☺╽"}
 		default:
 			return []string{"  "}
 		}
@@ -316,6 +939,28 @@ func getFloorSprite(size string, mode string, item ItemType) []string {
 			return []string{" ◢◣ ", " ◢◣ "}
 		case Fuse:
 			return []string{" ↯↯ ", " ↯↯ "}
+		case LadderUp, LadderDown:
+			return []string{" ╟╢ ", " ╟╢ "}
+		case UnstableFloor:
+			return []string{"    ", "╌╌╌╌"}
+		case CrackedFloor:
+			return []string{" ⁘⁘ ", "⁘╌╌⁘"}
+		case Hole:
+			return []string{"▗▄▄▖", "▝▀▀▘"}
+		case RewindCharge:
+			return []string{" ↶↷ ", " ↶↷ "}
+		case Checkpoint:
+			return []string{" ▕⚑ ", " ▕  "}
+		case Trap:
+			return []string{" ⊗⊗ ", " ⊗⊗ "}
+		case Camera:
+			return []string{" ▗▖ ", "▐◙◙▌"}
+		case Lever:
+			return []string{" ⌐  ", " ╨╨ "}
+		case Flashlight:
+			return []string{" ═▷ ", " ═▷ "}
+		case Colleague:
+			return []string{" ☺  ", "╶╂╴ "}
 		default:
 			return []string{"    ", "    "}
 		}
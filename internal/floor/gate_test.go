@@ -0,0 +1,52 @@
+package floor
+
+import (
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestGates(t *testing.T) {
+	levers := 0
+	for seed := int64(1); seed <= 40; seed++ {
+		for _, mode := range []string{state.ModeNoisy, state.ModeCrazy} {
+			f := New(1, seed, nil, nil, nil, nil, 0, 0, state.SpriteSmall, mode, state.NightNever, false)
+			if count(f.Items, Lever) == 0 {
+				if len(f.gates) > 0 {
+					t.Errorf("%s floor with seed %d has gates but no lever", mode, seed)
+				}
+				continue
+			}
+			levers++
+			open := 0
+			for p := range f.gates {
+				if f.Items[p.Y][p.X] != Wall {
+					open++
+				}
+			}
+			for p := range f.gates {
+				if f.Items[p.Y][p.X] == Lever {
+					t.Errorf("%s floor with seed %d has the lever in a gate", mode, seed)
+				}
+			}
+			if open == 0 || open == len(f.gates) {
+				t.Errorf("%s floor with seed %d has %d of %d gates open, want some of them", mode, seed, open, len(f.gates))
+			}
+			for pull := 0; pull < 2; pull++ {
+				f.PullLever()
+				if _, ok := solve(f.Items, f.Maze.Start(), f.Maze.End()); !ok {
+					t.Errorf("%s floor with seed %d has no way to the stairs after %d pulls", mode, seed, pull+1)
+				}
+			}
+			for p := range f.gates {
+				f.Items[p.Y][p.X] = Wall
+			}
+			if _, ok := solve(f.Items, f.Maze.Start(), f.Maze.End()); !ok {
+				t.Errorf("%s floor with seed %d has no way to the stairs with every gate closed", mode, seed)
+			}
+		}
+	}
+	if levers == 0 {
+		t.Error("no floor has a lever")
+	}
+}
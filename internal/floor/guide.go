@@ -0,0 +1,18 @@
+package floor
+
+import "github.com/vinser/maze"
+
+// Guide returns up to steps cells of the shortest way from (x, y) to the stairs up, the nearest first.
+// Crumbling walls count as open, they give way after a few bumps.
+// It returns no cells if the stairs are reached already or there is no way to them.
+func (f *Floor) Guide(x, y, steps int) []maze.Point {
+	path, ok := solve(f.Items, maze.Point{X: x, Y: y}, f.Maze.End())
+	if !ok {
+		return nil
+	}
+	path = path[1:]
+	if len(path) > steps {
+		path = path[:steps]
+	}
+	return path
+}
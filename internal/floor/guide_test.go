@@ -0,0 +1,31 @@
+package floor
+
+import (
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestGuide(t *testing.T) {
+	for seed := int64(1); seed <= 10; seed++ {
+		f := New(1, seed, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeNoisy, state.NightNever, false)
+		start, end := f.Maze.Start(), f.Maze.End()
+		guide := f.Guide(start.X, start.Y, 10)
+		if len(guide) != min(10, f.Difficulty.PathLength-1) {
+			t.Fatalf("floor with seed %d guides %d steps, want 10 of the %d cell way", seed, len(guide), f.Difficulty.PathLength)
+		}
+		prev := start
+		for _, p := range guide {
+			if manhattan(p.X, p.Y, prev.X, prev.Y) != 1 {
+				t.Errorf("floor with seed %d guides from %v to %v in one step", seed, prev, p)
+			}
+			if f.Items[p.Y][p.X] == Wall {
+				t.Errorf("floor with seed %d guides through the wall at %v", seed, p)
+			}
+			prev = p
+		}
+		if got := f.Guide(end.X, end.Y, 10); len(got) != 0 {
+			t.Errorf("floor with seed %d guides %v from the stairs", seed, got)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+package floor
+
+import (
+	"testing"
+	"unicode/utf8"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestMinimap(t *testing.T) {
+	f := New(1, 1, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeEasy, state.NightNever, false)
+	lines := f.Minimap()
+	if got, want := len(lines), (len(f.Items)+1)/2; got != want {
+		t.Fatalf("Minimap() has %d lines, want %d", got, want)
+	}
+	for y, line := range lines {
+		if got := utf8.RuneCountInString(line); got != len(f.Items[0]) {
+			t.Errorf("line %d is %d cells wide, want %d", y, got, len(f.Items[0]))
+		}
+	}
+	// The outer wall is solid along the top
+	if lines[0][:len("█")] != "█" {
+		t.Errorf("Minimap() starts with %q, want a wall", lines[0])
+	}
+}
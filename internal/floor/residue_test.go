@@ -0,0 +1,29 @@
+package floor
+
+import (
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestResidue(t *testing.T) {
+	f := New(3, 7, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeNoisy, state.NightNever, false)
+	again := New(3, 7, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeNoisy, state.NightNever, false)
+	found := 0
+	for y := range f.Items {
+		for x := range f.Items[y] {
+			if f.Photograph(x, y) {
+				found++
+				if f.Photograph(x, y) {
+					t.Errorf("residue at (%d, %d) develops twice", x, y)
+				}
+				if !again.Photograph(x, y) {
+					t.Errorf("residue at (%d, %d) is not on the same floor generated again", x, y)
+				}
+			}
+		}
+	}
+	if found != residueCount {
+		t.Errorf("found %d residues, want %d", found, residueCount)
+	}
+}
@@ -0,0 +1,28 @@
+package floor
+
+import (
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestScent(t *testing.T) {
+	f := New(1, 1, nil, nil, nil, nil, 0, 0, state.SpriteSmall, state.ModeEasy, state.NightNever, false)
+	if got := f.Scent(1, 1); got != 0 {
+		t.Fatalf("Scent() = %d before any step, want 0", got)
+	}
+	for x := 1; x <= 3; x++ {
+		f.LayScent(x, 1)
+	}
+	for x, want := range map[int]int{1: ScentTrail - 2, 2: ScentTrail - 1, 3: ScentTrail, 4: 0} {
+		if got := f.Scent(x, 1); got != want {
+			t.Errorf("Scent(%d, 1) = %d, want %d", x, got, want)
+		}
+	}
+	for range ScentTrail {
+		f.LayScent(5, 5)
+	}
+	if got := f.Scent(3, 1); got != 0 {
+		t.Errorf("Scent(3, 1) = %d after %d more steps, want it faded", got, ScentTrail)
+	}
+}
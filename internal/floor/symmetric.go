@@ -0,0 +1,134 @@
+package floor
+
+import "github.com/vinser/maze"
+
+// neighbors are the four directions in the order the maze solver explores them.
+var neighbors = []maze.Point{{X: 0, Y: -1}, {X: 0, Y: 1}, {X: -1, Y: 0}, {X: 1, Y: 0}}
+
+// symmetrize makes the items grid of the maze left-right symmetric for the retro layout.
+// The left half is replaced with the mirrored right half, so the den ends up centered under the
+// middle column, its door is opened there and the parts cut off by the mirroring are joined again.
+func symmetrize(items [][]ItemType, m *maze.Maze) {
+	w := m.Width()
+	center := w / 2
+	for y := range items {
+		for x := 0; x < center; x++ {
+			if items[y][w-1-x] == Wall {
+				items[y][x] = Wall
+			} else {
+				items[y][x] = Empty
+			}
+		}
+	}
+	start, end := m.Start(), m.End()
+	items[start.Y][start.X] = Start
+	items[end.Y][end.X] = End
+
+	// The door of the den in the middle of its top wall, like the arcade one
+	door := m.DenStartY() - 1
+	items[door][center] = Empty
+	items[door-1][center] = Empty
+
+	connect(items, m, true)
+}
+
+// connect opens walls until every open cell is reachable from the start,
+// mirrored walls are opened together to keep the maze symmetric.
+// Walls of the den are opened only if there is no other way.
+func connect(items [][]ItemType, m *maze.Maze, mirrored bool) {
+	w := m.Width()
+	den := func(p maze.Point) bool {
+		q := maze.Point{X: w - 1 - p.X, Y: p.Y}
+		return m.IsInsideDen(p) || m.IsAdjacentToDen(p) || m.IsInsideDen(q) || m.IsAdjacentToDen(q)
+	}
+	for {
+		reached := reachable(items, m.Start())
+		bridge, ok := findBridge(items, reached, func(p maze.Point) bool { return !den(p) })
+		if !ok {
+			bridge, ok = findBridge(items, reached, func(maze.Point) bool { return true })
+		}
+		if !ok {
+			return
+		}
+		items[bridge.Y][bridge.X] = Empty
+		if mirrored {
+			items[bridge.Y][w-1-bridge.X] = Empty
+		}
+	}
+}
+
+// findBridge returns an allowed inner wall between a reached cell and an open cell not reached yet.
+func findBridge(items [][]ItemType, reached [][]bool, allowed func(maze.Point) bool) (maze.Point, bool) {
+	for y := 1; y < len(items)-1; y++ {
+		for x := 1; x < len(items[y])-1; x++ {
+			p := maze.Point{X: x, Y: y}
+			if items[y][x] != Wall || !allowed(p) {
+				continue
+			}
+			for _, pair := range [][2]maze.Point{{{X: x - 1, Y: y}, {X: x + 1, Y: y}}, {{X: x, Y: y - 1}, {X: x, Y: y + 1}}} {
+				a, b := pair[0], pair[1]
+				if reached[a.Y][a.X] != reached[b.Y][b.X] && items[a.Y][a.X] != Wall && items[b.Y][b.X] != Wall {
+					return p, true
+				}
+			}
+		}
+	}
+	return maze.Point{}, false
+}
+
+// reachable marks the open cells reachable from the point.
+func reachable(items [][]ItemType, from maze.Point) [][]bool {
+	reached := make([][]bool, len(items))
+	for y := range items {
+		reached[y] = make([]bool, len(items[y]))
+	}
+	reached[from.Y][from.X] = true
+	queue := []maze.Point{from}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		for _, dir := range neighbors {
+			next := maze.Point{X: p.X + dir.X, Y: p.Y + dir.Y}
+			if next.Y < 0 || next.Y >= len(items) || next.X < 0 || next.X >= len(items[next.Y]) {
+				continue
+			}
+			if !reached[next.Y][next.X] && items[next.Y][next.X] != Wall {
+				reached[next.Y][next.X] = true
+				queue = append(queue, next)
+			}
+		}
+	}
+	return reached
+}
+
+// solve finds the shortest way between the points over the open cells of the items grid.
+// Like maze.Solve it returns the path with both ends and true if there is one.
+func solve(items [][]ItemType, from, to maze.Point) ([]maze.Point, bool) {
+	parent := map[maze.Point]maze.Point{}
+	visited := map[maze.Point]bool{from: true}
+	queue := []maze.Point{from}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if p == to {
+			path := []maze.Point{p}
+			for p != from {
+				p = parent[p]
+				path = append([]maze.Point{p}, path...)
+			}
+			return path, true
+		}
+		for _, dir := range neighbors {
+			next := maze.Point{X: p.X + dir.X, Y: p.Y + dir.Y}
+			if next.Y < 0 || next.Y >= len(items) || next.X < 0 || next.X >= len(items[next.Y]) {
+				continue
+			}
+			if !visited[next] && items[next.Y][next.X] != Wall {
+				visited[next] = true
+				parent[next] = p
+				queue = append(queue, next)
+			}
+		}
+	}
+	return nil, false
+}
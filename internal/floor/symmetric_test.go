@@ -0,0 +1,67 @@
+package floor
+
+import (
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/maze"
+)
+
+func TestGenerateRetro(t *testing.T) {
+	solid := func(item ItemType) bool { return item == Wall || item == CrumblingWall }
+	for _, mode := range []string{state.ModeEasy, state.ModeNoisy, state.ModeCrazy} {
+		for seed := int64(1); seed <= 20; seed++ {
+			f := New(3, seed, nil, nil, nil, nil, 0, 0, state.SpriteSmall, mode, state.NightNever, true)
+			w := f.Maze.Width()
+			for y, row := range f.Items {
+				for x, item := range row {
+					if solid(item) != solid(row[w-1-x]) {
+						t.Fatalf("%s floor with seed %d is not symmetric at (%d, %d)", mode, seed, x, y)
+					}
+				}
+			}
+
+			if door := f.Items[f.Maze.DenStartY()-1][w/2]; solid(door) {
+				t.Errorf("%s floor with seed %d has the den door closed", mode, seed)
+			}
+
+			walls := make([][]ItemType, len(f.Items))
+			for y, row := range f.Items {
+				walls[y] = make([]ItemType, len(row))
+				for x, item := range row {
+					if !solid(item) {
+						walls[y][x] = Empty
+					}
+				}
+			}
+			reached := reachable(walls, f.Maze.Start())
+			for y, row := range walls {
+				for x, item := range row {
+					if item != Wall && !reached[y][x] {
+						t.Fatalf("%s floor with seed %d has (%d, %d) cut off", mode, seed, x, y)
+					}
+				}
+			}
+			if _, ok := solve(walls, f.Maze.Start(), f.Maze.End()); !ok {
+				t.Errorf("%s floor with seed %d has no way to the stairs", mode, seed)
+			}
+		}
+	}
+}
+
+func TestSolve(t *testing.T) {
+	items := [][]ItemType{
+		{Wall, Wall, Wall, Wall, Wall},
+		{Wall, Start, Empty, Empty, Wall},
+		{Wall, Wall, Wall, End, Wall},
+		{Wall, Wall, Wall, Wall, Wall},
+	}
+	path, ok := solve(items, maze.Point{X: 1, Y: 1}, maze.Point{X: 3, Y: 2})
+	if want := 4; !ok || len(path) != want {
+		t.Errorf("solve() = %v, %v, want a path of %d cells", path, ok, want)
+	}
+	items[1][2] = Wall
+	if _, ok := solve(items, maze.Point{X: 1, Y: 1}, maze.Point{X: 3, Y: 2}); ok {
+		t.Error("solve() found a way through a wall")
+	}
+}
@@ -25,6 +25,7 @@ type geoipClient struct {
 	url         string
 	httpTimeout time.Duration
 	cacheTTL    time.Duration
+	offline     bool
 
 	mu        sync.Mutex
 	cache     *LocationInfo
@@ -48,6 +49,11 @@ func SetHTTPTimeout(d time.Duration) {
 	Default.httpTimeout = d
 }
 
+// SetOffline disables network lookups, e.g. for kiosks and containers without network.
+func SetOffline(offline bool) {
+	Default.offline = offline
+}
+
 // GetLocationInfo returns coordinates, location and error.
 func GetLocationInfo() (*LocationInfo, error) {
 	return Default.GetLocationInfo()
@@ -58,6 +64,10 @@ func (c *geoipClient) GetLocationInfo() (*LocationInfo, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
+	if c.offline {
+		return nil, errors.New("geoip: network disabled")
+	}
+
 	// Return cached data if it is not expired
 	if c.cache != nil && time.Since(c.cacheTime) <= c.cacheTTL {
 		return c.cache, nil
@@ -0,0 +1,39 @@
+// Package input filters raw key presses before they reach the gameplay.
+package input
+
+import "time"
+
+// Filter drops auto-repeated keys and, optionally, any key pressed too soon after an accepted one.
+// The debounce helps players with tremors avoid accidental double presses.
+type Filter struct {
+	RepeatThreshold time.Duration
+	Debounce        time.Duration
+
+	lastKey      string
+	lastTime     time.Time
+	lastAccepted time.Time
+}
+
+// NewFilter returns a filter with the given repeat threshold and debounce period.
+func NewFilter(repeatThreshold, debounce time.Duration) *Filter {
+	return &Filter{
+		RepeatThreshold: repeatThreshold,
+		Debounce:        debounce,
+	}
+}
+
+// Accept reports whether the key pressed at the given time should be handled.
+// An auto-repeat event is just the same key coming in very fast, holding a key keeps it rejected.
+func (f *Filter) Accept(key string, now time.Time) bool {
+	isAutoRepeat := key == f.lastKey && now.Sub(f.lastTime) < f.RepeatThreshold
+	f.lastKey = key
+	f.lastTime = now
+	if isAutoRepeat {
+		return false
+	}
+	if f.Debounce > 0 && now.Sub(f.lastAccepted) < f.Debounce {
+		return false
+	}
+	f.lastAccepted = now
+	return true
+}
@@ -0,0 +1,48 @@
+package input
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFilterAccept(t *testing.T) {
+	type press struct {
+		key  string
+		at   time.Duration
+		want bool
+	}
+	tests := []struct {
+		name     string
+		repeat   time.Duration
+		debounce time.Duration
+		presses  []press
+	}{
+		{"auto-repeat is dropped", 100 * time.Millisecond, 0, []press{
+			{"up", 0, true},
+			{"up", 50 * time.Millisecond, false},
+			{"up", 120 * time.Millisecond, false}, // still held
+			{"up", 300 * time.Millisecond, true},
+		}},
+		{"other keys pass", 100 * time.Millisecond, 0, []press{
+			{"up", 0, true},
+			{"left", 10 * time.Millisecond, true},
+			{"up", 20 * time.Millisecond, true},
+		}},
+		{"debounce drops any key", 100 * time.Millisecond, 200 * time.Millisecond, []press{
+			{"up", 0, true},
+			{"left", 150 * time.Millisecond, false},
+			{"down", 250 * time.Millisecond, true},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := NewFilter(tt.repeat, tt.debounce)
+			start := time.Now()
+			for i, p := range tt.presses {
+				if got := f.Accept(p.key, start.Add(p.at)); got != p.want {
+					t.Errorf("press %d (%s at %v): Accept() = %v, want %v", i, p.key, p.at, got, p.want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,11 @@
+// Package keyring keeps secrets in the keyring of the OS with its command line tool:
+// security on macOS and secret-tool of libsecret on Linux.
+package keyring
+
+import "errors"
+
+// service names haunteed entries in the keyring
+const service = "haunteed"
+
+// ErrUnavailable is returned when the system has no keyring the game can use.
+var ErrUnavailable = errors.New("no keyring available")
@@ -0,0 +1,28 @@
+//go:build darwin
+
+package keyring
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// Get returns the secret of the account from the login keychain.
+func Get(account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", service, "-a", account, "-w").Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set stores the secret of the account in the login keychain, replacing the old one.
+// The command goes to the interactive mode of security on stdin, so the secret stays off the command line,
+// and the secret is hex encoded for -X, so no quoting can break it.
+func Set(account, secret string) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf("add-generic-password -U -s %q -a %q -X %s\n", service, account, hex.EncodeToString([]byte(secret))))
+	return cmd.Run()
+}
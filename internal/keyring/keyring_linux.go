@@ -0,0 +1,30 @@
+//go:build linux
+
+package keyring
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// Get returns the secret of the account from the Secret Service keyring.
+func Get(account string) (string, error) {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return "", ErrUnavailable
+	}
+	out, err := exec.Command("secret-tool", "lookup", "service", service, "account", account).Output()
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(out), "\n"), nil
+}
+
+// Set stores the secret of the account in the Secret Service keyring, replacing the old one.
+func Set(account, secret string) error {
+	if _, err := exec.LookPath("secret-tool"); err != nil {
+		return ErrUnavailable
+	}
+	cmd := exec.Command("secret-tool", "store", "--label=Haunteed "+account, "service", service, "account", account)
+	cmd.Stdin = strings.NewReader(secret) // Kept off the command line
+	return cmd.Run()
+}
@@ -0,0 +1,13 @@
+//go:build !darwin && !linux
+
+package keyring
+
+// Get always fails, the system has no supported keyring.
+func Get(account string) (string, error) {
+	return "", ErrUnavailable
+}
+
+// Set always fails, the system has no supported keyring.
+func Set(account, secret string) error {
+	return ErrUnavailable
+}
@@ -0,0 +1,14 @@
+//go:build linux || darwin
+
+package metrics
+
+import "syscall"
+
+// diskUsage returns the used percent of the disk the directory is on.
+func diskUsage(dir string) (int, bool) {
+	var st syscall.Statfs_t
+	if err := syscall.Statfs(dir, &st); err != nil || st.Blocks == 0 {
+		return 0, false
+	}
+	return int(100 - st.Bavail*100/st.Blocks), true
+}
@@ -0,0 +1,14 @@
+//go:build darwin
+
+package metrics
+
+import "os/exec"
+
+// loadAverage returns the 1 minute load average reported by sysctl.
+func loadAverage() (float64, bool) {
+	out, err := exec.Command("sysctl", "-n", "vm.loadavg").Output()
+	if err != nil {
+		return 0, false
+	}
+	return parseLoad(string(out))
+}
@@ -0,0 +1,14 @@
+//go:build linux
+
+package metrics
+
+import "os"
+
+// loadAverage returns the 1 minute load average from /proc/loadavg.
+func loadAverage() (float64, bool) {
+	data, err := os.ReadFile("/proc/loadavg")
+	if err != nil {
+		return 0, false
+	}
+	return parseLoad(string(data))
+}
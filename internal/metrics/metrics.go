@@ -0,0 +1,84 @@
+// Package metrics reads host metrics for the opt-in power failure events: spikes of the load average,
+// disk usage and a running down battery of the real machine turn into trouble on the floor.
+package metrics
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+
+	"github.com/vinser/haunteed/internal/paths"
+	"github.com/vinser/haunteed/internal/power"
+)
+
+const (
+	BrownoutLoad = 1.5 // Load average per CPU the lights brown out at
+	BrownoutDisk = 95  // Disk usage percent the lights brown out at
+	LowBattery   = 15  // Battery percent an extra ghost comes out at when unplugged
+	unknown      = -1
+)
+
+// Sample is a reading of the host metrics, unknown values are -1.
+type Sample struct {
+	Load      float64 // 1 minute load average per CPU
+	Disk      int     // Used percent of the disk with the data directory
+	Battery   int     // Battery charge percent
+	OnBattery bool    // Unplugged and discharging
+}
+
+// Provider reads host metrics.
+type Provider interface {
+	Sample() Sample
+}
+
+// Host reads the metrics of the machine the game runs on.
+type Host struct{}
+
+func (Host) Sample() Sample {
+	s := Sample{Load: unknown, Disk: unknown, Battery: unknown}
+	if load, ok := loadAverage(); ok {
+		s.Load = load / float64(runtime.NumCPU())
+	}
+	if dir, err := paths.Data.Path(); err == nil {
+		if used, ok := diskUsage(dir); ok {
+			s.Disk = used
+		}
+	}
+	if st, ok := power.Read(); ok {
+		s.Battery, s.OnBattery = st.Level, st.OnBattery
+	}
+	return s
+}
+
+// Fake is a provider with fixed metrics for tests and demos.
+type Fake Sample
+
+func (f Fake) Sample() Sample {
+	return Sample(f)
+}
+
+// Events are the floor events the metrics call for.
+type Events struct {
+	Brownout   bool // The lights flicker and fade, see mutator.Brownout
+	ExtraGhost bool // The reinforcement ghosts come out right away
+}
+
+// Read samples the provider and translates spikes into events.
+func Read(p Provider) Events {
+	s := p.Sample()
+	return Events{
+		Brownout:   s.Load >= BrownoutLoad || s.Disk >= BrownoutDisk,
+		ExtraGhost: s.OnBattery && s.Battery != unknown && s.Battery <= LowBattery,
+	}
+}
+
+// parseLoad parses the first number of a load average line,
+// e.g. "0.52 0.58 0.59 1/467 12345" on Linux or "{ 1.23 1.10 1.05 }" on macOS.
+func parseLoad(line string) (float64, bool) {
+	fields := strings.Fields(strings.Trim(line, "{} \n"))
+	if len(fields) == 0 {
+		return 0, false
+	}
+	load, err := strconv.ParseFloat(fields[0], 64)
+	return load, err == nil
+}
@@ -0,0 +1,13 @@
+//go:build !linux && !darwin
+
+package metrics
+
+// loadAverage is unknown on other systems.
+func loadAverage() (float64, bool) {
+	return 0, false
+}
+
+// diskUsage is unknown on other systems.
+func diskUsage(dir string) (int, bool) {
+	return 0, false
+}
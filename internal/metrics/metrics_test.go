@@ -0,0 +1,38 @@
+package metrics
+
+import "testing"
+
+func TestRead(t *testing.T) {
+	calm := Sample{Load: 0.3, Disk: 40, Battery: 80, OnBattery: true}
+	tests := []struct {
+		name string
+		s    Sample
+		want Events
+	}{
+		{"calm", calm, Events{}},
+		{"busy", Sample{Load: 2, Disk: 40, Battery: unknown}, Events{Brownout: true}},
+		{"disk full", Sample{Load: unknown, Disk: 97, Battery: unknown}, Events{Brownout: true}},
+		{"low battery", Sample{Load: 0.3, Disk: 40, Battery: 10, OnBattery: true}, Events{ExtraGhost: true}},
+		{"charging", Sample{Load: 0.3, Disk: 40, Battery: 10}, Events{}},
+		{"unknown", Sample{Load: unknown, Disk: unknown, Battery: unknown, OnBattery: true}, Events{}},
+	}
+	for _, tt := range tests {
+		if got := Read(Fake(tt.s)); got != tt.want {
+			t.Errorf("%s: Read() = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestParseLoad(t *testing.T) {
+	for line, want := range map[string]float64{
+		"0.52 0.58 0.59 1/467 12345\n": 0.52,
+		"{ 1.23 1.10 1.05 }\n":         1.23,
+	} {
+		if got, ok := parseLoad(line); !ok || got != want {
+			t.Errorf("parseLoad(%q) = %v, %v, want %v", line, got, ok, want)
+		}
+	}
+	if _, ok := parseLoad(""); ok {
+		t.Error("parseLoad of an empty line should fail")
+	}
+}
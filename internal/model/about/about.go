@@ -10,6 +10,7 @@ import (
 	"github.com/vinser/haunteed/internal/embeddata"
 	"github.com/vinser/haunteed/internal/render"
 	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
 )
 
 const (
@@ -26,6 +27,7 @@ type Model struct {
 	startHeight int
 	termWidth   int
 	termHeight  int
+	ui          style.UI
 
 	viewport viewport.Model
 }
@@ -38,7 +40,7 @@ func closeAboutCmd() tea.Cmd {
 	}
 }
 
-func New(state *state.State, width, height int) Model {
+func New(state *state.State, ui style.UI, width, height int) Model {
 	width = max(width, lipgloss.Width(footer))
 	bytes, err := embeddata.ReadAboutMD()
 	if err != nil {
@@ -52,6 +54,7 @@ func New(state *state.State, width, height int) Model {
 	vp.SetContent(glam)
 
 	return Model{
+		ui:          ui,
 		width:       width,
 		height:      height,
 		startHeight: height,
@@ -93,7 +96,7 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 const footer = "↑ ↓ — scroll, esc — back, q — quit"
 
 func (m Model) View() string {
-	return render.Page("About", "\n"+m.viewport.View()+"\n", footer, m.width, m.height, m.termWidth, m.termHeight)
+	return render.Page(m.ui, "About", "\n"+m.viewport.View()+"\n", footer, m.width, m.height, m.termWidth, m.termHeight)
 }
 
 func glamContent(content string, width, frame, gutter int) string {
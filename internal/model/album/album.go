@@ -0,0 +1,144 @@
+package album
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/screenshot"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+// rows is the number of screenshots listed at once
+const rows = 10
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	shots    []screenshot.Shot
+	selected int
+	frame    string // the screenshot on the screen, empty in the list
+
+	soundManager *sound.Manager
+}
+
+// CloseAlbumMsg is a message sent when the player leaves the screenshots.
+type CloseAlbumMsg struct{}
+
+func closeAlbumCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseAlbumMsg{}
+	}
+}
+
+// New returns the list of screenshots taken at notable moments, the latest is selected.
+func New(ui style.UI, width, height int, sm *sound.Manager) Model {
+	shots, _ := screenshot.List()
+	return Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(footer)),
+		height:       height,
+		shots:        shots,
+		selected:     len(shots) - 1,
+		soundManager: sm,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	key, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+	if m.frame != "" {
+		switch key.String() {
+		case "left", "up":
+			m.browse(-1)
+		case "right", "down":
+			m.browse(1)
+		default:
+			m.frame = ""
+			m.soundManager.Play(sound.UI_CANCEL)
+		}
+		return m, nil
+	}
+	switch key.String() {
+	case "esc":
+		m.soundManager.Play(sound.UI_CANCEL)
+		return m, closeAlbumCmd()
+	case "up":
+		m.selected = max(m.selected-1, 0)
+		m.soundManager.Play(sound.UI_CLICK)
+	case "down":
+		m.selected = min(m.selected+1, len(m.shots)-1)
+		m.soundManager.Play(sound.UI_CLICK)
+	case "enter", " ":
+		m.browse(0)
+	}
+	return m, nil
+}
+
+// browse shows the screenshot step away from the selected one.
+func (m *Model) browse(step int) {
+	if len(m.shots) == 0 {
+		return
+	}
+	m.selected = max(min(m.selected+step, len(m.shots)-1), 0)
+	frame, err := screenshot.Read(m.shots[m.selected])
+	if err != nil {
+		frame = err.Error()
+	}
+	m.frame = frame
+	m.soundManager.Play(sound.UI_CLICK)
+}
+
+const footer = "↑ ↓ — select, enter — view, esc — back"
+
+func (m Model) View() string {
+	if m.frame != "" {
+		return m.frame // Shown as taken, ← → browse and other keys go back to the list
+	}
+	return render.Page(m.ui, "Screenshots", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	if len(m.shots) == 0 {
+		return "No screenshots yet.\n\nBeat the high score or get caught deeper than ever,\nthe moment is kept here.\n"
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Screenshot %d of %d\n\n", m.selected+1, len(m.shots)))
+	first := max(min(m.selected-rows/2, len(m.shots)-rows), 0)
+	for i := first; i < min(first+rows, len(m.shots)); i++ {
+		s := m.shots[i]
+		prefix := "  "
+		if i == m.selected {
+			prefix = "▶ "
+		}
+		line := prefix + s.TakenAt.Format("2006-01-02 15:04") + "  " + s.Reason
+		if i == m.selected {
+			b.WriteString(m.ui.SetupItemSelected.Render(line))
+		} else {
+			b.WriteString(m.ui.SetupItem.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(m.ui.SetupDescription.Render("Also kept as files, see \"haunteed paths\"."))
+	b.WriteString("\n")
+	return b.String()
+}
@@ -0,0 +1,155 @@
+package bestiary
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/embeddata"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+// Entry describes a ghost, the weakness is revealed once the ghost is eaten.
+type Entry struct {
+	Ghost    string `json:"ghost"`
+	Title    string `json:"title"`
+	Behavior string `json:"behavior"`
+	Weakness string `json:"weakness"`
+}
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	entries  []Entry
+	met      map[string]int
+	eaten    map[string]int
+	selected int
+
+	soundManager *sound.Manager
+}
+
+// CloseBestiaryMsg is a message sent when the player leaves the bestiary.
+type CloseBestiaryMsg struct{}
+
+func closeBestiaryCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseBestiaryMsg{}
+	}
+}
+
+func New(st *state.State, ui style.UI, width, height int, sm *sound.Manager) Model {
+	bytes, err := embeddata.ReadBestiary()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var data struct {
+		Entries []Entry `json:"entries"`
+	}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		log.Fatal(err)
+	}
+	return Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(footer)),
+		height:       height,
+		entries:      data.Entries,
+		met:          st.GhostsMet,
+		eaten:        st.GhostsEaten,
+		soundManager: sm,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "enter":
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, closeBestiaryCmd()
+		case "up":
+			if m.selected > 0 {
+				m.selected--
+			}
+			m.soundManager.Play(sound.UI_CLICK)
+		case "down":
+			if m.selected < len(m.entries)-1 {
+				m.selected++
+			}
+			m.soundManager.Play(sound.UI_CLICK)
+		}
+	}
+	return m, nil
+}
+
+const footer = "↑ ↓ — select ghost, esc — back"
+
+func (m Model) View() string {
+	return render.Page(m.ui, "Bestiary", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+// known returns true if the ghost was ever met or eaten.
+func (m Model) known(e Entry) bool {
+	return m.met[e.Ghost] > 0 || m.eaten[e.Ghost] > 0
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+	for i, e := range m.entries {
+		prefix := "  "
+		if i == m.selected {
+			prefix = "▶ "
+		}
+		line := prefix + "???"
+		if m.known(e) {
+			line = fmt.Sprintf("%s%s, %s", prefix, e.Ghost, e.Title)
+		}
+		if i == m.selected {
+			b.WriteString(m.ui.SetupItemSelected.Render(line))
+		} else {
+			b.WriteString(m.ui.SetupItem.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	if len(m.entries) == 0 {
+		return b.String()
+	}
+
+	// Descriptions are wrapped to the page width
+	wrap := lipgloss.NewStyle().Width(m.width)
+	e := m.entries[m.selected]
+	if !m.known(e) {
+		b.WriteString(m.ui.SetupDescription.Render("Not met yet. Something moves in the dark..."))
+	} else {
+		b.WriteString(wrap.Render(e.Behavior))
+		b.WriteString("\n\n")
+		if m.eaten[e.Ghost] > 0 {
+			b.WriteString(wrap.Render("Weakness: " + e.Weakness))
+		} else {
+			b.WriteString(m.ui.SetupDescription.Render("Eat it once to learn its weakness."))
+		}
+		b.WriteString("\n\n")
+		b.WriteString(m.ui.SetupDescription.Render(fmt.Sprintf("Met %d times, eaten %d times", m.met[e.Ghost], m.eaten[e.Ghost])))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
@@ -21,6 +21,9 @@ type Model struct {
 	height       int
 }
 
+// Title is the terminal title shown while the boss key is on.
+const Title = "tail -f /var/log/syslog"
+
 // TickMsg refreshes boss key
 type TickMsg time.Time
 
@@ -0,0 +1,225 @@
+package challenges
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/embeddata"
+	"github.com/vinser/haunteed/internal/mutator"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+// Challenge is a handcrafted floor: a seeded ground floor of the game mode played with special rules.
+// The stairs up have to be reached as fast as possible.
+type Challenge struct {
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Mode        string   `json:"mode"`
+	Seed        int64    `json:"seed"`
+	Rules       []string `json:"rules"` // names of challenge rules, see mutator.Rules
+	Medals      Medals   `json:"medals"`
+}
+
+// Medals are the clear time thresholds in seconds.
+type Medals struct {
+	Gold   int `json:"gold"`
+	Silver int `json:"silver"`
+	Bronze int `json:"bronze"`
+}
+
+// Medal is an award for clearing a challenge in time.
+type Medal int
+
+const (
+	NoMedal Medal = iota
+	Bronze
+	Silver
+	Gold
+)
+
+var medalNames = []string{"no medal", "bronze", "silver", "gold"}
+
+// String returns the medal name.
+func (m Medal) String() string {
+	return medalNames[m]
+}
+
+// Medal returns the medal for clearing the challenge in d.
+func (c Challenge) Medal(d time.Duration) Medal {
+	switch s := d.Seconds(); {
+	case s <= float64(c.Medals.Gold):
+		return Gold
+	case s <= float64(c.Medals.Silver):
+		return Silver
+	case s <= float64(c.Medals.Bronze):
+		return Bronze
+	}
+	return NoMedal
+}
+
+// Mutators returns the rules of the challenge, unknown rules are skipped.
+func (c Challenge) Mutators() mutator.Set {
+	var set mutator.Set
+	for _, rule := range c.Rules {
+		if m, ok := mutator.Rules[rule]; ok {
+			set = append(set, m)
+		}
+	}
+	return set
+}
+
+// Load returns the challenges embedded into the game.
+func Load() []Challenge {
+	bytes, err := embeddata.ReadChallenges()
+	if err != nil {
+		log.Fatal(err)
+	}
+	var data struct {
+		Challenges []Challenge `json:"challenges"`
+	}
+	if err := json.Unmarshal(bytes, &data); err != nil {
+		log.Fatal(err)
+	}
+	return data.Challenges
+}
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	challenges []Challenge
+	state      *state.State
+	selected   int
+	result     string // result of the last challenge played
+
+	soundManager *sound.Manager
+}
+
+// StartChallengeMsg is a message sent when the player picks a challenge.
+type StartChallengeMsg struct {
+	Challenge Challenge
+}
+
+func startChallengeCmd(c Challenge) tea.Cmd {
+	return func() tea.Msg {
+		return StartChallengeMsg{Challenge: c}
+	}
+}
+
+// CloseChallengesMsg is a message sent when the player leaves the challenge list.
+type CloseChallengesMsg struct{}
+
+func closeChallengesCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseChallengesMsg{}
+	}
+}
+
+// New returns the challenge list with the last one played selected and its result shown.
+func New(st *state.State, last, result string, ui style.UI, width, height int, sm *sound.Manager) Model {
+	m := Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(footer)),
+		height:       height,
+		challenges:   Load(),
+		state:        st,
+		result:       result,
+		soundManager: sm,
+	}
+	for i, c := range m.challenges {
+		if c.ID == last {
+			m.selected = i
+		}
+	}
+	return m
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, closeChallengesCmd()
+		case "up":
+			if m.selected > 0 {
+				m.selected--
+			}
+			m.soundManager.Play(sound.UI_CLICK)
+		case "down":
+			if m.selected < len(m.challenges)-1 {
+				m.selected++
+			}
+			m.soundManager.Play(sound.UI_CLICK)
+		case "enter", " ":
+			if len(m.challenges) > 0 {
+				m.soundManager.Play(sound.UI_SAVE)
+				return m, startChallengeCmd(m.challenges[m.selected])
+			}
+		}
+	}
+	return m, nil
+}
+
+const footer = "↑ ↓ — select challenge, enter — start, esc — back"
+
+func (m Model) View() string {
+	return render.Page(m.ui, "Challenges", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+	for i, c := range m.challenges {
+		prefix := "  "
+		if i == m.selected {
+			prefix = "▶ "
+		}
+		best := "not cleared"
+		if d, ok := m.state.ChallengeBest(c.ID); ok {
+			best = fmt.Sprintf("best %.1fs, %s", d.Seconds(), c.Medal(d))
+		}
+		line := fmt.Sprintf("%s%-15s %s", prefix, c.Name, best)
+		if i == m.selected {
+			b.WriteString(m.ui.SetupItemSelected.Render(line))
+		} else {
+			b.WriteString(m.ui.SetupItem.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	if len(m.challenges) > 0 {
+		c := m.challenges[m.selected]
+		b.WriteString("\n")
+		b.WriteString(lipgloss.NewStyle().Width(m.width).Render(c.Description))
+		b.WriteString("\n")
+		b.WriteString(m.ui.SetupDescription.Render(fmt.Sprintf("%s mode, gold %ds, silver %ds, bronze %ds",
+			c.Mode, c.Medals.Gold, c.Medals.Silver, c.Medals.Bronze)))
+		b.WriteString("\n")
+	}
+	if m.result != "" {
+		b.WriteString("\n")
+		b.WriteString(m.result)
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,183 @@
+package fame
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/card"
+	"github.com/vinser/haunteed/internal/model/splash"
+	"github.com/vinser/haunteed/internal/nick"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+const (
+	tickPeriod  = 100 * time.Millisecond
+	slidePeriod = 8 * time.Second
+	topScores   = 3 // High scores shown of each mode
+)
+
+// slide is a page of the hall of fame.
+type slide struct {
+	title string
+	lines []string
+}
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	slides  []slide
+	minimap slide // deepest floor, shown once it is generated
+	frame   int
+}
+
+type TickMsg struct{}
+
+func tickCmd() tea.Cmd {
+	return tea.Tick(tickPeriod, func(t time.Time) tea.Msg {
+		return TickMsg{}
+	})
+}
+
+// WakeMsg is sent on any key or mouse press, the screen the hall of fame took over comes back.
+type WakeMsg struct{}
+
+func wakeCmd() tea.Cmd {
+	return func() tea.Msg {
+		return WakeMsg{}
+	}
+}
+
+// MinimapMsg carries the walls of the deepest floor reached, see floor.Minimap.
+type MinimapMsg struct {
+	Floor int
+	Lines []string
+}
+
+// New returns the hall of fame of the saved runs, a screensaver of kiosk installs:
+// high scores, achievements and the deepest floor take turns while the splash ghosts march below.
+func New(st *state.State, ui style.UI, width, height int) Model {
+	m := Model{
+		ui:     ui,
+		width:  width,
+		height: height,
+	}
+	modes := []struct {
+		name   string
+		scores []state.HighScore
+	}{
+		{state.ModeEasy, st.EasyScores},
+		{state.ModeNoisy, st.NoisyScores},
+		{state.ModeCrazy, st.CrazyScores},
+	}
+	for _, mode := range modes {
+		if len(mode.scores) > 0 {
+			m.slides = append(m.slides, slide{title: "Top " + mode.name, lines: scoreLines(mode.scores)})
+		}
+	}
+	if lines := achievementLines(st.Runs); len(lines) > 0 {
+		m.slides = append(m.slides, slide{title: "Achievements", lines: lines})
+	}
+	return m
+}
+
+// scoreLines returns the top high scores of a mode.
+func scoreLines(scores []state.HighScore) []string {
+	scores = scores[:min(len(scores), topScores)]
+	digits := 0
+	for _, hs := range scores {
+		digits = max(digits, len(strconv.Itoa(hs.Score)))
+	}
+	var lines []string
+	for i, hs := range scores {
+		lines = append(lines, fmt.Sprintf("%d. %s — %s", i+1, render.PadLeft(strconv.Itoa(hs.Score), digits), render.Isolate(nick.Sanitize(hs.Nick))))
+	}
+	return lines
+}
+
+// achievementLines returns how many runs earned each badge and the best run.
+func achievementLines(runs []state.Run) []string {
+	if len(runs) == 0 {
+		return nil
+	}
+	earned := make(map[string]int)
+	var order []string
+	best := runs[0]
+	for _, r := range runs {
+		for _, name := range card.Achievements(r) {
+			if earned[name] == 0 {
+				order = append(order, name)
+			}
+			earned[name]++
+		}
+		if r.Score > best.Score {
+			best = r
+		}
+	}
+	var lines []string
+	for _, name := range order {
+		lines = append(lines, fmt.Sprintf("%s × %d", name, earned[name]))
+	}
+	if len(lines) > 0 {
+		lines = append(lines, "")
+	}
+	lines = append(lines, fmt.Sprintf("Best run: %d pts, floor %d, %s", best.Score, best.Floor, best.Mode))
+	return lines
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return tickCmd()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case TickMsg:
+		m.frame++
+		return m, tickCmd()
+	case MinimapMsg:
+		m.minimap = slide{title: fmt.Sprintf("Deepest floor: %d", msg.Floor), lines: msg.Lines}
+	case tea.KeyMsg, tea.MouseMsg:
+		return m, wakeCmd()
+	}
+	return m, nil
+}
+
+const footer = "any key — back"
+
+func (m Model) View() string {
+	return render.Page(m.ui, "Hall of Fame", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+// current returns the slide on show, they change every slidePeriod.
+func (m Model) current() (slide, bool) {
+	slides := m.slides
+	if m.minimap.lines != nil {
+		slides = append(slides[:len(slides):len(slides)], m.minimap)
+	}
+	if len(slides) == 0 {
+		return slide{}, false
+	}
+	return slides[m.frame/int(slidePeriod/tickPeriod)%len(slides)], true
+}
+
+func (m Model) renderContent() string {
+	lines := []string{"No runs yet. Be the first!"}
+	if s, ok := m.current(); ok {
+		lines = append([]string{style.SplashHaunteed.Render(s.title), ""}, s.lines...)
+	}
+	lines = append(lines, "", splash.Parade(m.width, m.frame))
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
@@ -0,0 +1,107 @@
+package gallery
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	photos   []state.Evidence
+	selected int
+
+	soundManager *sound.Manager
+}
+
+// CloseGalleryMsg is a message sent when the player leaves the gallery.
+type CloseGalleryMsg struct{}
+
+func closeGalleryCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseGalleryMsg{}
+	}
+}
+
+// New returns the gallery of ghost photos, the latest photo is shown first.
+func New(st *state.State, ui style.UI, width, height int, sm *sound.Manager) Model {
+	return Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(footer)),
+		height:       height,
+		photos:       st.Gallery,
+		selected:     len(st.Gallery) - 1,
+		soundManager: sm,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc", "enter":
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, closeGalleryCmd()
+		case "left":
+			if m.selected > 0 {
+				m.selected--
+			}
+			m.soundManager.Play(sound.UI_CLICK)
+		case "right":
+			if m.selected < len(m.photos)-1 {
+				m.selected++
+			}
+			m.soundManager.Play(sound.UI_CLICK)
+		}
+	}
+	return m, nil
+}
+
+const footer = "← → — browse, esc — back"
+
+func (m Model) View() string {
+	return render.Page(m.ui, "Evidence", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	if len(m.photos) == 0 {
+		return "No evidence yet.\n\nPick up a camera ◙ and press e next to a ghost.\n"
+	}
+	e := m.photos[m.selected]
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Photo %d of %d\n\n", m.selected+1, len(m.photos)))
+	width := 0
+	for _, row := range e.Snapshot {
+		width = max(width, len([]rune(row)))
+	}
+	frame := strings.Repeat("─", width)
+	b.WriteString("┌" + frame + "┐\n")
+	for _, row := range e.Snapshot {
+		b.WriteString("│" + row + "│\n")
+	}
+	b.WriteString("└" + frame + "┘\n\n")
+	b.WriteString(fmt.Sprintf("%s on floor %d\n", e.Ghost, e.Floor))
+	b.WriteString(m.ui.SetupDescription.Render(e.TakenAt.Format("2006-01-02 15:04")))
+	b.WriteString("\n")
+	return b.String()
+}
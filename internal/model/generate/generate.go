@@ -0,0 +1,209 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/floor"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+const (
+	// showDelay is how long a generation goes unnoticed before the progress screen shows up
+	showDelay = 200 * time.Millisecond
+	barWidth  = 28
+)
+
+// Build generates a floor reporting its progress, it returns nil if the context is cancelled.
+type Build func(ctx context.Context, progress func(floor.Progress)) *floor.Floor
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	index    int
+	build    Build
+	job      int // messages of stopped jobs are ignored
+	cancel   context.CancelFunc
+	updates  chan tea.Msg
+	started  time.Time
+	progress floor.Progress
+	spinner  spinner.Model
+
+	soundManager *sound.Manager
+}
+
+type progressMsg struct {
+	job      int
+	progress floor.Progress
+}
+
+type doneMsg struct {
+	job   int
+	floor *floor.Floor
+}
+
+// ReadyMsg is a message sent when the floor is generated.
+type ReadyMsg struct {
+	Floor *floor.Floor
+}
+
+func readyCmd(f *floor.Floor) tea.Cmd {
+	return func() tea.Msg {
+		return ReadyMsg{Floor: f}
+	}
+}
+
+// CancelMsg is a message sent when the player cancels the generation.
+type CancelMsg struct{}
+
+func cancelCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CancelMsg{}
+	}
+}
+
+// New returns the progress screen of the floor generation, the generation begins with Start.
+func New(index int, build Build, ui style.UI, width, height int, sm *sound.Manager) Model {
+	return Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(footer)),
+		height:       height,
+		index:        index,
+		build:        build,
+		spinner:      spinner.New(spinner.WithSpinner(spinner.Dot)),
+		soundManager: sm,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+// Start runs the generation in the background from scratch.
+func (m *Model) Start() tea.Cmd {
+	m.Stop()
+	ctx, cancel := context.WithCancel(context.Background())
+	job, updates, build := m.job, make(chan tea.Msg), m.build
+	m.cancel = cancel
+	m.updates = updates
+	m.started = time.Now()
+	m.progress = floor.Progress{}
+	go func() {
+		defer close(updates)
+		f := build(ctx, func(p floor.Progress) {
+			send(ctx, updates, progressMsg{job: job, progress: p})
+		})
+		if f != nil {
+			send(ctx, updates, doneMsg{job: job, floor: f})
+		}
+	}()
+	return tea.Batch(m.spinner.Tick, wait(updates))
+}
+
+// Stop cancels the generation in progress, if any.
+func (m *Model) Stop() {
+	if m.cancel != nil {
+		m.cancel()
+		m.cancel = nil
+	}
+	m.job++
+}
+
+// send passes the message to the screen unless the generation is cancelled.
+func send(ctx context.Context, updates chan<- tea.Msg, msg tea.Msg) {
+	select {
+	case updates <- msg:
+	case <-ctx.Done():
+	}
+}
+
+// wait returns the next message of the generation, nil once it is over.
+func wait(updates <-chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		msg, ok := <-updates
+		if !ok {
+			return nil
+		}
+		return msg
+	}
+}
+
+// Visible returns true once the generation takes long enough to be worth a screen of its own.
+func (m Model) Visible() bool {
+	return time.Since(m.started) >= showDelay
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case progressMsg:
+		if msg.job != m.job {
+			return m, nil
+		}
+		m.progress = msg.progress
+		return m, wait(m.updates)
+	case doneMsg:
+		if msg.job != m.job {
+			return m, nil
+		}
+		m.Stop()
+		return m, readyCmd(msg.floor)
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	case tea.KeyMsg:
+		if msg.String() == "esc" {
+			m.Stop()
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, cancelCmd()
+		}
+	}
+	return m, nil
+}
+
+const footer = "esc — cancel"
+
+func (m Model) View() string {
+	return render.Page(m.ui, fmt.Sprintf("Floor %d", m.index), m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	p := m.progress
+	var b strings.Builder
+	stage := p.Stage
+	if stage == "" {
+		stage = "Waking up the builders"
+	}
+	b.WriteString(m.spinner.View() + stage + "\n\n") // the spinner frames end with a space
+
+	filled := 0
+	if p.Total > 0 {
+		filled = barWidth * p.Done / p.Total
+	}
+	b.WriteString(fmt.Sprintf("[%s%s] %d/%d\n\n", strings.Repeat("█", filled), strings.Repeat("░", barWidth-filled), p.Done, p.Total))
+
+	// The time left is estimated from the pace of the stages done so far
+	if p.Done > 0 && p.Done < p.Total {
+		elapsed := time.Since(m.started)
+		left := elapsed * time.Duration(p.Total-p.Done) / time.Duration(p.Done)
+		b.WriteString(m.ui.SetupDescription.Render(fmt.Sprintf("About %.1fs left", left.Seconds())))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
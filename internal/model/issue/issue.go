@@ -0,0 +1,161 @@
+package issue
+
+import (
+	"os"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/card"
+	"github.com/vinser/haunteed/internal/paths"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/report"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	report       report.Report
+	input        textinput.Model
+	path         string // saved report file, empty until the report is saved
+	err          error
+	copied       bool
+	soundManager *sound.Manager
+}
+
+// CloseIssueMsg is a message sent when the player leaves the issue report.
+type CloseIssueMsg struct{}
+
+func closeIssueCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseIssueMsg{}
+	}
+}
+
+// copyLinkCmd copies the issue link into the terminal clipboard.
+func copyLinkCmd(link string) tea.Cmd {
+	return func() tea.Msg {
+		card.Copy(os.Stdout, link)
+		return nil
+	}
+}
+
+// New returns the issue report screen, the player describes the issue and saves the report.
+func New(r report.Report, ui style.UI, width, height int, sm *sound.Manager) Model {
+	ti := textinput.New()
+	ti.Prompt = "What happened? "
+	ti.Placeholder = "optional"
+	ti.CharLimit = 200
+	ti.Width = 40
+	ti.Focus()
+
+	return Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(savedFooter)),
+		height:       height,
+		report:       r,
+		input:        ti,
+		soundManager: sm,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+// Typing returns true while the description takes the keys.
+func (m Model) Typing() bool {
+	return m.path == ""
+}
+
+func (m Model) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, closeIssueCmd()
+		case "enter":
+			if m.Typing() {
+				m.report.Description = m.input.Value()
+				m.path, m.err = m.report.Save()
+				if m.err != nil {
+					m.path = ""
+					return m, nil
+				}
+				m.soundManager.Play(sound.UI_SAVE)
+				return m, nil
+			}
+		case "c":
+			if !m.Typing() {
+				m.copied = true
+				return m, copyLinkCmd(m.report.IssueURL(m.path))
+			}
+		}
+	}
+	if !m.Typing() {
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+const (
+	footer      = "enter — save, esc — back"
+	savedFooter = "c — copy issue link, esc — back"
+)
+
+func (m Model) View() string {
+	f := footer
+	if !m.Typing() {
+		f = savedFooter
+	}
+	return render.Page(m.ui, "Report issue", m.renderContent(), f, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	var lines []string
+	if m.Typing() {
+		lines = append(lines,
+			"The report gets the version, settings, floor seed,",
+			"terminal and the latest game events attached.",
+			"",
+			m.input.View(),
+		)
+		if m.err != nil {
+			lines = append(lines, "", m.ui.SetupDescription.Render("Couldn't save: "+m.err.Error()))
+		}
+		return lipgloss.JoinVertical(lipgloss.Left, lines...)
+	}
+	lines = append(lines,
+		"Saved to",
+		m.shownPath(),
+		"",
+		"Press c to copy a link to a pre-filled GitHub issue,",
+		"or attach the file to a new issue at",
+		"github.com/vinser/haunteed/issues",
+	)
+	if m.copied {
+		lines = append(lines, "", m.ui.SetupDescription.Render("Copied, paste it into a browser"))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
+
+// shownPath returns the path of the saved report, the home directory is left out in streamer mode.
+func (m Model) shownPath() string {
+	if m.report.Settings != nil && m.report.Settings.Streamer {
+		return paths.Hide(m.path)
+	}
+	return m.path
+}
@@ -0,0 +1,139 @@
+package latency
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+const (
+	beatPeriod = 750 * time.Millisecond // 80 beats per minute
+	flashTime  = 150 * time.Millisecond
+	step       = 10 // Offset change per key press in milliseconds
+)
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	offsetMs     int  // audio offset being tried
+	lit          bool // the beat flash is on
+	beats        int
+	soundManager *sound.Manager
+}
+
+type BeatMsg struct{}
+
+func beatCmd() tea.Cmd {
+	return tea.Tick(beatPeriod, func(t time.Time) tea.Msg {
+		return BeatMsg{}
+	})
+}
+
+type DimMsg struct{}
+
+func dimCmd() tea.Cmd {
+	return tea.Tick(flashTime, func(t time.Time) tea.Msg {
+		return DimMsg{}
+	})
+}
+
+// SaveLatencyMsg is sent when the player keeps the audio offset.
+type SaveLatencyMsg struct {
+	OffsetMs int
+}
+
+func saveLatencyCmd(offsetMs int) tea.Cmd {
+	return func() tea.Msg {
+		return SaveLatencyMsg{OffsetMs: offsetMs}
+	}
+}
+
+type CloseLatencyMsg struct{}
+
+func closeLatencyCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseLatencyMsg{}
+	}
+}
+
+// New returns the audio latency calibration: a metronome flashes on the beat and clicks,
+// the player shifts the click until both land together.
+func New(st *state.State, ui style.UI, width, height int, sm *sound.Manager) Model {
+	return Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(footer)),
+		height:       height,
+		offsetMs:     st.AudioOffsetMs,
+		soundManager: sm,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return beatCmd()
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case BeatMsg:
+		m.lit = true
+		m.beats++
+		// The click of the next beat is scheduled ahead, so the offset can make it up
+		m.soundManager.SetOffset(time.Duration(m.offsetMs) * time.Millisecond)
+		m.soundManager.PlayAt(sound.UI_CLICK, time.Now().Add(beatPeriod))
+		return m, tea.Batch(beatCmd(), dimCmd())
+	case DimMsg:
+		m.lit = false
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "left":
+			m.offsetMs = max(m.offsetMs-step, 0)
+		case "right":
+			m.offsetMs = min(m.offsetMs+step, state.MaxAudioOffsetMs)
+		case "enter", "s":
+			m.soundManager.Play(sound.UI_SAVE)
+			return m, saveLatencyCmd(m.offsetMs)
+		case "esc":
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, closeLatencyCmd()
+		}
+	}
+	return m, nil
+}
+
+const footer = "← → — shift the click, enter — save, esc — cancel"
+
+func (m Model) View() string {
+	return render.Page(m.ui, "Audio latency", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	beat := "○"
+	if m.lit {
+		beat = style.SplashHaunteed.Render("●")
+	}
+	lines := []string{
+		"Wireless headsets lag behind the screen.",
+		"Shift the click until it lands right on the flash.",
+		"",
+		strings.Repeat(" ", 12) + beat,
+		"",
+		fmt.Sprintf("Offset: %d ms", m.offsetMs),
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, lines...)
+}
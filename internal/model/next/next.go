@@ -2,11 +2,16 @@ package next
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/mutator"
 	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/style"
 )
 
 const nextPeriod = 5 * time.Second
@@ -16,11 +21,70 @@ type Model struct {
 	height     int
 	termWidth  int
 	termHeight int
+	ui         style.UI
 
 	index     int
+	mutators  mutator.Set
+	shop      *Shop
+	stats     map[dweller.GhostType]dweller.GhostStats
 	nextUntil time.Time
 }
 
+// Item is an offer of the shop on the floor intro.
+type Item int
+
+const (
+	ExtraLife Item = iota
+	TrapKit
+	FuseCharge
+)
+
+var itemNames = map[Item]string{
+	ExtraLife:  "Extra life",
+	TrapKit:    "Trap kit",
+	FuseCharge: "Fuse charge",
+}
+
+// Price returns the price of the item once n of them were bought in the run.
+// Lives double in price, the rest grow by the base price.
+func Price(item Item, n int) int {
+	switch item {
+	case ExtraLife:
+		return 1000 << n
+	case TrapKit:
+		return 200 * (n + 1)
+	default: // FuseCharge
+		return 150 * (n + 1)
+	}
+}
+
+// Shop holds what the haunteed can spend points on before the floor.
+type Shop struct {
+	Points int          // points to spend
+	Bought map[Item]int // items bought so far in the run
+	Fuses  bool         // fuse charges are only sold in crazy mode
+}
+
+// offers returns the items for sale in the order of their keys.
+func (s *Shop) offers() []Item {
+	if s.Fuses {
+		return []Item{ExtraLife, TrapKit, FuseCharge}
+	}
+	return []Item{ExtraLife, TrapKit}
+}
+
+// PurchaseMsg is sent when the player buys an item, the price is already taken off the shop points.
+type PurchaseMsg struct {
+	Item  Item
+	Price int
+}
+
+func purchaseCmd(item Item, price int) tea.Cmd {
+	return func() tea.Msg {
+		return PurchaseMsg{Item: item, Price: price}
+	}
+}
+
 // TickMsg is a tick message for periodic updates.
 type TickMsg time.Time
 
@@ -39,15 +103,28 @@ func timedoutCmd() tea.Cmd {
 	}
 }
 
-func New(index, width, height int) Model {
-	if width < lipgloss.Width(footer) {
-		width = lipgloss.Width(footer)
+// New returns the floor intro model, shop is nil if nothing is for sale.
+// stats are the ghost stats of the floor left behind, none on the first floor.
+func New(index int, mutators mutator.Set, shop *Shop, stats map[dweller.GhostType]dweller.GhostStats, ui style.UI, width, height int) Model {
+	if shop != nil {
+		bought := make(map[Item]int, len(shop.Bought))
+		for item, n := range shop.Bought {
+			bought[item] = n
+		}
+		shop = &Shop{Points: shop.Points, Bought: bought, Fuses: shop.Fuses}
+		if width < lipgloss.Width(shopFooter) {
+			width = lipgloss.Width(shopFooter)
+		}
 	}
 	return Model{
+		ui:     ui,
 		width:  width,
 		height: height,
 
 		index:     index,
+		mutators:  mutators,
+		shop:      shop,
+		stats:     stats,
 		nextUntil: time.Now().Add(nextPeriod),
 	}
 }
@@ -62,9 +139,17 @@ func (m Model) Init() tea.Cmd {
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
-	switch msg.(type) {
+	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		// Ignore all keyboard events during the transition to prevent input queue buildup.
+		if m.shop != nil {
+			switch key := msg.String(); key {
+			case "1", "2", "3":
+				return m.buy(int(key[0] - '1'))
+			case "enter":
+				return m, timedoutCmd()
+			}
+		}
+		// Ignore all other keyboard events during the transition to prevent input queue buildup.
 		return m, nil
 	case TickMsg:
 		if time.Now().After(m.nextUntil) {
@@ -76,16 +161,93 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	return m, tick()
 }
 
-const footer = ""
+// buy takes the price of the i-th offer off the points, the countdown starts over to let the player think.
+func (m Model) buy(i int) (Model, tea.Cmd) {
+	offers := m.shop.offers()
+	if i >= len(offers) {
+		return m, nil
+	}
+	item := offers[i]
+	price := Price(item, m.shop.Bought[item])
+	if price > m.shop.Points {
+		return m, nil
+	}
+	m.shop.Points -= price
+	m.shop.Bought[item]++
+	m.nextUntil = time.Now().Add(nextPeriod)
+	return m, purchaseCmd(item, price)
+}
+
+const (
+	footer     = ""
+	shopFooter = "1 2 3 — buy, enter — go"
+)
 
 func (m Model) View() string {
 	flash := ""
 	if (time.Now().UnixNano()/int64(time.Millisecond)/500)%2 == 0 {
 		flash = fmt.Sprintf("Going to Floor # %d", m.index)
 	}
-	return render.Page(flash, m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+	f := footer
+	if m.shop != nil {
+		f = shopFooter
+	}
+	return render.Page(m.ui, flash, m.renderContent(), f, m.width, m.height, m.termWidth, m.termHeight)
 }
 
 func (m Model) renderContent() string {
-	return "\nGet ready...\n"
+	var b strings.Builder
+	b.WriteString("\nGet ready...\n")
+	if m.mutators.GhostsSurround() {
+		b.WriteString("\nThe ghost king holds court here!\n")
+	}
+	b.WriteString(m.statsTable())
+	if len(m.mutators) > 0 {
+		b.WriteString("\nThis floor:\n")
+		for _, mt := range m.mutators {
+			b.WriteString(fmt.Sprintf("  %s — %s\n", mt.Name(), mt.Description()))
+		}
+	}
+	if m.shop != nil {
+		b.WriteString(fmt.Sprintf("\nShop, %d points to spend:\n", m.shop.Points))
+		for i, item := range m.shop.offers() {
+			price := Price(item, m.shop.Bought[item])
+			mark := ""
+			if price > m.shop.Points {
+				mark = " (can't afford)"
+			}
+			b.WriteString(fmt.Sprintf("  %d  %-12s %5d%s\n", i+1, itemNames[item], price, mark))
+		}
+	}
+	return b.String()
+}
+
+// statsTable returns what each ghost did on the floor left behind, the one with the most close calls
+// is the one to watch out for.
+func (m Model) statsTable() string {
+	if len(m.stats) == 0 {
+		return ""
+	}
+	types := make([]dweller.GhostType, 0, len(m.stats))
+	for t := range m.stats {
+		types = append(types, t)
+	}
+	slices.Sort(types)
+	worst := types[0]
+	for _, t := range types {
+		if m.stats[t].Close > m.stats[worst].Close {
+			worst = t
+		}
+	}
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("\nLast floor:  %6s %6s %6s\n", "Close", "Eaten", "Moved"))
+	for _, t := range types {
+		st := m.stats[t]
+		mark := ""
+		if t == worst && st.Close > 0 {
+			mark = " ◀"
+		}
+		b.WriteString(fmt.Sprintf("  %-10s %6d %6d %6d%s\n", t, st.Close, st.Eaten, st.Traveled, mark))
+	}
+	return b.String()
 }
@@ -0,0 +1,98 @@
+package over
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vinser/haunteed/internal/nick"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+// initialsAlphabet is what an initial rolls through, like on the arcade cabinet
+const initialsAlphabet = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789. "
+
+// initialsCount is the number of initials
+const initialsCount = 3
+
+// initials is the arcade entry of a high score nickname: the letters are rolled with up and down
+// in the slot picked with left and right, so a joystick or a phone keypad is enough.
+type initials struct {
+	letters [initialsCount]int // indexes into initialsAlphabet
+	slot    int
+}
+
+// update handles a key, it returns true when the initials are entered.
+func (in *initials) update(key string) bool {
+	n := len(initialsAlphabet)
+	switch key {
+	case "up":
+		in.letters[in.slot] = (in.letters[in.slot] + 1) % n
+	case "down":
+		in.letters[in.slot] = (in.letters[in.slot] + n - 1) % n
+	case "left", "backspace":
+		in.slot = max(in.slot-1, 0)
+	case "right", " ":
+		if in.slot == initialsCount-1 {
+			return key == " " // Fire on the last slot enters them
+		}
+		in.slot++
+	case "enter":
+		return true
+	default:
+		// A typed letter goes into the slot, as a keyboard is faster when there is one
+		if i := strings.Index(initialsAlphabet, strings.ToUpper(key)); len(key) == 1 && i >= 0 {
+			in.letters[in.slot] = i
+			in.slot = min(in.slot+1, initialsCount-1)
+		}
+	}
+	return false
+}
+
+// value returns the initials without trailing blanks.
+func (in initials) value() string {
+	var b strings.Builder
+	for _, l := range in.letters {
+		b.WriteByte(initialsAlphabet[l])
+	}
+	return strings.TrimSpace(b.String())
+}
+
+// view returns the initials with arrows over and under the slot being picked.
+func (in initials) view(ui style.UI) string {
+	var up, letters, down strings.Builder
+	for i, l := range in.letters {
+		letter := string(initialsAlphabet[l])
+		if initialsAlphabet[l] == ' ' {
+			letter = "_"
+		}
+		if i == in.slot {
+			up.WriteString(" ▲ ")
+			letters.WriteString(" " + ui.SetupItemSelected.Render(letter) + " ")
+			down.WriteString(" ▼ ")
+		} else {
+			up.WriteString("   ")
+			letters.WriteString(" " + letter + " ")
+			down.WriteString("   ")
+		}
+	}
+	return up.String() + "\n" + letters.String() + "\n" + down.String()
+}
+
+// updateInitials handles keys of the arcade initials entry.
+func (m Model) updateInitials(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if msg.Type == tea.KeyEsc {
+		m.status = statusIdle
+		return m, saveHighScoreCmd("")
+	}
+	if !m.arcade.update(msg.String()) {
+		m.nickErr = ""
+		return m, nil
+	}
+	name := nick.Sanitize(m.arcade.value())
+	if err := nick.Check(name); err != nil {
+		m.nickErr = err.Error()
+		return m, nil
+	}
+	m.status = statusIdle
+	return m, saveHighScoreCmd(name)
+}
@@ -2,12 +2,17 @@ package over
 
 import (
 	"fmt"
+	"os"
 	"strconv"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/card"
+	"github.com/vinser/haunteed/internal/nick"
 	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/score"
 	"github.com/vinser/haunteed/internal/state"
 	"github.com/vinser/haunteed/internal/style"
 )
@@ -24,12 +29,21 @@ type Model struct {
 	height     int
 	termWidth  int
 	termHeight int
+	ui         style.UI
 
 	status     status
 	state      *state.State
 	score      int
+	insured    int // banked points of an insured run, 0 if not insured
+	percent    int // share of the score a handicapped run keeps, 0 if not handicapped
 	highScores []state.HighScore
 	textInput  textinput.Model
+	nickErr    string // why the nickname filter rejected the entered nickname
+	card       string // share card of the run
+	ecto       int    // ectoplasm earned by the run
+	copied     bool
+
+	arcade *initials // arcade initials entry instead of the text input, nil if off
 }
 
 // PlayAgainMsg is a message sent when the user chooses to play again.
@@ -61,7 +75,7 @@ func saveHighScoreCmd(nick string) tea.Cmd {
 	}
 }
 
-func New(st *state.State, score int, highScores []state.HighScore, width, height int) Model {
+func New(st *state.State, score int, highScores []state.HighScore, ui style.UI, width, height int) Model {
 	if width < lipgloss.Width(footer) {
 		width = lipgloss.Width(footer)
 	}
@@ -69,13 +83,17 @@ func New(st *state.State, score int, highScores []state.HighScore, width, height
 	ti := textinput.New()
 	ti.Prompt = "Nickname: "
 	ti.Placeholder = "Enter Your Nickname"
-	ti.CharLimit = 26
-	ti.Width = 30
+	ti.CharLimit = nick.MaxWidth
+	ti.Width = nick.MaxWidth
 
 	leftAlign := lipgloss.NewStyle().Align(lipgloss.Left)
 	ti.PromptStyle = leftAlign
 	ti.TextStyle = leftAlign
 	ti.PlaceholderStyle = leftAlign
+	if st.Streamer {
+		ti.EchoMode = textinput.EchoPassword // The nickname shows up in the high scores once saved
+		ti.EchoCharacter = '•'
+	}
 
 	status := statusIdle
 	// Check if the current score is high enough to make the list
@@ -84,7 +102,13 @@ func New(st *state.State, score int, highScores []state.HighScore, width, height
 		ti.Focus()
 	}
 
+	var arcade *initials
+	if st.Initials {
+		arcade = &initials{}
+	}
+
 	return Model{
+		ui:         ui,
 		width:      width,
 		height:     height,
 		status:     status,
@@ -92,9 +116,23 @@ func New(st *state.State, score int, highScores []state.HighScore, width, height
 		score:      score,
 		highScores: highScores,
 		textInput:  ti,
+		arcade:     arcade,
+	}
+}
+
+// copyCardCmd copies the card into the terminal clipboard.
+func copyCardCmd(text string) tea.Cmd {
+	return func() tea.Msg {
+		card.Copy(os.Stdout, text)
+		return nil
 	}
 }
 
+// Typing returns true while the nickname takes the keys.
+func (m Model) Typing() bool {
+	return m.status == statusEntering
+}
+
 func (m *Model) SetSize(width, height int) {
 	m.termWidth = width
 	m.termHeight = height
@@ -107,22 +145,40 @@ func (m Model) Init() tea.Cmd {
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	var cmd tea.Cmd
 
+	if m.status == statusEntering && m.arcade != nil {
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			return m.updateInitials(msg)
+		}
+		return m, nil
+	}
 	if m.status == statusEntering {
 		switch msg := msg.(type) {
 		case tea.KeyMsg:
 			switch msg.Type {
 			case tea.KeyEnter:
-				// Save the score and switch to idle status
-				nick := m.textInput.Value()
+				// Save the score and switch to idle status unless the filter rejects the nickname
+				name := nick.Sanitize(m.textInput.Value())
+				if err := nick.Check(name); err != nil {
+					m.nickErr = err.Error()
+					return m, nil
+				}
 				m.status = statusIdle
-				return m, saveHighScoreCmd(nick)
+				return m, saveHighScoreCmd(name)
 			case tea.KeyEsc:
 				// Cancel entering, save with default name
 				m.status = statusIdle
 				return m, saveHighScoreCmd("")
 			}
 		}
+		prev := m.textInput.Value()
 		m.textInput, cmd = m.textInput.Update(msg)
+		// Wide glyphs take two columns, keep the input within the nickname width
+		if lipgloss.Width(m.textInput.Value()) > nick.MaxWidth {
+			m.textInput.SetValue(prev)
+		}
+		if m.textInput.Value() != prev {
+			m.nickErr = ""
+		}
 		return m, cmd
 	}
 
@@ -134,6 +190,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			return m, playAgainCmd()
 		case "q":
 			return m, quitGameCmd()
+		case "c":
+			if m.card != "" {
+				m.copied = true
+				return m, copyCardCmd(m.card)
+			}
 		}
 	}
 	return m, nil
@@ -143,25 +204,34 @@ var (
 	buttonStyle = lipgloss.NewStyle().Padding(0, 2).Margin(1)
 )
 
-const footer = "a — play again, q — quit"
+const footer = "a — play again, c — copy card, q — quit"
 
 func (m Model) View() string {
-	return render.Page("Game Over!", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+	return render.Page(m.ui, "Game Over!", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
 }
 
 func (m Model) renderContent() string {
 	if m.status == statusEntering {
 		var input []string
-		input = append(input, style.HighScore.Render(fmt.Sprintf("New %s High score: %d !!!", m.state.GameMode, m.score)))
+		input = append(input, m.ui.HighScore.Render(fmt.Sprintf("New %s High score: %d !!!", m.state.ModeName(), m.score)))
 
 		input = append(input, "") // Add a blank line
 		// blockStyle := lipgloss.NewStyle().Inline(false).Align(lipgloss.Left)
 		// textInputLine := blockStyle.Render(m.textInput.View())
 		// input = append(input, textInputLine)
-		input = append(input, m.textInput.View())
+		hint := "(press Enter to save, Esc to cancel)"
+		if m.arcade != nil {
+			input = append(input, m.arcade.view(m.ui))
+			hint = "(↑ ↓ letter, ← → slot, Enter to save, Esc to cancel)"
+		} else {
+			input = append(input, m.textInput.View())
+		}
 
 		input = append(input, "") // Add a blank line
-		input = append(input, "(press Enter to save, Esc to cancel)")
+		if m.nickErr != "" {
+			input = append(input, m.ui.SetupDescription.Render(m.nickErr))
+		}
+		input = append(input, hint)
 
 		return lipgloss.JoinVertical(lipgloss.Left, input...)
 	}
@@ -169,17 +239,34 @@ func (m Model) renderContent() string {
 	var content []string
 
 	if len(m.highScores) == 0 || m.score > m.highScores[len(m.highScores)-1].Score {
-		content = append(content, style.HighScore.Render(fmt.Sprintf("New %s High score: %d !!!", m.state.GameMode, m.score)))
+		content = append(content, m.ui.HighScore.Render(fmt.Sprintf("New %s High score: %d !!!", m.state.ModeName(), m.score)))
 	} else {
-		content = append(content, fmt.Sprintf("Your %s score: %d", m.state.GameMode, m.score))
+		content = append(content, fmt.Sprintf("Your %s score: %d", m.state.ModeName(), m.score))
+	}
+	if m.percent > 0 {
+		content = append(content, fmt.Sprintf("Handicap: %s", score.FormatMultiplier(m.percent)))
+	}
+	if m.insured > 0 {
+		content = append(content, fmt.Sprintf("Insured: %d", m.insured))
+	}
+	if m.ecto > 0 {
+		content = append(content, fmt.Sprintf("Ectoplasm: +%d", m.ecto))
 	}
 
 	content = append(content, "") // Add a blank line
 	content = append(content, "High Scores:")
 
-	listFormat := fmt.Sprintf("%%d. %%%dd — %%s", calcDidgits(m.highScores))
+	digits := calcDidgits(m.highScores)
 	for i, hs := range m.highScores {
-		content = append(content, fmt.Sprintf(listFormat, i+1, hs.Score, hs.Nick))
+		content = append(content, fmt.Sprintf("%d. %s — %s", i+1, render.PadLeft(strconv.Itoa(hs.Score), digits), render.Isolate(nick.Sanitize(hs.Nick))))
+	}
+
+	if m.card != "" {
+		content = append(content, "")
+		content = append(content, strings.TrimSuffix(m.card, "\n"))
+		if m.copied {
+			content = append(content, m.ui.SetupDescription.Render("Copied, paste it anywhere"))
+		}
 	}
 
 	return lipgloss.JoinVertical(lipgloss.Left, content...)
@@ -199,6 +286,26 @@ func (m Model) Score() int {
 	return m.score
 }
 
+// SetCard shows the share card of the run, ready to be copied.
+func (m *Model) SetCard(text string) {
+	m.card = text
+}
+
+// SetEctoplasm shows the ectoplasm the run earned for the vault.
+func (m *Model) SetEctoplasm(earned int) {
+	m.ecto = earned
+}
+
+// SetInsured shows the points banked by the insurance in the summary.
+func (m *Model) SetInsured(banked int) {
+	m.insured = banked
+}
+
+// SetHandicap shows the multiplier the handicaps scaled the score with.
+func (m *Model) SetHandicap(percent int) {
+	m.percent = percent
+}
+
 func (m *Model) SetHighScores(highScores []state.HighScore) {
 	m.highScores = highScores
 }
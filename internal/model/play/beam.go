@@ -0,0 +1,60 @@
+package play
+
+import (
+	"time"
+
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/sound"
+)
+
+const (
+	beamPeriod = 4 * time.Second // a flashlight charge lasts this long
+	beamLength = 5               // cells lit in front of the haunteed
+)
+
+// lightBeam spends a flashlight charge, the beam follows the haunteed's facing until it runs out.
+func (m *Model) lightBeam() {
+	if !m.haunteed.UsePickup(dweller.Flashlight) {
+		return
+	}
+	m.soundManager.Play(sound.FUSE_TOGGLE)
+	m.events.Add("flashlight on")
+	m.beamUntil = time.Now().Add(beamPeriod)
+	m.beamTurnsLeft = m.turns(beamPeriod)
+	m.aimBeam()
+}
+
+// beamOn returns true while a flashlight charge lasts.
+func (m *Model) beamOn() bool {
+	if m.turnBased {
+		return m.beamTurnsLeft > 0
+	}
+	return time.Now().Before(m.beamUntil)
+}
+
+// aimBeam points the beam where the haunteed faces, or puts it out once the charge is spent.
+// It runs after every step of the haunteed and before every ghost move.
+func (m *Model) aimBeam() {
+	if !m.beamOn() {
+		m.floor.ClearBeam()
+		return
+	}
+	dx, dy := m.facingStep()
+	pos := m.haunteed.Pos()
+	m.floor.SetBeam(pos.X, pos.Y, dx, dy, beamLength)
+}
+
+// facingStep returns the step the haunteed faces.
+func (m *Model) facingStep() (dx, dy int) {
+	switch m.haunteed.Facing() {
+	case dweller.Up:
+		dy = -1
+	case dweller.Down:
+		dy = 1
+	case dweller.Left:
+		dx = -1
+	case dweller.Right:
+		dx = 1
+	}
+	return dx, dy
+}
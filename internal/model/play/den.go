@@ -0,0 +1,55 @@
+package play
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/vinser/haunteed/internal/dweller"
+)
+
+// denPips returns the sprites over the den door: pips for the ghosts waiting to be released on the left
+// and the seconds to the next release on the right, so new players see why the ghosts trickle out.
+func (m *Model) denPips() map[dweller.Position][]string {
+	pips := make(map[dweller.Position][]string)
+	if len(m.floor.Dens) == 0 {
+		return pips
+	}
+	waiting, next := 0, time.Duration(0)
+	for _, g := range m.ghosts {
+		if g.Reinforcement() {
+			continue
+		}
+		if left, ok := g.ReleaseLeft(); ok {
+			waiting++
+			if next == 0 || left < next {
+				next = left
+			}
+		}
+	}
+	if waiting == 0 {
+		return pips
+	}
+	// The door is in the wall row right above the den, under the exit
+	d := m.floor.Dens[0]
+	pips[dweller.Position{X: d.Exit.X - 1, Y: d.Y - 1}] = m.pipSprite(waiting)
+	pips[dweller.Position{X: d.Exit.X + 1, Y: d.Y - 1}] = m.countdownSprite(next)
+	return pips
+}
+
+// pipSprite shows a pip for each waiting ghost, or their number with a pip when they don't fit the tile.
+func (m *Model) pipSprite(waiting int) []string {
+	w, h := m.getSpriteCharDims()
+	pips := strings.Repeat("•", waiting)
+	if waiting > w {
+		pips = fmt.Sprintf("%d•", waiting)
+		if w == 1 {
+			pips = fmt.Sprintf("%d", waiting)
+		}
+	}
+	sprite := []string{styleHeader.Render(fmt.Sprintf("%*s", w, pips))}
+	for len(sprite) < h {
+		sprite = append(sprite, strings.Repeat(" ", w))
+	}
+	return sprite
+}
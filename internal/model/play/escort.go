@@ -0,0 +1,50 @@
+package play
+
+import (
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/sound"
+)
+
+// rescueBonus is scored for a colleague escorted to the stairs
+const rescueBonus = 1000
+
+// rescue makes the colleague found at the position follow the haunteed.
+func (m *Model) rescue(pos dweller.Position) {
+	m.soundManager.Play(sound.FUSE_TOGGLE)
+	m.events.Add("found colleague")
+	m.follower = dweller.NewFollower(pos)
+}
+
+// escortTargets returns the follower position for the chasing ghosts to go for, if there is a follower.
+func (m *Model) escortTargets() []dweller.Position {
+	if m.follower == nil {
+		return nil
+	}
+	return []dweller.Position{m.follower.Pos()}
+}
+
+// escort runs after the ghosts moved: the follower takes a step if it walks, a ghost catching it ends the rescue.
+func (m *Model) escort(walk bool) {
+	if m.follower == nil {
+		return
+	}
+	if walk && !m.follower.Caught(m.ghosts) {
+		m.follower.Move()
+	}
+	if m.follower.Caught(m.ghosts) {
+		m.soundManager.Play(sound.LOSE_LIFE)
+		m.events.Add("colleague caught")
+		m.follower = nil
+	}
+}
+
+// deliver scores the colleague the haunteed takes upstairs.
+func (m *Model) deliver() {
+	if m.follower == nil {
+		return
+	}
+	m.soundManager.Play(sound.HIGH_SCORE)
+	m.events.Add("rescued colleague")
+	m.score.Add(rescueBonus)
+	m.follower = nil
+}
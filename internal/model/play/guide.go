@@ -0,0 +1,72 @@
+package play
+
+import (
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+)
+
+const (
+	guideCharges = 3               // guided path uses per floor
+	guideCost    = 100             // points a guided path costs
+	guideSteps   = 10              // steps of the way to the stairs shown
+	guidePeriod  = 5 * time.Second // time the guided path is shown
+)
+
+var styleGuide = lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("226")) // bright yellow
+
+// showGuide spends a charge and points to show the next steps of the way to the stairs for a while.
+func (m *Model) showGuide() {
+	m.guidesLeft--
+	m.score.Add(-guideCost)
+	m.guide = m.guide[:0]
+	pos := m.haunteed.Pos()
+	for _, p := range m.floor.Guide(pos.X, pos.Y, guideSteps) {
+		m.guide = append(m.guide, dweller.Position{X: p.X, Y: p.Y})
+	}
+	m.guideUntil = time.Now().Add(guidePeriod)
+	m.soundManager.Play(sound.UI_CLICK)
+	m.events.Add("guide -%d", guideCost)
+}
+
+// GuidesLeft returns the guided path uses left on the floor.
+func (m Model) GuidesLeft() int {
+	return m.guidesLeft
+}
+
+// SetGuidesLeft sets the guided path uses left on the floor, so a respawn doesn't refill them.
+func (m *Model) SetGuidesLeft(n int) {
+	m.guidesLeft = n
+}
+
+// guideOverlay returns the guided path sprites for maze cells while the guide is shown.
+func (m *Model) guideOverlay() map[dweller.Position][]string {
+	overlay := make(map[dweller.Position][]string)
+	if time.Now().After(m.guideUntil) {
+		return overlay
+	}
+	var sprite []string
+	for _, s := range guideSprite(m.state.SpriteSize) {
+		sprite = append(sprite, styleGuide.Render(s))
+	}
+	for _, p := range m.guide {
+		if p != m.haunteed.Pos() {
+			overlay[p] = sprite
+		}
+	}
+	return overlay
+}
+
+func guideSprite(size string) []string {
+	switch size {
+	case state.SpriteSmall:
+		return []string{"•"}
+	case state.SpriteLarge:
+		return []string{" •• ", " •• "}
+	default: // state.SpriteMedium
+		return []string{"••"}
+	}
+}
@@ -0,0 +1,46 @@
+package play
+
+import (
+	"time"
+
+	"github.com/vinser/haunteed/internal/score"
+)
+
+const (
+	speedBonusPercent = 125              // Ghost tick in percent of the floor one with the speed bonus handicap
+	longFrightPeriod  = 15 * time.Second // Frightened period with the long fright handicap
+)
+
+// SetHandicap slows the ghosts down and makes them stay frightened longer if the player took these handicaps.
+// Versus rounds and challenges keep their rules, so the caller leaves them off there.
+func (m *Model) SetHandicap(speedBonus, longFright bool) {
+	m.speedBonus = speedBonus
+	m.longFright = longFright
+	if !m.powerMode {
+		m.ghostTickInterval = m.ghostTick()
+	}
+}
+
+// ghostTick returns the ghost tick of the floor, longer with the speed bonus handicap.
+func (m *Model) ghostTick() time.Duration {
+	if m.speedBonus {
+		return m.floor.GhostTickInterval * speedBonusPercent / 100
+	}
+	return m.floor.GhostTickInterval
+}
+
+// frightenedPeriod returns how long ghosts stay frightened after a power pellet.
+func (m *Model) frightenedPeriod() time.Duration {
+	if m.longFright {
+		return longFrightPeriod
+	}
+	return frightenedPeriod
+}
+
+// handicapTag shows the multiplier of the final score of a handicapped run.
+func (m *Model) handicapTag() string {
+	if percent := m.score.Percent(); percent < 100 && m.versusGhost == nil && !m.haunteed.IsImmortal() {
+		return "  " + score.FormatMultiplier(percent)
+	}
+	return ""
+}
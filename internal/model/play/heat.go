@@ -0,0 +1,86 @@
+package play
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/vinser/haunteed/internal/dweller"
+	floor "github.com/vinser/haunteed/internal/floor"
+	"github.com/vinser/haunteed/internal/sound"
+)
+
+const (
+	maxHeat        = 100                    // the datacenter overheats at this temperature
+	heatRadius     = 4                      // ghosts closer than this warm the air up
+	heatPerGhost   = 4                      // warming per ghost move and ghost near
+	heatCooling    = 1                      // cooling per ghost move with no ghost near
+	ventRadius     = 2                      // fuse boxes closer than this vent the heat
+	ventCooling    = 5                      // cooling per ghost move next to a fuse box
+	overheatPeriod = 3 * time.Second        // time the haunteed suffers from the heat
+	overheatRadius = 2                      // visibility radius of the blurred, overheated haunteed
+	overheatStep   = 250 * time.Millisecond // shortest time between steps of the overheated haunteed
+)
+
+// updateHeat warms the air up while ghosts are near and cools it down near fuse boxes, it runs on every ghost move.
+// At maxHeat the haunteed overheats for a while: the sight blurs and the steps slow down.
+func (m *Model) updateHeat() {
+	pos := m.haunteed.Pos()
+	near := 0
+	for _, g := range m.ghosts {
+		if g.State() != dweller.Eaten && !g.Respawning() && distance(pos, g.Pos()) < heatRadius {
+			near++
+		}
+	}
+	switch {
+	case near > 0:
+		m.heat += near * heatPerGhost
+	case m.nearVent(pos):
+		m.heat -= ventCooling
+	default:
+		m.heat -= heatCooling
+	}
+	m.heat = max(min(m.heat, maxHeat), 0)
+	if m.heat == maxHeat && !m.overheated() {
+		m.overheatedUntil = time.Now().Add(overheatPeriod)
+		m.heat = maxHeat / 2
+		m.soundManager.Play(sound.FUSE_ARC)
+		m.events.Add("overheated")
+	}
+}
+
+// nearVent returns true if a fuse box is within ventRadius of the position.
+func (m *Model) nearVent(pos dweller.Position) bool {
+	for y := pos.Y - ventRadius; y <= pos.Y+ventRadius; y++ {
+		for x := pos.X - ventRadius; x <= pos.X+ventRadius; x++ {
+			if item, err := m.floor.ItemAt(x, y); err == nil && item == floor.Fuse {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// overheated returns true while the haunteed suffers from the heat.
+func (m *Model) overheated() bool {
+	return time.Now().Before(m.overheatedUntil)
+}
+
+// slowedDown returns true if the overheated haunteed can't take another step yet.
+func (m *Model) slowedDown() bool {
+	if !m.overheated() || time.Since(m.lastStep) >= overheatStep {
+		m.lastStep = time.Now()
+		return false
+	}
+	return true
+}
+
+// heatTag shows the temperature in the header once the air gets warm, and the overheating.
+func (m *Model) heatTag() string {
+	switch {
+	case m.overheated():
+		return "  OVERHEATED"
+	case m.heat > 0:
+		return fmt.Sprintf("  Temp: %d°", m.heat)
+	}
+	return ""
+}
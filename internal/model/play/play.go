@@ -10,8 +10,14 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/eventlog"
 	floor "github.com/vinser/haunteed/internal/floor"
+	"github.com/vinser/haunteed/internal/input"
+	"github.com/vinser/haunteed/internal/metrics"
 	"github.com/vinser/haunteed/internal/model/motd"
+	"github.com/vinser/haunteed/internal/mutator"
+	"github.com/vinser/haunteed/internal/nick"
+	"github.com/vinser/haunteed/internal/render"
 	"github.com/vinser/haunteed/internal/score"
 	"github.com/vinser/haunteed/internal/sound"
 	"github.com/vinser/haunteed/internal/state"
@@ -19,8 +25,9 @@ import (
 )
 
 const (
-	frightenedPeriod    = 10 * time.Second
-	autoRepeatThreshold = 100 * time.Millisecond // Anticheat
+	frightenedPeriod = 10 * time.Second
+	stickyStepPeriod = 80 * time.Millisecond
+	framePeriod      = 100 * time.Millisecond // Ghost tick period, the maze is redrawn on every tick
 )
 
 // Viewport represents the visible area of the maze
@@ -37,13 +44,15 @@ type TerminalDimensions struct {
 
 type Model struct {
 	state             *state.State
+	ui                style.UI
 	soundManager      *sound.Manager
 	floor             *floor.Floor
 	score             *score.Score
 	haunteed          *dweller.Haunteed
 	ghosts            []*dweller.Ghost
-	lastKeyMsg        tea.KeyMsg
-	lastKeyTime       time.Time
+	keyFilter         *input.Filter
+	stickyLeft        int // sticky moves left for the last key press
+	stickySeq         int // last key press number, stale sticky steps are dropped
 	lastGhostMove     time.Time
 	powerMode         bool
 	powerModeUntil    time.Time
@@ -53,10 +62,66 @@ type Model struct {
 	sb                *strings.Builder
 	fullVisibility    bool
 	paused            bool
-	gotCrumbs         bool
+	guidesLeft        int                // guided path uses left on the floor, see showGuide
+	guide             []dweller.Position // steps of the guided path shown until guideUntil
+	guideUntil        time.Time
 	terminal          TerminalDimensions // Terminal dimensions
 	viewport          Viewport           // Current viewport for scrolling
+	lookX, lookY      int                // Free-look shift of the paused viewport, see look
 	motd              motd.Model
+	debugAllowed      bool           // ghost view overlay is available in dev and practice runs
+	ghostView         bool           // ghost targets and paths overlay
+	turnBased         bool           // puzzle variant: ghosts move one step per haunteed step
+	turn              int            // turns made on the floor in turn-based play
+	powerTurnsLeft    int            // power mode turns left in turn-based play
+	versusGhost       *dweller.Ghost // ghost steered by player two in a versus round, nil otherwise
+	versusRound       time.Duration  // time the haunteed has to survive in a versus round
+	versusStart       time.Time
+	pausedAt          time.Time
+	rewind            *rewindBuffer              // latest entity positions to roll back to
+	checkpoint        *dweller.Position          // checkpoint touched on the floor, see ReachedCheckpoint
+	met               map[dweller.GhostType]bool // ghosts met on the floor, see sightings
+	eaten             []dweller.GhostType        // ghosts eaten since the last sightings
+	reinforced        bool                       // reinforcement ghosts are called, see callReinforcements
+	events            *eventlog.Log              // event log panel shown under the maze, see SetEvents
+	cooldowns         map[string]time.Time       // quick actions resting until, see quickUse
+	saver             bool                       // energy saver: the maze is redrawn on ghost moves only, see SetSaver
+	brownout          bool                       // the host is struggling and the lights flicker, see SetHostEvents
+	heat              int                        // temperature of the air around the haunteed, see updateHeat
+	overheatedUntil   time.Time
+	lastStep          time.Time          // last step of the haunteed, the overheated haunteed walks slower
+	beamUntil         time.Time          // the flashlight shines until, see aimBeam
+	beamTurnsLeft     int                // flashlight turns left in turn-based play
+	follower          *dweller.Follower  // rescued colleague walking behind the haunteed, see escort
+	spectral          bool               // spectral photography is allowed in the pause menu, see SetSpectral
+	crosshair         dweller.Position   // cell aimed at by spectral photography
+	developed         int                // residue photographed during the pause, scored on resume
+	sandbox           *Sandbox           // training floor setup, nil outside the sandbox, see SetSandbox
+	eatenPellets      []dweller.Position // power pellets to refill in the sandbox after power mode
+	speedBonus        bool               // handicap: ghosts step slower, see SetHandicap
+	longFright        bool               // handicap: ghosts stay frightened longer
+}
+
+// quickLetters are the letter keys of the quick actions
+var quickLetters = map[string]string{
+	"c": state.ActionGuide,      // Show the next steps to the stairs
+	"t": state.ActionTrap,       // Set a trap where the haunteed stands
+	"f": state.ActionFuse,       // Spend a fuse charge
+	"r": state.ActionRewind,     // Spend a rewind charge
+	"e": state.ActionCamera,     // Photograph a ghost next to the haunteed
+	"x": state.ActionFlashlight, // Shine the flashlight ahead
+	"l": state.ActionLog,        // Toggle the event log panel
+}
+
+// sightingRange is how close a ghost comes to be met
+const sightingRange = 3
+
+// versusKeys steer player two's ghost
+var versusKeys = map[string]dweller.Direction{
+	"w": dweller.Up, "W": dweller.Up,
+	"s": dweller.Down, "S": dweller.Down,
+	"a": dweller.Left, "A": dweller.Left,
+	"d": dweller.Right, "D": dweller.Right,
 }
 
 // GhostTickMsg is a tick message.
@@ -65,12 +130,24 @@ type Model struct {
 // The tick interval is defined by the ghostTickInterval in the play model.
 type GhostTickMsg time.Time
 
-func tickGhosts() tea.Cmd {
-	return tea.Tick(100*time.Millisecond, func(t time.Time) tea.Msg {
+func tickGhosts(period time.Duration) tea.Cmd {
+	return tea.Tick(period, func(t time.Time) tea.Msg {
 		return GhostTickMsg(t)
 	})
 }
 
+// StickyStepMsg is a message to repeat the move of a single key press in sticky-direction mode.
+// Seq is the number of the key press, so steps of an earlier press are dropped.
+type StickyStepMsg struct {
+	Seq int
+}
+
+func stickyStepCmd(seq int) tea.Cmd {
+	return tea.Tick(stickyStepPeriod, func(time.Time) tea.Msg {
+		return StickyStepMsg{Seq: seq}
+	})
+}
+
 // NextFloorMsg is a message to transition to the next floor.
 // It contains the index of the next floor.
 // This is used to handle the transition logic in the main application.
@@ -118,11 +195,62 @@ func gameOverCmd(score int) tea.Cmd {
 	}
 }
 
+// VersusOverMsg is a message sent when a versus round ends.
+// Captured is true if the ghosts won, Survived is how long the haunteed held out.
+type VersusOverMsg struct {
+	Ghost    dweller.GhostType
+	Captured bool
+	Survived time.Duration
+}
+
+func versusOverCmd(ghost dweller.GhostType, captured bool, survived time.Duration) tea.Cmd {
+	return func() tea.Msg {
+		return VersusOverMsg{
+			Ghost:    ghost,
+			Captured: captured,
+			Survived: survived,
+		}
+	}
+}
+
 // RespawnMsg is a message sent when the haunteed respawns after losing a life.
 type RespawnMsg struct {
 	Lives int
 }
 
+// ReportIssueMsg is a message sent when the player wants to report an issue from the pause menu.
+type ReportIssueMsg struct{}
+
+func reportIssueCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ReportIssueMsg{}
+	}
+}
+
+// EvidenceMsg is a message sent when the haunteed photographs a ghost.
+type EvidenceMsg struct {
+	Ghost    dweller.GhostType
+	Evidence state.Evidence
+}
+
+func evidenceCmd(ghost dweller.GhostType, e state.Evidence) tea.Cmd {
+	return func() tea.Msg {
+		return EvidenceMsg{Ghost: ghost, Evidence: e}
+	}
+}
+
+// SightingMsg is a message sent when the haunteed meets a ghost on the floor for the first time or eats it.
+type SightingMsg struct {
+	Ghost dweller.GhostType
+	Eaten bool
+}
+
+func sightingCmd(ghost dweller.GhostType, eaten bool) tea.Cmd {
+	return func() tea.Msg {
+		return SightingMsg{Ghost: ghost, Eaten: eaten}
+	}
+}
+
 func respawnCmd(lives int) tea.Cmd {
 	return func() tea.Msg {
 		return RespawnMsg{
@@ -131,6 +259,40 @@ func respawnCmd(lives int) tea.Cmd {
 	}
 }
 
+// ClimbMsg is a message sent when the haunteed takes a ladder to an adjacent floor.
+// X and Y are the coordinates of the ladder which are the same on both floors.
+type ClimbMsg struct {
+	Floor int
+	X, Y  int
+}
+
+func climbCmd(floor, x, y int) tea.Cmd {
+	return func() tea.Msg {
+		return ClimbMsg{
+			Floor: floor,
+			X:     x,
+			Y:     y,
+		}
+	}
+}
+
+// FallMsg is a message sent when the haunteed falls through a hole to the floor below.
+// X and Y are the coordinates of the hole which are mapped onto the floor below.
+type FallMsg struct {
+	Floor int
+	X, Y  int
+}
+
+func fallCmd(floor, x, y int) tea.Cmd {
+	return func() tea.Msg {
+		return FallMsg{
+			Floor: floor,
+			X:     x,
+			Y:     y,
+		}
+	}
+}
+
 // VisibilityToggledMsg is a message sent when the visibility of a floor is toggled when the haunteed steps on a fuse.
 type VisibilityToggledMsg struct {
 	FloorIndex int
@@ -162,9 +324,9 @@ func windowSizeCmd(width, height int) tea.Cmd {
 }
 
 // New returns a new play model.
-func New(s *state.State, sm *sound.Manager, f *floor.Floor, sc *score.Score, h *dweller.Haunteed, floorVisibility bool) Model {
+func New(s *state.State, ui style.UI, sm *sound.Manager, f *floor.Floor, sc *score.Score, h *dweller.Haunteed, floorVisibility bool) Model {
 	rng := rand.New(rand.NewSource(s.FloorSeeds[f.Index]))
-	ghosts := dweller.PlaceGhosts(f.Index, s.SpriteSize, s.GameMode, f.Maze.Width(), f.Maze.Height(), f.Maze.DenWidth(), f.Maze.DenHeight(), rng)
+	ghosts := dweller.PlaceGhosts(f.Index, s.SpriteSize, s.GameMode, f.Maze.Width(), f.Maze.Height(), f.Dens, rng)
 	ghostTick := f.GhostTickInterval
 
 	// Calculate minimal viewport size based on noisy mode maze size plus header/footer
@@ -176,6 +338,7 @@ func New(s *state.State, sm *sound.Manager, f *floor.Floor, sc *score.Score, h *
 
 	m := Model{
 		state:             s,
+		ui:                ui,
 		soundManager:      sm,
 		floor:             f,
 		score:             sc,
@@ -184,7 +347,12 @@ func New(s *state.State, sm *sound.Manager, f *floor.Floor, sc *score.Score, h *
 		lastGhostMove:     time.Now(),
 		powerMode:         false,
 		powerModeUntil:    time.Now(),
+		keyFilter:         input.NewFilter(s.RepeatThreshold(), s.InputDebounce()),
 		ghostController:   dweller.NewGhostController(),
+		rewind:            &rewindBuffer{},
+		met:               make(map[dweller.GhostType]bool),
+		guidesLeft:        guideCharges,
+		cooldowns:         make(map[string]time.Time),
 		ghostTickInterval: ghostTick,
 		justArrived:       true,
 		sb:                &strings.Builder{},
@@ -194,10 +362,35 @@ func New(s *state.State, sm *sound.Manager, f *floor.Floor, sc *score.Score, h *
 		motd:              motd.New(f.Maze.Width()*2, 1, 1*time.Minute),
 	}
 
+	if f.Mutators.GhostsHearSteps() {
+		m.ghostController.SkipScatter()
+	}
+	if f.Mutators.GhostRush() {
+		for _, g := range ghosts {
+			if !g.Reinforcement() {
+				g.SetRelease(0)
+			}
+		}
+	}
+
+	if s.TurnBased {
+		m.turnBased = true
+		m.ghostController.SetTurnPeriod(ghostTick)
+		for _, g := range ghosts {
+			g.Hold() // Released by turns, see ghostTurn
+		}
+	}
+
+	m.updateTempo()
 	if m.shouldPlayFuseSound() {
 		m.soundManager.PlayLoopWithVolume(sound.FUSE_ARC, 2)
 	}
 
+	// Back on a floor with half of the dots eaten, the reinforcements are out already
+	if m.halfEaten() {
+		m.releaseReinforcements()
+	}
+
 	return m
 }
 
@@ -208,7 +401,36 @@ func (m Model) shouldPlayFuseSound() bool {
 
 func (m Model) Init() tea.Cmd {
 	// Start a continuous ghost ticker that never stops.
-	return tea.Batch(tickGhosts())
+	return tea.Batch(tickGhosts(framePeriod))
+}
+
+// togglePause pauses or resumes the game.
+func (m *Model) togglePause() tea.Cmd {
+	m.paused = !m.paused
+	if m.paused {
+		m.pausedAt = time.Now()
+		m.crosshair = m.haunteed.Pos()
+		m.soundManager.PlayLoopWithVolume(sound.PAUSE_GAME, 0)
+		return m.motd.Init()
+	}
+	m.versusStart = m.versusStart.Add(time.Since(m.pausedAt)) // Pauses don't count as survival
+	m.lookX, m.lookY = 0, 0
+	m.developPhotos()
+	m.soundManager.StopListed(sound.PAUSE_GAME)
+	return tickGhosts(m.tickPeriod()) // Game is resumed, start ticking again
+}
+
+// Pause pauses or resumes the game like the p key, nothing happens if it is paused or running already.
+func (m *Model) Pause(paused bool) tea.Cmd {
+	if m.paused == paused {
+		return nil
+	}
+	return m.togglePause()
+}
+
+// Paused returns true if the game is paused.
+func (m Model) Paused() bool {
+	return m.paused
 }
 
 func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
@@ -223,22 +445,47 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	case tea.KeyMsg:
 		switch msg.String() {
 		case "p", "P": // Toggle pause
-			m.paused = !m.paused
+			return m, m.togglePause()
+		case "g", "G": // Toggle ghost view overlay
+			if m.debugAllowed || m.haunteed.IsImmortal() {
+				m.ghostView = !m.ghostView
+				return m, nil
+			}
+		case "i", "I": // Bank a share of the score from the pause menu
+			if m.canInsure() && m.score.Insure() {
+				m.soundManager.Play(sound.UI_SAVE)
+				return m, nil
+			}
+		case "?": // Report an issue from the pause menu
 			if m.paused {
-				m.soundManager.PlayLoopWithVolume(sound.PAUSE_GAME, 0)
-				return m, m.motd.Init()
-			} else {
-				m.soundManager.StopListed(sound.PAUSE_GAME)
-				return m, tickGhosts() // Game is resumed, start ticking again
+				m.soundManager.Play(sound.UI_CLICK)
+				return m, reportIssueCmd()
 			}
-		case "c", "C": // Buy crumbs for one life
-			if !m.paused && !m.gotCrumbs && m.state.GameMode == state.ModeCrazy && m.haunteed.Lives() > 1 {
-				m.floor.ShowCrumbs(m.floor.Index, m.state.SpriteSize)
-				m.haunteed.LoseLife()
-				m.gotCrumbs = true
-				return m, tickGhosts()
+		case "enter", " ": // Photograph the cell under the crosshair in the pause menu
+			if m.paused && m.spectral {
+				m.photographCell()
+				return m, nil
+			}
+		}
+		if d, ok := crosshairMoves[msg.String()]; ok && m.paused && m.spectral {
+			m.aim(d)
+			return m, nil
+		}
+		// Quick actions have letter keys of their own besides the number keys of the bar
+		if action, ok := quickLetters[strings.ToLower(msg.String())]; ok {
+			if cmd, used := m.quickUse(action); used {
+				return m, cmd
 			}
 		}
+		if action, ok := m.quickKey(msg.String()); ok {
+			cmd, _ := m.quickUse(action)
+			return m, cmd // An idle number key is no step
+		}
+	case tea.MouseMsg:
+		if m.paused {
+			m.look(msg)
+		}
+		return m, nil
 	case WindowSizeMsg:
 		// Handle terminal resize
 		m.terminal.Width = msg.Width
@@ -257,155 +504,633 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		// Player two's keys bypass the filter, so the players don't debounce each other
+		if dir, ok := versusKeys[msg.String()]; ok && m.versusGhost != nil {
+			m.versusGhost.Steer(dir)
+			return m, nil
+		}
 		// sound.ClearSpeaker()
-		// Distinguish between a real key press and an auto-repeat.
-		// An auto-repeat event is just the same key coming in very fast.
-		isAutoRepeat := msg.Type == m.lastKeyMsg.Type &&
-			msg.String() == m.lastKeyMsg.String() &&
-			time.Since(m.lastKeyTime) < autoRepeatThreshold
-
-		// Update last key press info for the next event.
-		m.lastKeyMsg = msg
-		m.lastKeyTime = time.Now()
-
-		if isAutoRepeat {
-			return m, nil // Ignore auto-repeat events
+		if !m.keyFilter.Accept(msg.String(), time.Now()) {
+			return m, nil // Ignore auto-repeat and bouncing key events
 		}
 
-		m.haunteed.HandleInput(msg.String())
-
-		nextPos := m.haunteed.NextPos()
-		if m.haunteed.Dir() != dweller.No {
-			tile, err := m.floor.ItemAt(nextPos.X, nextPos.Y)
-			canMove := false
-			if err == nil {
-				if tile == floor.CrumblingWall {
-					if m.powerMode {
-						m.floor.BreakWall(nextPos.X, nextPos.Y)
-						m.soundManager.Play(sound.WALL_BREAK)
-						canMove = true
-					}
-				} else if tile != floor.Wall {
-					canMove = true
-				}
-			}
-			if canMove {
-				m.haunteed.SetPos(nextPos)
-				m.soundManager.Play(sound.STEP_CREAKY)
-				// Update viewport to follow player if using scrolling
-				m.centerViewportOnPlayer()
-			} else {
-				m.soundManager.Play(sound.STEP_BUMP)
-			}
+		if m.turnBased && msg.String() == " " {
+			return m.ghostTurn() // Stand still and let the ghosts move
 		}
 
-		pos := m.haunteed.Pos()
-		tile := m.floor.EatItem(pos.X, pos.Y)
-
-		switch tile {
-		case floor.Dot:
-			points := 0
-			switch m.state.GameMode {
-			case state.ModeEasy:
-				points = 5
-			case state.ModeNoisy:
-				points = 10
-			case state.ModeCrazy:
-				points = 15
-				if !m.fullVisibility {
-					points *= 2
-				}
-				if m.gotCrumbs {
-					points = 5
-				}
-			}
-			m.score.Add(points)
-			m.soundManager.PlayWithVolume(sound.PICK_CRUMB, -1.5)
-		case floor.PowerPellet:
-			m.soundManager.Play(sound.EAT_PELLET)
-			m.score.Add(50)
-			m.powerMode = true
-			m.powerModeUntil = time.Now().Add(frightenedPeriod)
-			m.ghostTickInterval = m.floor.GhostTickInterval * 2 // slow down ghosts
-			for _, g := range m.ghosts {
-				g.SetState(dweller.Frightened)
-			}
-		case floor.Fuse:
-			m.fullVisibility = !m.fullVisibility
-			m.soundManager.Play(sound.FUSE_TOGGLE)
-			if m.shouldPlayFuseSound() {
-				m.soundManager.PlayLoop(sound.FUSE_ARC)
-			} else {
-				m.soundManager.StopListed(sound.FUSE_ARC)
-			}
-			return m, toggleVisibilityCmd(m.floor.Index, m.fullVisibility)
-		case floor.Start:
-			if !m.justArrived {
-				return m, prevFloorCmd(m.floor.Index - 1)
-			}
-		case floor.End:
-			if !m.justArrived {
-				return m, nextFloorCmd(m.floor.Index + 1)
-			}
+		if m.slowedDown() {
+			return m, nil
 		}
-
-		if m.justArrived {
-			m.justArrived = false
+		m.haunteed.HandleInput(msg.String())
+		m.stickySeq++
+		m.stickyLeft = m.state.StickySteps - 1
+		return m.stickyStep()
+	case StickyStepMsg:
+		if msg.Seq != m.stickySeq || m.stickyLeft <= 0 {
+			return m, nil // superseded by a newer key press
 		}
+		m.stickyLeft--
+		return m.stickyStep()
 	case GhostTickMsg:
 		// Always re-arm the ticker so it keeps firing
-		cmd := tickGhosts()
+		cmd := tickGhosts(m.tickPeriod())
 
-		// Skip ghost logic if game is paused
-		if m.paused {
+		// Skip ghost logic if game is paused, ghosts follow the haunteed's turns in the puzzle variant
+		if m.paused || m.turnBased {
 			return m, cmd
 		}
 
 		// update power mode
 		if m.powerMode && time.Now().After(m.powerModeUntil) {
-			m.powerMode = false
-			m.ghostTickInterval = m.floor.GhostTickInterval // reset ghost speed
-			m.score.ResetGhostStreak()
-			for _, g := range m.ghosts {
-				if g.State() == dweller.Frightened {
-					g.SetState(dweller.Chase)
-				}
-			}
+			m.endPowerMode()
 		}
 
+		m.rewind.record(m.haunteed, m.ghosts)
+
 		if time.Since(m.lastGhostMove) >= m.ghostTickInterval {
+			m.aimBeam()
 			m.ghostController.Update(m.ghosts)
-			dweller.MoveGhosts(m.ghosts, m.floor, m.powerMode, m.haunteed.Pos(), m.haunteed.Dir())
+			m.drill()
+			if brokenWalls := dweller.MoveGhosts(m.ghosts, m.floor, m.powerMode, m.haunteed.Pos(), m.haunteed.Dir(), m.escortTargets()...); len(brokenWalls) > 0 {
+				m.soundManager.PlayWithVolume(sound.WALL_BREAK, 0)
+			}
+			m.escort(true)
+			m.springTraps()
+			m.updateHeat()
 			m.lastGhostMove = time.Now()
 		}
 
-		// check haunteed collisions with ghosts
-		htPos := m.haunteed.Pos()
-		for _, g := range m.ghosts {
-			if htPos == g.Pos() {
-				switch g.State() {
-				case dweller.Frightened: // eat the ghost
-					m.soundManager.Play(sound.KILL_GHOST)
-					m.score.AddGhostPoints()
-					g.SetState(dweller.Eaten)
-				case dweller.Chase: // lose a life
-					m.haunteed.LoseLife()
-					if m.haunteed.IsDead() { // game over
-						score := m.score.Get()
-						return m, gameOverCmd(score)
-					}
-					// enter respawn mode
+		if collisionCmd := m.collide(); collisionCmd != nil {
+			return m, tea.Batch(collisionCmd, m.sightings())
+		}
+		if m.versusGhost != nil && time.Since(m.versusStart) >= m.versusRound {
+			return m, versusOverCmd(m.versusGhost.Type(), false, m.versusRound)
+		}
+		return m, tea.Batch(cmd, m.sightings())
+	}
+	return m, nil
+}
+
+// collide checks haunteed collisions with ghosts.
+// It returns a command if the haunteed lost a life.
+func (m *Model) collide() tea.Cmd {
+	htPos := m.haunteed.Pos()
+	for _, g := range m.ghosts {
+		if g.Watch(htPos) {
+			m.events.Add("%s %s", g.Type(), m.bearing(g.Pos()))
+		}
+	}
+	for _, g := range m.ghosts {
+		if htPos == g.Pos() {
+			switch g.State() {
+			case dweller.Frightened: // eat the ghost
+				m.soundManager.Play(sound.KILL_GHOST)
+				points := m.score.AddGhostPoints()
+				m.events.Add("%s eaten +%d", g.Type(), points)
+				g.SetState(dweller.Eaten)
+				m.eaten = append(m.eaten, g.Type())
+			case dweller.Chase: // lose a life
+				if m.versusGhost != nil {
 					m.soundManager.PlayWithVolume(sound.LOSE_LIFE, 2)
-					return m, respawnCmd(m.haunteed.Lives())
+					return versusOverCmd(m.versusGhost.Type(), true, time.Since(m.versusStart))
+				}
+				if m.rewindWorld() {
+					return nil // Escaped
+				}
+				m.haunteed.LoseLife()
+				m.score.Die()
+				m.events.Add("caught by %s", g.Type())
+				if m.haunteed.IsDead() { // game over
+					score := m.score.Final()
+					return gameOverCmd(score)
 				}
+				// enter respawn mode
+				m.soundManager.PlayWithVolume(sound.LOSE_LIFE, 2)
+				return respawnCmd(m.haunteed.Lives())
 			}
 		}
+	}
+	return nil
+}
+
+// bearing tells where pos is from the haunteed, going by the way the haunteed faces.
+func (m *Model) bearing(pos dweller.Position) string {
+	dx, dy := m.facingStep()
+	htPos := m.haunteed.Pos()
+	switch ahead := (pos.X-htPos.X)*dx + (pos.Y-htPos.Y)*dy; {
+	case ahead > 0:
+		return "is ahead"
+	case ahead < 0:
+		return "is behind you"
+	}
+	return "is beside you"
+}
+
+// ghostInReach returns a ghost next to the haunteed to photograph, nil if there is none.
+func (m *Model) ghostInReach() *dweller.Ghost {
+	htPos := m.haunteed.Pos()
+	for _, g := range m.ghosts {
+		if g.State() != dweller.Eaten && g.State() != dweller.Exiting && manhattan(g.Pos(), htPos) <= 1 {
+			return g
+		}
+	}
+	return nil
+}
+
+// photograph spends a camera on a ghost next to the haunteed, it returns nil if there is none in reach.
+func (m *Model) photograph() tea.Cmd {
+	g := m.ghostInReach()
+	if g == nil || !m.haunteed.UsePickup(dweller.Camera) {
+		return nil
+	}
+	m.soundManager.Play(sound.FUSE_TOGGLE)
+	m.events.Add("photographed %s", g.Type())
+	return evidenceCmd(g.Type(), state.Evidence{
+		Ghost:    g.Type().String(),
+		Floor:    m.floor.Index,
+		TakenAt:  time.Now(),
+		Snapshot: m.snapshot(g),
+	})
+}
+
+// snapshot sketches the surroundings of the ghost with the ghost's initial and the haunteed as @.
+func (m *Model) snapshot(g *dweller.Ghost) []string {
+	const radius = 2
+	pos := g.Pos()
+	rows := m.floor.Sketch(pos.X, pos.Y, radius)
+	mark := func(p dweller.Position, r rune) {
+		x, y := p.X-pos.X+radius, p.Y-pos.Y+radius
+		if y < 0 || y >= len(rows) {
+			return
+		}
+		row := []rune(rows[y])
+		if x < 0 || x >= len(row) {
+			return
+		}
+		row[x] = r
+		rows[y] = string(row)
+	}
+	mark(m.haunteed.Pos(), '@')
+	mark(pos, []rune(g.Type().String())[0])
+	return rows
+}
+
+// springTraps sends ghosts that stepped on a trap home, trapped ghosts bring no points.
+func (m *Model) springTraps() {
+	for _, g := range m.ghosts {
+		if g.State() == dweller.Eaten || g.State() == dweller.Exiting {
+			continue
+		}
+		if pos := g.Pos(); m.floor.SpringTrap(pos.X, pos.Y) {
+			m.soundManager.Play(sound.KILL_GHOST)
+			m.events.Add("%s trapped", g.Type())
+			g.SetState(dweller.Eaten)
+		}
+	}
+}
+
+// sightings reports ghosts met for the first time on the floor and ghosts eaten since the last call.
+func (m *Model) sightings() tea.Cmd {
+	var cmds []tea.Cmd
+	for _, g := range m.ghosts {
+		if m.met[g.Type()] || g.State() == dweller.Exiting || distance(g.Pos(), m.haunteed.Pos()) > sightingRange {
+			continue
+		}
+		m.met[g.Type()] = true
+		cmds = append(cmds, sightingCmd(g.Type(), false))
+	}
+	for _, ghost := range m.eaten {
+		cmds = append(cmds, sightingCmd(ghost, true))
+	}
+	m.eaten = nil
+	return tea.Batch(cmds...)
+}
+
+// rewindWorld spends a rewind charge to roll the haunteed and ghosts back to where they were a few seconds ago.
+// It returns false if there is no charge or nothing recorded yet, the charge is kept then.
+func (m *Model) rewindWorld() bool {
+	if m.versusGhost != nil || !m.haunteed.UsePickup(dweller.Rewind) {
+		return false
+	}
+	if !m.rewind.rewind(m.haunteed, m.ghosts) {
+		m.haunteed.AddPickup(dweller.Rewind)
+		return false
+	}
+	m.soundManager.Play(sound.TRANSITION_DOWN)
+	m.events.Add("rewound")
+	m.centerViewportOnPlayer()
+	return true
+}
+
+// endPowerMode turns frightened ghosts back to chasing.
+func (m *Model) endPowerMode() {
+	m.powerMode = false
+	m.ghostTickInterval = m.ghostTick() // reset ghost speed
+	m.score.ResetGhostStreak()
+	for _, g := range m.ghosts {
+		if g.State() == dweller.Frightened {
+			g.SetState(dweller.Chase)
+		}
+	}
+	m.refillPellets()
+}
+
+// turns returns the number of turn-based turns matching the period of real-time play.
+func (m *Model) turns(period time.Duration) int {
+	return max(int(period/m.floor.GhostTickInterval), 1)
+}
 
+// ghostTurn moves the ghosts one step after a haunteed step in turn-based play.
+// Every turn costs a point, so the shortest solution of a floor scores best.
+func (m Model) ghostTurn() (Model, tea.Cmd) {
+	if cmd := m.collide(); cmd != nil {
+		return m, cmd // Walked into a ghost
+	}
+	m.rewind.record(m.haunteed, m.ghosts)
+	m.turn++
+	if m.score.Get() > 0 {
+		m.score.Add(-1)
+	}
+	for i, g := range m.ghosts {
+		if !g.Reinforcement() && !g.Respawning() && m.turn >= i*m.turns(dweller.ReleaseDelay(1)) {
+			g.SetRelease(0)
+		}
+	}
+	if m.powerMode {
+		m.powerTurnsLeft--
+		if m.powerTurnsLeft <= 0 {
+			m.endPowerMode()
+		}
+	}
+	m.beamTurnsLeft = max(m.beamTurnsLeft-1, 0)
+	m.aimBeam()
+	// Frightened ghosts are slowed down to every other turn
+	if !m.powerMode || m.turn%2 == 0 {
+		m.ghostController.Update(m.ghosts)
+		m.drill()
+		if brokenWalls := dweller.MoveGhosts(m.ghosts, m.floor, m.powerMode, m.haunteed.Pos(), m.haunteed.Dir(), m.escortTargets()...); len(brokenWalls) > 0 {
+			m.soundManager.PlayWithVolume(sound.WALL_BREAK, 0)
+		}
+		m.escort(m.turn%2 == 0) // The follower is slower than the haunteed, it walks every other turn
+		m.springTraps()
+		m.updateHeat()
+	}
+	return m, tea.Batch(m.collide(), m.sightings())
+}
+
+// stickyStep makes a step and schedules the next one while sticky moves are left.
+// Sticky moves stop as soon as the haunteed bumps into something or leaves the floor.
+// In turn-based play each step is followed by a ghost turn.
+func (m Model) stickyStep() (Model, tea.Cmd) {
+	from := m.haunteed.Pos()
+	m, cmd := m.step()
+	if cmd == nil && m.turnBased && m.haunteed.Pos() != from {
+		m, cmd = m.ghostTurn()
+	}
+	if cmd != nil || m.haunteed.Pos() == from {
+		m.stickyLeft = 0
 		return m, cmd
 	}
+	if m.stickyLeft > 0 {
+		return m, stickyStepCmd(m.stickySeq)
+	}
 	return m, nil
 }
 
+// step moves the haunteed one cell in its current direction and handles the tile it ends up on.
+func (m Model) step() (Model, tea.Cmd) {
+	nextPos := m.haunteed.NextPos()
+	moved := false
+	if m.haunteed.Dir() != dweller.No {
+		tile, err := m.floor.ItemAt(nextPos.X, nextPos.Y)
+		canMove := false
+		cracked := false
+		if err == nil {
+			if tile == floor.CrumblingWall {
+				if m.powerMode {
+					m.floor.BreakWall(nextPos.X, nextPos.Y)
+					m.soundManager.PlayWithVolume(sound.WALL_BREAK, 0)
+					canMove = true
+				} else {
+					// Without power mode the wall gives way after several bumps
+					damage, broken := m.floor.BumpWall(nextPos.X, nextPos.Y)
+					cracked = !broken
+					if broken {
+						m.soundManager.PlayWithVolume(sound.WALL_BREAK, 0)
+					} else {
+						m.soundManager.PlayWithVolume(sound.WALL_BREAK, float64(damage-floor.CrumblingWallStrength))
+					}
+				}
+			} else if tile != floor.Wall {
+				canMove = true
+			}
+		}
+		if canMove {
+			moved = true
+			m.moveHaunteed(nextPos)
+			m.slide()
+			m.soundManager.PlayWithVolume(sound.STEP_CREAKY, m.floor.Mutators.StepVolume(0))
+			// Update viewport to follow player if using scrolling
+			m.centerViewportOnPlayer()
+		} else if !cracked {
+			m.soundManager.Play(sound.STEP_BUMP)
+		}
+	}
+
+	m.aimBeam()
+	pos := m.haunteed.Pos()
+	if moved && m.versusGhost == nil && m.sandbox == nil && m.floor.Tread(pos.X, pos.Y) == floor.Hole {
+		return m.fall(pos)
+	}
+	tile := m.floor.EatItem(pos.X, pos.Y)
+
+	switch tile {
+	case floor.Dot:
+		m.eatDot()
+	case floor.PowerPellet:
+		m.soundManager.Play(sound.EAT_PELLET)
+		m.events.Add("ate pellet")
+		m.score.Add(50)
+		m.powerMode = true
+		m.powerModeUntil = time.Now().Add(m.frightenedPeriod())
+		m.powerTurnsLeft = m.turns(m.frightenedPeriod())
+		m.pelletEaten(pos)
+		m.ghostTickInterval = m.ghostTick() * 2 // slow down ghosts
+		for _, g := range m.ghosts {
+			g.SetState(dweller.Frightened)
+		}
+	case floor.Checkpoint:
+		if m.checkpoint == nil || *m.checkpoint != pos {
+			m.soundManager.Play(sound.FUSE_TOGGLE)
+			m.events.Add("checkpoint")
+			m.checkpoint = &pos
+		}
+	case floor.RewindCharge:
+		m.soundManager.Play(sound.EAT_PELLET)
+		m.events.Add("got rewind charge")
+		m.haunteed.AddPickup(dweller.Rewind)
+	case floor.Camera:
+		m.soundManager.Play(sound.EAT_PELLET)
+		m.events.Add("got camera")
+		m.haunteed.AddPickup(dweller.Camera)
+	case floor.Flashlight:
+		m.soundManager.Play(sound.EAT_PELLET)
+		m.events.Add("got flashlight")
+		m.haunteed.AddPickup(dweller.Flashlight)
+	case floor.Colleague:
+		m.rescue(pos)
+	case floor.Fuse:
+		return m, m.toggleFuse()
+	case floor.Lever:
+		if moved {
+			m.soundManager.Play(sound.FUSE_TOGGLE)
+			m.events.Add("pulled lever")
+			m.floor.PullLever()
+		}
+	case floor.Start:
+		if m.canLeave() {
+			return m, prevFloorCmd(m.floor.Index - 1)
+		}
+	case floor.End:
+		if m.canLeave() {
+			m.deliver()
+			return m, nextFloorCmd(m.floor.Index + 1)
+		}
+	case floor.LadderUp:
+		if m.canLeave() {
+			return m, climbCmd(m.floor.Index+1, pos.X, pos.Y)
+		}
+	case floor.LadderDown:
+		if m.canLeave() {
+			return m, climbCmd(m.floor.Index-1, pos.X, pos.Y)
+		}
+	}
+
+	if m.justArrived {
+		m.justArrived = false
+	}
+	return m, nil
+}
+
+// toggleFuse switches the lights of the floor on or off.
+func (m *Model) toggleFuse() tea.Cmd {
+	m.fullVisibility = !m.fullVisibility
+	m.soundManager.Play(sound.FUSE_TOGGLE)
+	if m.shouldPlayFuseSound() {
+		m.soundManager.PlayLoop(sound.FUSE_ARC)
+	} else {
+		m.soundManager.StopListed(sound.FUSE_ARC)
+	}
+	return toggleVisibilityCmd(m.floor.Index, m.fullVisibility)
+}
+
+// canInsure returns true if the score can be insured now: in the pause menu of a regular run, once per run.
+func (m Model) canInsure() bool {
+	return m.paused && m.score.CanInsure() && m.versusGhost == nil && !m.haunteed.IsImmortal()
+}
+
+// canLeave returns true if stairs and ladders take the haunteed to another floor.
+// A versus round is played on a single floor.
+func (m Model) canLeave() bool {
+	return !m.justArrived && m.versusGhost == nil && m.sandbox == nil
+}
+
+// fall drops the haunteed through a hole. It costs a life outside of crazy mode,
+// in crazy mode the haunteed gets disoriented instead (see app).
+func (m Model) fall(pos dweller.Position) (Model, tea.Cmd) {
+	m.soundManager.Play(sound.WALL_BREAK)
+	if m.state.GameMode != state.ModeCrazy {
+		m.haunteed.LoseLife()
+		m.score.Die()
+		if m.haunteed.IsDead() {
+			return m, gameOverCmd(m.score.Final())
+		}
+	}
+	return m, fallCmd(m.floor.Index-1, pos.X, pos.Y)
+}
+
+// eatDot scores a dot eaten by the haunteed.
+func (m *Model) eatDot() {
+	points := 0
+	switch m.state.GameMode {
+	case state.ModeEasy:
+		points = 5
+	case state.ModeNoisy:
+		points = 10
+	case state.ModeCrazy:
+		points = 15
+		if !m.fullVisibility {
+			points *= 2
+		}
+	}
+	m.score.Add(points)
+	m.soundManager.PlayWithVolume(sound.PICK_CRUMB, -1.5)
+	m.updateTempo()
+	m.callReinforcements()
+}
+
+// updateTempo speeds the music up with the floor depth and the dots left.
+func (m *Model) updateTempo() {
+	m.soundManager.SetTempo(sound.Tempo(m.floor.Index, m.floor.DotsLeft()))
+}
+
+// halfEaten returns true once half of the floor dots are eaten.
+func (m *Model) halfEaten() bool {
+	return m.floor.DotsLeft() <= m.floor.Dots/2
+}
+
+// callReinforcements releases the ghosts of the reinforcement dens with a klaxon once half of the dots are eaten.
+func (m *Model) callReinforcements() {
+	if m.reinforced || !m.halfEaten() {
+		return
+	}
+	if m.releaseReinforcements() {
+		m.soundManager.Play(sound.KLAXON)
+		m.events.Add("reinforcements out")
+	}
+}
+
+// releaseReinforcements lets the reinforcement ghosts out, it returns false if the floor has none.
+func (m *Model) releaseReinforcements() bool {
+	m.reinforced = true
+	released := false
+	for _, g := range m.ghosts {
+		if g.Reinforcement() {
+			g.SetRelease(0)
+			released = true
+		}
+	}
+	return released
+}
+
+// slide continues the haunteed move on slippery floors.
+// Sliding only passes over plain floor and dots, it stops at anything else.
+func (m *Model) slide() {
+	for i := 0; i < m.floor.Mutators.ExtraSteps(); i++ {
+		cur := m.haunteed.Pos()
+		if item, _ := m.floor.ItemAt(cur.X, cur.Y); item != floor.Empty && item != floor.Dot {
+			return
+		}
+		next := m.haunteed.NextPos()
+		if item, err := m.floor.ItemAt(next.X, next.Y); err != nil || item == floor.Wall || item == floor.CrumblingWall {
+			return
+		}
+		if m.floor.EatItem(cur.X, cur.Y) == floor.Dot {
+			m.eatDot()
+		}
+		m.moveHaunteed(next)
+	}
+}
+
+// moveHaunteed walks the haunteed one cell, leaving its scent and a trail for the follower.
+func (m *Model) moveHaunteed(pos dweller.Position) {
+	from := m.haunteed.Pos()
+	m.haunteed.SetPos(pos)
+	m.leaveScent()
+	if m.follower != nil {
+		m.follower.Track(from)
+	}
+}
+
+// leaveScent marks the haunteed's cell on the trail on floors where the ghosts follow it.
+func (m *Model) leaveScent() {
+	if m.floor.Mutators.GhostsFollowScent() {
+		pos := m.haunteed.Pos()
+		m.floor.LayScent(pos.X, pos.Y)
+	}
+}
+
+// SetVersus starts a versus round: player two steers the ghost of the given type,
+// the haunteed wins by surviving for round.
+func (m *Model) SetVersus(ghost dweller.GhostType, round time.Duration) {
+	m.turnBased = false
+	m.ghostController.SetTurnPeriod(0)
+	for i, g := range m.ghosts {
+		if g.Reinforcement() {
+			continue // Called by the dots as usual
+		}
+		g.SetRelease(dweller.ReleaseDelay(i))
+		if g.Type() == ghost {
+			g.SetControlled(true)
+			g.SetRelease(0) // Player two doesn't wait
+			m.versusGhost = g
+		}
+	}
+	m.versusRound = round
+	m.versusStart = time.Now()
+}
+
+// ReachedCheckpoint returns the checkpoint the haunteed touched on the floor, if any.
+func (m Model) ReachedCheckpoint() (dweller.Position, bool) {
+	if m.checkpoint == nil {
+		return dweller.Position{}, false
+	}
+	return *m.checkpoint, true
+}
+
+// SetEvents sets the event log the floor events are added to, it is kept across floors.
+func (m *Model) SetEvents(l *eventlog.Log) {
+	m.events = l
+}
+
+// SetHostEvents applies the power failure events of the real machine, see metrics:
+// a brownout makes the lights flicker, an extra ghost lets the reinforcements out right away.
+func (m *Model) SetHostEvents(e metrics.Events) {
+	if e.Brownout && !m.brownout {
+		m.events.Add("brownout")
+	}
+	m.brownout = e.Brownout
+	if e.ExtraGhost && !m.reinforced && m.releaseReinforcements() {
+		m.soundManager.Play(sound.KLAXON)
+		m.events.Add("low battery, reinforcements out")
+	}
+}
+
+// SetSaver switches the energy saver, ghosts keep their pace but the maze is redrawn less often.
+func (m *Model) SetSaver(on bool) {
+	m.saver = on
+}
+
+// tickPeriod returns the period of the next ghost tick. The energy saver skips the frames between ghost moves.
+func (m *Model) tickPeriod() time.Duration {
+	if !m.saver {
+		return framePeriod
+	}
+	if left := m.ghostTickInterval - time.Since(m.lastGhostMove); left > framePeriod {
+		return left
+	}
+	return framePeriod
+}
+
+// SetDebug allows the ghost view overlay outside of practice runs.
+func (m *Model) SetDebug(allowed bool) {
+	m.debugAllowed = allowed
+}
+
+// ghostPathLen is the number of path steps shown by the ghost view overlay
+const ghostPathLen = 12
+
+// ghostOverlay returns the ghost view sprites for maze cells: intended paths and target tiles in ghost colors.
+func (m *Model) ghostOverlay() map[dweller.Position][]string {
+	overlay := make(map[dweller.Position][]string)
+	if !m.ghostView {
+		return overlay
+	}
+	htPos := m.haunteed.Pos()
+	for _, g := range m.ghosts {
+		target, ok := g.Target(m.ghosts, htPos, m.haunteed.Dir())
+		if !ok {
+			continue
+		}
+		pathSprite, targetSprite := g.OverlaySprites()
+		for _, p := range g.PlanPath(m.floor, target, ghostPathLen) {
+			overlay[p] = pathSprite
+		}
+		overlay[target] = targetSprite
+	}
+	return overlay
+}
+
 func (m Model) Haunteed() *dweller.Haunteed {
 	return m.haunteed
 }
@@ -422,6 +1147,15 @@ func (m Model) Lives() int {
 	return m.haunteed.Lives()
 }
 
+// GhostStats returns what each ghost did since the play started, ghosts of the same type add up.
+func (m Model) GhostStats() map[dweller.GhostType]dweller.GhostStats {
+	stats := make(map[dweller.GhostType]dweller.GhostStats, len(m.ghosts))
+	for _, g := range m.ghosts {
+		stats[g.Type()] = stats[g.Type()].Add(g.Stats())
+	}
+	return stats
+}
+
 func abs(n int) int {
 	if n < 0 {
 		return -n
@@ -487,7 +1221,7 @@ func (m *Model) shouldScrollHorizontally() bool {
 func (m *Model) shouldScrollVertically() bool {
 	_, mazeHeightRows := m.getMazePixelDimensions()
 	headerH := m.headerRows()
-	footerH := 1
+	footerH := m.footerRows()
 	availableHeight := m.terminal.Height - headerH - footerH
 	return mazeHeightRows > availableHeight
 }
@@ -496,7 +1230,7 @@ func (m *Model) shouldScrollVertically() bool {
 func (m *Model) shouldScroll() (scrollH, scrollV bool) {
 	wChar, hRows := m.getMazePixelDimensions()
 	headerH := m.headerRows()
-	footerH := 1
+	footerH := m.footerRows()
 	availableHeight := m.terminal.Height - headerH - footerH - 1
 	scrollH = wChar > m.terminal.Width
 	scrollV = hRows > availableHeight
@@ -517,7 +1251,7 @@ func (m *Model) updateViewport() {
 	mazeHeight := m.floor.Maze.Height()
 	wChar, hRows := m.getSpriteCharDims()
 	headerH := m.headerRows()
-	footerH := 1
+	footerH := m.footerRows()
 	availableHeightRows := m.terminal.Height - headerH - footerH - 1
 	if availableHeightRows < 1 {
 		availableHeightRows = 1
@@ -602,6 +1336,7 @@ func (m *Model) render() {
 
 	if scrollH || scrollV {
 		m.updateViewport()
+		m.applyLook()
 	}
 
 	mazeWidth := m.floor.Maze.Width()
@@ -622,6 +1357,11 @@ func (m *Model) render() {
 	wChar, hRows := m.getSpriteCharDims()
 	mazeWidthChars := viewW * wChar
 	mazeHeightRows := viewH * hRows
+	split := m.splitView(scrollH)
+	if split {
+		viewW = max((m.terminal.Width-1)/2/wChar, 1)
+		mazeWidthChars = 2*viewW*wChar + 1
+	}
 
 	var horizontalPadding, verticalPadding int
 	if centerH {
@@ -632,7 +1372,7 @@ func (m *Model) render() {
 	}
 	if centerV {
 		headerH := m.headerRows()
-		footerH := 1
+		footerH := m.footerRows()
 		availableHeight := m.terminal.Height - headerH - footerH - 1
 		verticalPadding = (availableHeight - mazeHeightRows) / 2
 		if verticalPadding < 0 {
@@ -647,7 +1387,13 @@ func (m *Model) render() {
 
 	m.renderHeader(horizontalPadding)
 
-	m.renderMaze(startX, startY, viewW, viewH, horizontalPadding)
+	if split {
+		m.renderSplit(viewW, viewH, horizontalPadding)
+	} else {
+		m.renderMaze(startX, startY, viewW, viewH, horizontalPadding)
+	}
+
+	m.renderEvents(horizontalPadding)
 
 	m.renderMOTD(mazeWidthChars, horizontalPadding)
 
@@ -658,57 +1404,218 @@ func (m *Model) render() {
 // renderTopBar
 func (m *Model) renderTopBar(width, hPadding int) {
 	m.sb.WriteString(strings.Repeat(" ", hPadding))
-	m.sb.WriteString(style.TopPattern.Render(strings.Repeat("/", width)))
+	m.sb.WriteString(m.ui.TopPattern.Render(strings.Repeat("/", width)))
 	m.sb.WriteString("\n")
 }
 
 // renderHeader
 func (m *Model) renderHeader(hPadding int) {
-	m.sb.WriteString(style.Title.Render(m.headerText(hPadding)))
+	m.sb.WriteString(m.ui.Title.Render(m.headerText(hPadding)))
 	m.sb.WriteString("\n")
 }
 
-// headerText builds the header string based on game state. It always ends with a newline and may span multiple lines.
+// Terminal heights the header collapses below, so short terminals keep room for the maze
+const (
+	fullHeaderHeight    = 30 // below it the first line (geo info or debug HUD) is dropped
+	compactHeaderHeight = 25 // below it the header is a single line of icons and numbers
+)
+
+// headerText builds the header string based on game state, it spans headerRows lines.
 func (m *Model) headerText(horizontalPadding int) string {
-	var b strings.Builder
 	padString := strings.Repeat(" ", horizontalPadding)
+	lines := m.headerLines()
+	lines = lines[max(len(lines)-m.headerRows(), 0):]
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = padString + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// headerLines returns the lines of the full header, the least important first, or the compact header line.
+func (m *Model) headerLines() []string {
+	if m.headerRows() == 1 {
+		return []string{m.compactHeader()}
+	}
 	if m.paused {
-		b.WriteString("\n\n")
-		b.WriteString(padString)
-		b.WriteString("PAUSED")
+		paused := "PAUSED"
+		if banked, ok := m.score.Insured(); ok {
+			paused += fmt.Sprintf("  Insured: %d", banked)
+		}
+		return []string{"", "", paused}
+	}
+	// First line: geo/time info in crazy mode, the debug HUD takes it over
+	first := ""
+	if m.ghostView {
+		first = m.debugLine()
+	} else if m.state.GameMode == state.ModeCrazy && m.state.Streamer {
+		first = "Location hidden in streamer mode"
+	} else if m.state.GameMode == state.ModeCrazy {
+		first = fmt.Sprintf("Latitude: %.4f, Longitude: %.4f, Timezone: %s", m.state.LocationInfo.Lat, m.state.LocationInfo.Lon, m.state.LocationInfo.Timezone)
+	}
+	// Second line: mode/night/floor
+	second := fmt.Sprintf("Mode: %s  Floor: %d  Lives: %d%s", m.state.ModeName(), m.floor.Index, m.haunteed.Lives(), m.pocketTag()+m.heatTag()+m.noAudioTag()+m.saverTag())
+	if m.state.GameMode == state.ModeCrazy {
+		second = fmt.Sprintf("Mode: %s, Night: %s  Floor: %d  Lives: %d%s", m.state.ModeName(), m.state.NightOption, m.floor.Index, m.haunteed.Lives(), m.pocketTag()+m.heatTag()+m.noAudioTag()+m.saverTag())
+	}
+	// Final line: score/lives
+	var last string
+	highScore := m.score.GetHigh()
+	if m.versusGhost != nil {
+		last = fmt.Sprintf("VERSUS — survive %s more, %s hunts", m.versusLeft(), m.versusGhost.Type())
+	} else if m.haunteed.IsImmortal() {
+		last = fmt.Sprintf("Score: %d  PRACTICE — not recorded", m.score.Get())
+	} else if highScore > 0 {
+		last = fmt.Sprintf("Score: %d  High Score: %d by %s", m.score.Get(), m.score.GetHigh(), render.Isolate(nick.Sanitize(m.score.GetHighNick())))
 	} else {
-		if m.state.GameMode == state.ModeCrazy {
-			// First line: geo/time info (restored)
-			b.WriteString(padString)
-			b.WriteString(fmt.Sprintf("Latitude: %.4f, Longitude: %.4f, Timezone: %s\n", m.state.LocationInfo.Lat, m.state.LocationInfo.Lon, m.state.LocationInfo.Timezone))
-			// Second line: mode/night/floor
-			b.WriteString(padString)
-			b.WriteString(fmt.Sprintf("Mode: %s, Night: %s  Floor: %d  Lives: %d\n", m.state.GameMode, m.state.NightOption, m.floor.Index, m.haunteed.Lives()))
-		} else {
-			// One line: mode/floor
-			b.WriteString("\n")
-			b.WriteString(padString)
-			b.WriteString(fmt.Sprintf("Mode: %s  Floor: %d  Lives: %d\n", m.state.GameMode, m.floor.Index, m.haunteed.Lives()))
-		}
-		// Final line: score/lives
-		b.WriteString(padString)
-		highScore := m.score.GetHigh()
-		if highScore > 0 {
-			b.WriteString(fmt.Sprintf("Score: %d  High Score: %d by %s", m.score.Get(), m.score.GetHigh(), m.score.GetHighNick()))
-		} else {
-			b.WriteString(fmt.Sprintf("Score: %d  High Score: —", m.score.Get()))
+		last = fmt.Sprintf("Score: %d  High Score: —", m.score.Get())
+	}
+	return []string{first, second, last + m.handicapTag()}
+}
+
+// compactHeader returns the single line header of short terminals: the floor, lives and score with icons.
+// Pickups are left to the quick-action bar.
+func (m *Model) compactHeader() string {
+	line := fmt.Sprintf("⌂ %d  ♥ %d  ★ %s", m.floor.Index, m.haunteed.Lives(), score.Format(m.score.Get()))
+	switch {
+	case m.paused:
+		line = "PAUSED  " + line
+	case m.versusGhost != nil:
+		line += fmt.Sprintf("  VERSUS ⧗ %s %s", m.versusLeft(), m.versusGhost.Type())
+	case m.haunteed.IsImmortal():
+		line += "  PRACTICE"
+	case m.score.GetHigh() > 0:
+		line += fmt.Sprintf("  ♛ %s", score.Format(m.score.GetHigh()))
+	}
+	return line + m.handicapTag() + m.heatTag() + m.noAudioTag() + m.saverTag()
+}
+
+// versusLeft returns the time the haunteed still has to survive in a versus round.
+func (m *Model) versusLeft() time.Duration {
+	left := m.versusRound - time.Since(m.versusStart)
+	if m.paused {
+		left = m.versusRound - m.pausedAt.Sub(m.versusStart)
+	}
+	if left < 0 {
+		left = 0
+	}
+	return left.Round(time.Second)
+}
+
+// pocketTags name header tags of pickups in display order
+var pocketTags = []struct {
+	kind dweller.Pickup
+	tag  string
+}{
+	{dweller.Rewind, "Rewinds: %d [r]"},
+	{dweller.Trap, "Traps: %d [t]"},
+	{dweller.FuseCharge, "Fuses: %d [f]"},
+	{dweller.Camera, "Cameras: %d [e]"},
+	{dweller.Flashlight, "Flashlights: %d [x]"},
+}
+
+// pocketTag shows the pickups the haunteed carries and their keys in the header.
+func (m *Model) pocketTag() string {
+	var b strings.Builder
+	for _, p := range pocketTags {
+		if n := m.haunteed.Pickups(p.kind); n > 0 {
+			b.WriteString("  ")
+			b.WriteString(fmt.Sprintf(p.tag, n))
 		}
 	}
 	return b.String()
 }
 
-// headerRows returns the number of terminal rows the header occupies
+// debugLine shows the maze difficulty of the floor in the debug HUD.
+func (m *Model) debugLine() string {
+	return fmt.Sprintf("Maze difficulty: %s", m.floor.Difficulty)
+}
+
+// noAudioTag marks the header when no audio device was found and the game runs muted.
+func (m *Model) noAudioTag() string {
+	if m.soundManager == nil {
+		return "  No audio"
+	}
+	return ""
+}
+
+// saverTag tells the energy saver is on.
+func (m *Model) saverTag() string {
+	if m.saver {
+		return "  Saver"
+	}
+	return ""
+}
+
+// footerRows returns the number of terminal rows under the maze: the footer and the event log panel
+func (m *Model) footerRows() int {
+	rows := 2 // The quick-action bar and the controls
+	if m.events.Visible() {
+		rows += eventlog.Size
+	}
+	return rows
+}
+
+// headerRows returns the number of terminal rows the header occupies, it shrinks on short terminals
 func (m *Model) headerRows() int {
+	switch {
+	case m.terminal.Height < compactHeaderHeight:
+		return 1
+	case m.terminal.Height < fullHeaderHeight:
+		return 2
+	}
 	return 3
 }
 
 // renderMaze renders a specific viewport of the maze.
 func (m *Model) renderMaze(startX, startY, width, height, horizontalPadding int) {
+	for _, line := range m.mazeLines(startX, startY, width, height) {
+		m.sb.WriteString(strings.Repeat(" ", horizontalPadding))
+		m.sb.WriteString(line)
+		m.sb.WriteRune('\n')
+	}
+}
+
+// splitView returns true if the versus players get a viewport each: the maze is wider than the terminal.
+func (m *Model) splitView(scrollH bool) bool {
+	return scrollH && m.versusGhost != nil
+}
+
+// renderSplit renders the maze side by side, the left half follows the haunteed and the right half the ghost of player two.
+// Each half scrolls on its own, the width is split anew on every frame, so it follows terminal resizes.
+func (m *Model) renderSplit(width, height, horizontalPadding int) {
+	left := m.viewportOn(m.haunteed.Pos(), width, height)
+	right := m.viewportOn(m.versusGhost.Pos(), width, height)
+	leftLines := m.mazeLines(left.StartX, left.StartY, width, height)
+	rightLines := m.mazeLines(right.StartX, right.StartY, width, height)
+	separator := m.ui.Footer.Render("│")
+	for i := range leftLines {
+		m.sb.WriteString(strings.Repeat(" ", horizontalPadding))
+		m.sb.WriteString(leftLines[i])
+		m.sb.WriteString(separator)
+		if i < len(rightLines) {
+			m.sb.WriteString(rightLines[i])
+		}
+		m.sb.WriteRune('\n')
+	}
+}
+
+// viewportOn returns a viewport of the given size centered on pos as far as the maze edges allow.
+func (m *Model) viewportOn(pos dweller.Position, width, height int) Viewport {
+	width = min(width, m.floor.Maze.Width())
+	height = min(height, m.floor.Maze.Height())
+	return Viewport{
+		StartX: min(max(pos.X-width/2, 0), m.floor.Maze.Width()-width),
+		StartY: min(max(pos.Y-height/2, 0), m.floor.Maze.Height()-height),
+		Width:  width,
+		Height: height,
+	}
+}
+
+// mazeLines renders the part of the maze in view, large sprites take two lines a row.
+func (m *Model) mazeLines(startX, startY, width, height int) []string {
+	var lines []string
 	isLarge := m.state.SpriteSize == state.SpriteLarge
 	f := m.floor
 	h := m.haunteed
@@ -716,37 +1623,61 @@ func (m *Model) renderMaze(startX, startY, width, height, horizontalPadding int)
 	htPos := h.Pos()
 
 	dwellerSprites := make(map[dweller.Position][]string)
+	if m.follower != nil {
+		dwellerSprites[m.follower.Pos()] = f.Sprites[floor.Colleague]
+	}
 	dwellerSprites[h.Pos()] = h.Render(m.state.SpriteSize)
 	for _, gh := range g {
 		dwellerSprites[gh.Pos()] = gh.Render(m.state.SpriteSize)
+		if left, ok := gh.RespawnLeft(); ok && m.ghostView {
+			dwellerSprites[gh.Pos()] = m.countdownSprite(left)
+		}
 	}
+	overlay := m.ghostOverlay()
+	guide := m.guideOverlay()
+	pips := m.denPips()
 
 	for y := startY; y < startY+height && y < f.Maze.Height(); y++ {
 		var line1, line2 strings.Builder
-		if horizontalPadding > 0 {
-			line1.WriteString(strings.Repeat(" ", horizontalPadding))
-			if isLarge {
-				line2.WriteString(strings.Repeat(" ", horizontalPadding))
-			}
-		}
 		for x := startX; x < startX+width && x < f.Maze.Width(); x++ {
 			var sprite []string
 			pos := dweller.Position{X: x, Y: y}
-			if m.notVisible(pos, htPos) {
+			visible := !m.notVisible(pos, htPos)
+			if m.paused && m.spectral && pos == m.crosshair {
+				sprite = m.crosshairSprite()
+			} else if sp, ok := guide[pos]; ok && (!visible || dwellerSprites[pos] == nil) {
+				sprite = sp // The guide shines through the dark, but not over the ghosts in sight
+			} else if !visible {
 				sprite = f.Sprites[floor.Empty]
 			} else {
 				if sp, ok := dwellerSprites[pos]; ok {
 					sprite = sp
+				} else if sp, ok := pips[pos]; ok {
+					sprite = sp
+				} else if sp, ok := overlay[pos]; ok {
+					sprite = sp
 				} else {
 					item, _ := f.ItemAt(x, y)
 					if item == floor.CrumblingWall {
 						if m.powerMode {
 							sprite = f.Sprites[floor.CrumblingWall]
+						} else if damage := f.WallDamage(x, y); damage > 0 {
+							sprite = f.CrackSprites[damage-1]
 						} else {
 							sprite = f.Sprites[floor.Wall]
 						}
 					} else if item == floor.Fuse && !m.fullVisibility {
 						sprite = f.DimFuseSprite
+					} else if f.InBeam(x, y) && (item == floor.Empty || item == floor.Dot) {
+						sprite = f.BeamSprite
+					} else if f.IsGate(x, y) {
+						if item == floor.Wall {
+							sprite = f.GateSprites[0]
+						} else if item == floor.Empty {
+							sprite = f.GateSprites[1]
+						} else {
+							sprite = f.Sprites[item] // A trap set in an open gate
+						}
 					} else {
 						sprite = f.Sprites[item]
 					}
@@ -757,12 +1688,40 @@ func (m *Model) renderMaze(startX, startY, width, height, horizontalPadding int)
 				line2.WriteString(sprite[1])
 			}
 		}
-		m.sb.WriteString(line1.String())
-		m.sb.WriteRune('\n')
+		lines = append(lines, line1.String())
 		if isLarge {
-			m.sb.WriteString(line2.String())
-			m.sb.WriteRune('\n')
+			lines = append(lines, line2.String())
+		}
+	}
+	return lines
+}
+
+// countdownSprite shows the seconds a ghost still waits in the den.
+func (m *Model) countdownSprite(left time.Duration) []string {
+	w, h := m.getSpriteCharDims()
+	secs := fmt.Sprintf("%d", int(math.Ceil(left.Seconds())))
+	if len(secs) > w {
+		secs = secs[len(secs)-w:]
+	}
+	sprite := []string{styleHeader.Render(fmt.Sprintf("%*s", w, secs))}
+	for len(sprite) < h {
+		sprite = append(sprite, strings.Repeat(" ", w))
+	}
+	return sprite
+}
+
+// renderEvents renders the event log panel, padded to its full height so the maze doesn't jump.
+func (m *Model) renderEvents(hPadding int) {
+	if !m.events.Visible() {
+		return
+	}
+	lines := m.events.Lines()
+	for i := 0; i < eventlog.Size; i++ {
+		m.sb.WriteString(strings.Repeat(" ", hPadding))
+		if i < len(lines) {
+			m.sb.WriteString(m.ui.Footer.Render("» " + lines[i]))
 		}
+		m.sb.WriteString("\n")
 	}
 }
 
@@ -783,29 +1742,73 @@ func (m *Model) renderMOTD(width, horizontalPadding int) {
 
 // renderFooter
 func (m *Model) renderFooter(width, hPadding int) {
+	m.sb.WriteString("\n")
+	m.sb.WriteString(strings.Repeat(" ", hPadding))
+	m.sb.WriteString(m.quickBar())
 	m.sb.WriteString("\n")
 	m.sb.WriteString(strings.Repeat(" ", hPadding))
 	header := ""
 	switch {
+	case m.canInsure():
+		header = fmt.Sprintf("p — resume, i — insure %d%% of the score for %d%%, l — event log, ? — report, q — quit", score.InsuredShare, score.InsurancePremium)
+	case m.paused && m.spectral:
+		header = "p — resume, ← ↑ ↓ → — aim, space — photograph, l — event log, ? — report, q — quit"
 	case m.paused:
-		header = "p — resume, q — quit"
-	case m.state.GameMode == state.ModeCrazy && !m.gotCrumbs && m.haunteed.Lives() > 1:
-		header = "← ↑ ↓ → — move, p — pause, c — crumbs, q — quit"
+		header = "p — resume, l — event log, ? — report, q — quit"
+	case m.versusGhost != nil:
+		header = "← ↑ ↓ → — haunteed, w a s d — ghost, p — pause, esc — leave versus, q — quit"
+	case m.haunteed.IsImmortal():
+		header = "← ↑ ↓ → — move, p — pause, g — ghost view, esc — leave practice, q — quit"
+	case m.turnBased:
+		header = "← ↑ ↓ → — move, space — wait, p — pause, q — quit"
+	case m.guidesLeft > 0:
+		header = "← ↑ ↓ → — move, p — pause, c — guide, q — quit"
 	default:
 		header = "← ↑ ↓ → — move, p — pause, q — quit"
 	}
-	m.sb.WriteString(style.Footer.Render(header))
-	repeatCount := width - len([]rune(header))
+	m.sb.WriteString(m.ui.Footer.Render(header))
+	repeatCount := width - lipgloss.Width(header)
 	if repeatCount < 0 {
 		repeatCount = 0
 	}
-	m.sb.WriteString(style.Footer.Render(strings.Repeat("/", repeatCount)))
+	m.sb.WriteString(m.ui.Footer.Render(strings.Repeat("/", repeatCount)))
 	m.sb.WriteString("\n")
 
 }
 
 //
 
+// look shifts the paused viewport with the mouse wheel, shift turns the wheel sideways.
+func (m *Model) look(msg tea.MouseMsg) {
+	dx, dy := 0, 0
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		dy = -1
+	case tea.MouseButtonWheelDown:
+		dy = 1
+	case tea.MouseButtonWheelLeft:
+		dx = -1
+	case tea.MouseButtonWheelRight:
+		dx = 1
+	}
+	if msg.Shift {
+		dx, dy = dy, dx
+	}
+	// The shift stops at the maze edges, so scrolling back starts right away
+	width, height := m.floor.Maze.Width(), m.floor.Maze.Height()
+	m.lookX = min(max(m.lookX+dx, -m.viewport.StartX), width-m.viewport.Width-m.viewport.StartX)
+	m.lookY = min(max(m.lookY+dy, -m.viewport.StartY), height-m.viewport.Height-m.viewport.StartY)
+}
+
+// applyLook moves the viewport by the free-look shift while the game is paused, within the maze.
+func (m *Model) applyLook() {
+	if !m.paused {
+		return
+	}
+	m.viewport.StartX = min(max(m.viewport.StartX+m.lookX, 0), m.floor.Maze.Width()-m.viewport.Width)
+	m.viewport.StartY = min(max(m.viewport.StartY+m.lookY, 0), m.floor.Maze.Height()-m.viewport.Height)
+}
+
 // resetViewport completely resets the viewport to initial state
 func (m *Model) resetViewport() {
 	m.viewport = Viewport{StartX: 0, StartY: 0, Width: 0, Height: 0}
@@ -818,9 +1821,24 @@ func (m *Model) resetViewport() {
 // If "NightOption" is set to "always", the upper floor is always dark.
 // If "NightOption" is set to "real", the upper floor is dark only during the real night,
 // in dawn and dusk it is lit but has reduced visibility and in daylight it is fully lit.
+// Floor mutators may shrink the visibility radius further in any mode.
 func (m Model) notVisible(spritePos, hauntedPos dweller.Position) bool {
 	isLimitedVisibilityActive := (m.state.GameMode == state.ModeCrazy) && (m.floor.Index < 0 || m.state.NightOption == state.NightAlways || m.state.NightOption == state.NightReal)
-	return isLimitedVisibilityActive && !m.fullVisibility && distance(spritePos, hauntedPos) > m.floor.VisibilityRadius
+	radius := m.floor.FullVisibilityRadius()
+	if isLimitedVisibilityActive && !m.fullVisibility {
+		radius = m.floor.VisibilityRadius
+	}
+	radius = m.floor.Mutators.Visibility(radius, time.Now())
+	if m.brownout {
+		radius = mutator.Brownout{}.Visibility(radius, time.Now())
+	}
+	if m.overheated() {
+		radius = min(radius, overheatRadius)
+	}
+	if m.floor.InBeam(spritePos.X, spritePos.Y) {
+		return false // The flashlight cuts through the dark
+	}
+	return distance(spritePos, hauntedPos) > radius
 }
 
 // View returns the complete screen output with game entities and stats.
@@ -0,0 +1,177 @@
+package play
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/floor"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+)
+
+// quickAction is an item or ability usable with its letter key or a number key of the quick-action bar.
+type quickAction struct {
+	label       string
+	cooldown    time.Duration          // rest after a use, so a held key doesn't empty the pocket
+	whilePaused bool                   // usable in the pause menu
+	count       func(m *Model) int     // uses left shown in the bar, nil if not counted
+	ready       func(m *Model) bool    // usable now
+	use         func(m *Model) tea.Cmd // called once ready
+}
+
+// pickupCount returns the number of pickups of the kind the haunteed carries.
+func pickupCount(kind dweller.Pickup) func(m *Model) int {
+	return func(m *Model) int {
+		return m.haunteed.Pickups(kind)
+	}
+}
+
+// hasPickup returns true if the haunteed carries a pickup of the kind.
+func hasPickup(kind dweller.Pickup) func(m *Model) bool {
+	return func(m *Model) bool {
+		return m.haunteed.Pickups(kind) > 0
+	}
+}
+
+// quickActions are the actions by name, see state.QuickActions
+var quickActions = map[string]quickAction{
+	state.ActionGuide: {
+		label:    "Guide",
+		cooldown: guidePeriod,
+		count:    func(m *Model) int { return m.guidesLeft },
+		ready: func(m *Model) bool {
+			return m.guidesLeft > 0 && m.score.Get() >= guideCost && m.versusGhost == nil
+		},
+		use: func(m *Model) tea.Cmd {
+			m.showGuide()
+			return nil
+		},
+	},
+	state.ActionTrap: {
+		label:    "Trap",
+		cooldown: 2 * time.Second,
+		count:    pickupCount(dweller.Trap),
+		ready: func(m *Model) bool {
+			pos := m.haunteed.Pos()
+			item, _ := m.floor.ItemAt(pos.X, pos.Y)
+			return m.haunteed.Pickups(dweller.Trap) > 0 && item == floor.Empty
+		},
+		use: func(m *Model) tea.Cmd {
+			pos := m.haunteed.Pos()
+			m.floor.SetTrap(pos.X, pos.Y)
+			m.haunteed.UsePickup(dweller.Trap)
+			m.soundManager.Play(sound.UI_CLICK)
+			return nil
+		},
+	},
+	state.ActionFuse: {
+		label:    "Fuse",
+		cooldown: 2 * time.Second,
+		count:    pickupCount(dweller.FuseCharge),
+		ready:    hasPickup(dweller.FuseCharge),
+		use: func(m *Model) tea.Cmd {
+			m.haunteed.UsePickup(dweller.FuseCharge)
+			return m.toggleFuse()
+		},
+	},
+	state.ActionRewind: {
+		label:    "Rewind",
+		cooldown: 3 * time.Second,
+		count:    pickupCount(dweller.Rewind),
+		ready: func(m *Model) bool {
+			return m.versusGhost == nil && m.haunteed.Pickups(dweller.Rewind) > 0
+		},
+		use: func(m *Model) tea.Cmd {
+			m.rewindWorld()
+			return nil
+		},
+	},
+	state.ActionCamera: {
+		label:    "Camera",
+		cooldown: time.Second,
+		count:    pickupCount(dweller.Camera),
+		ready: func(m *Model) bool {
+			return m.haunteed.Pickups(dweller.Camera) > 0 && m.ghostInReach() != nil
+		},
+		use: func(m *Model) tea.Cmd {
+			return m.photograph()
+		},
+	},
+	state.ActionFlashlight: {
+		label:    "Light",
+		cooldown: beamPeriod,
+		count:    pickupCount(dweller.Flashlight),
+		ready:    hasPickup(dweller.Flashlight),
+		use: func(m *Model) tea.Cmd {
+			m.lightBeam()
+			return nil
+		},
+	},
+	state.ActionLog: {
+		label:       "Log",
+		whilePaused: true,
+		ready: func(m *Model) bool {
+			return m.events != nil
+		},
+		use: func(m *Model) tea.Cmd {
+			m.events.Toggle()
+			m.resetViewport()
+			m.updateViewport()
+			return nil
+		},
+	},
+}
+
+// quickUse runs the named action, it returns false if the action can't be used now.
+func (m *Model) quickUse(name string) (tea.Cmd, bool) {
+	a, ok := quickActions[name]
+	if !ok || (m.paused && !a.whilePaused) || time.Now().Before(m.cooldowns[name]) || !a.ready(m) {
+		return nil, false
+	}
+	cmd := a.use(m)
+	if a.cooldown > 0 {
+		m.cooldowns[name] = time.Now().Add(a.cooldown)
+	}
+	return cmd, true
+}
+
+// quickKey returns the action bound to a number key, false if the key is not bound.
+func (m *Model) quickKey(key string) (string, bool) {
+	if len(key) != 1 || key[0] < '1' || key[0] > '9' {
+		return "", false
+	}
+	bar := m.state.QuickBarActions()
+	if i := int(key[0] - '1'); i < len(bar) {
+		return bar[i], true
+	}
+	return "", false
+}
+
+// quickBar renders the bound actions with their number keys: usable ones are lit,
+// the others are dimmed and those resting show the seconds left.
+func (m *Model) quickBar() string {
+	var parts []string
+	for i, name := range m.state.QuickBarActions() {
+		a, ok := quickActions[name]
+		if !ok {
+			continue
+		}
+		text := fmt.Sprintf("%d %s", i+1, a.label)
+		if a.count != nil {
+			text += fmt.Sprintf("×%d", a.count(m))
+		}
+		st := m.ui.PlayHeader
+		if left := time.Until(m.cooldowns[name]); left > 0 {
+			text += fmt.Sprintf(" %.0fs", math.Ceil(left.Seconds()))
+			st = m.ui.Footer
+		} else if !a.ready(m) {
+			st = m.ui.Footer
+		}
+		parts = append(parts, st.Render(text))
+	}
+	return strings.Join(parts, "  ")
+}
@@ -0,0 +1,55 @@
+package play
+
+import "github.com/vinser/haunteed/internal/dweller"
+
+// rewindSnapshots is the number of snapshots kept: one per ghost tick or turn, about 5 seconds of play
+const rewindSnapshots = 50
+
+// snapshot holds entity positions at one moment.
+// Only positions are rolled back: eaten items, scores and ghost states stay as they are,
+// so nothing can be collected twice.
+type snapshot struct {
+	haunteed dweller.Position
+	ghosts   []ghostSnapshot
+}
+
+type ghostSnapshot struct {
+	pos dweller.Position
+	dir dweller.Direction
+}
+
+// rewindBuffer is a ring buffer of the latest snapshots.
+type rewindBuffer struct {
+	snapshots [rewindSnapshots]snapshot
+	next      int // index the next snapshot is written to
+	count     int
+}
+
+// record adds a snapshot of the haunteed and ghosts, overwriting the oldest one when full.
+func (b *rewindBuffer) record(h *dweller.Haunteed, ghosts []*dweller.Ghost) {
+	s := &b.snapshots[b.next]
+	s.haunteed = h.Pos()
+	s.ghosts = s.ghosts[:0]
+	for _, g := range ghosts {
+		s.ghosts = append(s.ghosts, ghostSnapshot{pos: g.Pos(), dir: g.Dir()})
+	}
+	b.next = (b.next + 1) % rewindSnapshots
+	b.count = min(b.count+1, rewindSnapshots)
+}
+
+// rewind restores the oldest snapshot and empties the buffer. It returns false if there is nothing to restore.
+func (b *rewindBuffer) rewind(h *dweller.Haunteed, ghosts []*dweller.Ghost) bool {
+	if b.count == 0 {
+		return false
+	}
+	oldest := &b.snapshots[(b.next-b.count+rewindSnapshots)%rewindSnapshots]
+	h.SetPos(oldest.haunteed)
+	for i, g := range ghosts {
+		if i < len(oldest.ghosts) {
+			g.SetPos(oldest.ghosts[i].pos)
+			g.SetDirection(oldest.ghosts[i].dir)
+		}
+	}
+	b.count = 0
+	return true
+}
@@ -0,0 +1,47 @@
+package play
+
+import "github.com/vinser/haunteed/internal/dweller"
+
+// Sandbox sets up a training floor: how many ghosts haunt it, the state they keep outside power mode
+// and whether eaten power pellets come back.
+type Sandbox struct {
+	Ghosts         int
+	State          dweller.GhostState // Chase, Scatter or Frightened
+	EndlessPellets bool
+}
+
+// SetSandbox turns the floor into a sandbox, the haunteed stays on it.
+func (m *Model) SetSandbox(s Sandbox) {
+	m.sandbox = &s
+	m.ghosts = m.ghosts[:max(min(s.Ghosts, len(m.ghosts)), 1)]
+	m.drill()
+}
+
+// drill keeps the training dummies in the chosen state while no power pellet is in effect.
+func (m *Model) drill() {
+	if m.sandbox == nil || m.powerMode {
+		return
+	}
+	for _, g := range m.ghosts {
+		if st := g.State(); st == dweller.Chase || st == dweller.Scatter || st == dweller.Frightened {
+			g.SetState(m.sandbox.State)
+		}
+	}
+}
+
+// refillPellets puts the power pellets eaten during the last power mode back in endless pellet sandboxes.
+func (m *Model) refillPellets() {
+	if m.sandbox != nil && m.sandbox.EndlessPellets {
+		for _, p := range m.eatenPellets {
+			m.floor.PutPellet(p.X, p.Y)
+		}
+	}
+	m.eatenPellets = nil
+}
+
+// pelletEaten remembers a power pellet eaten in an endless pellet sandbox.
+func (m *Model) pelletEaten(pos dweller.Position) {
+	if m.sandbox != nil && m.sandbox.EndlessPellets {
+		m.eatenPellets = append(m.eatenPellets, pos)
+	}
+}
@@ -0,0 +1,66 @@
+package play
+
+import (
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+)
+
+// residueBonus is scored on resume for every spectral residue photographed during the pause
+const residueBonus = 25
+
+// crosshairMoves move the crosshair of spectral photography in the pause menu
+var crosshairMoves = map[string]dweller.Position{
+	"up": {X: 0, Y: -1}, "down": {X: 0, Y: 1}, "left": {X: -1, Y: 0}, "right": {X: 1, Y: 0},
+}
+
+// SetSpectral allows spectral photography in the pause menu, leaderboard runs keep the pause idle.
+func (m *Model) SetSpectral(on bool) {
+	m.spectral = on
+}
+
+// aim moves the crosshair within the visible part of the maze.
+func (m *Model) aim(d dweller.Position) {
+	next := dweller.Position{X: m.crosshair.X + d.X, Y: m.crosshair.Y + d.Y}
+	v := m.viewport
+	if next.X < v.StartX || next.X >= v.StartX+v.Width || next.Y < v.StartY || next.Y >= v.StartY+v.Height {
+		return
+	}
+	if m.notVisible(next, m.haunteed.Pos()) {
+		return
+	}
+	m.crosshair = next
+}
+
+// photographCell takes a photo of the cell under the crosshair, spectral residue shows up on it.
+func (m *Model) photographCell() {
+	if m.floor.Photograph(m.crosshair.X, m.crosshair.Y) {
+		m.soundManager.Play(sound.EAT_PELLET)
+		m.events.Add("spectral residue")
+		m.developed++
+		return
+	}
+	m.soundManager.Play(sound.UI_CLICK)
+}
+
+// developPhotos scores the residue photographed during the pause, it runs on resume.
+func (m *Model) developPhotos() {
+	if m.developed == 0 {
+		return
+	}
+	m.score.Add(m.developed * residueBonus)
+	m.events.Add("developed %d residue photos", m.developed)
+	m.developed = 0
+}
+
+// crosshairSprite marks the cell under the crosshair.
+func (m *Model) crosshairSprite() []string {
+	switch m.state.SpriteSize {
+	case state.SpriteSmall:
+		return []string{styleHeader.Render("+")}
+	case state.SpriteLarge:
+		return []string{styleHeader.Render("┌  ┐"), styleHeader.Render("└  ┘")}
+	default: // state.SpriteMedium
+		return []string{styleHeader.Render("[]")}
+	}
+}
@@ -0,0 +1,169 @@
+package practice
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+// maxRows is the number of floors listed at once
+const maxRows = 9
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	floors   []int
+	seeds    map[int]int64
+	selected int
+	offset   int
+
+	boxOpen bool    // the sandbox panel is shown instead of the floors
+	box     sandbox // sandbox choices
+
+	soundManager *sound.Manager
+}
+
+// StartPracticeMsg is a message sent when the player picks a floor to practice on.
+type StartPracticeMsg struct {
+	Floor int
+	Seed  int64
+}
+
+func startPracticeCmd(floor int, seed int64) tea.Cmd {
+	return func() tea.Msg {
+		return StartPracticeMsg{Floor: floor, Seed: seed}
+	}
+}
+
+// ClosePracticeMsg is a message sent when the player leaves the floor list.
+type ClosePracticeMsg struct{}
+
+func closePracticeCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ClosePracticeMsg{}
+	}
+}
+
+func New(st *state.State, ui style.UI, width, height int, sm *sound.Manager) Model {
+	width = max(width, lipgloss.Width(footer))
+	floors := st.ReachedFloors()
+	selected := 0
+	for i, f := range floors {
+		if f == 0 {
+			selected = i
+		}
+	}
+	m := Model{
+		ui:           ui,
+		width:        width,
+		height:       height,
+		floors:       floors,
+		seeds:        st.FloorSeeds,
+		selected:     selected,
+		box:          newSandbox(),
+		soundManager: sm,
+	}
+	m.scroll()
+	return m
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.boxOpen {
+			cmd, leave := m.updateSandbox(msg.String())
+			m.boxOpen = !leave
+			return m, cmd
+		}
+		switch msg.String() {
+		case "s":
+			m.boxOpen = true
+			m.soundManager.Play(sound.UI_CLICK)
+		case "esc":
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, closePracticeCmd()
+		case "up":
+			if m.selected > 0 {
+				m.selected--
+			}
+			m.scroll()
+			m.soundManager.Play(sound.UI_CLICK)
+		case "down":
+			if m.selected < len(m.floors)-1 {
+				m.selected++
+			}
+			m.scroll()
+			m.soundManager.Play(sound.UI_CLICK)
+		case "enter", " ":
+			if len(m.floors) == 0 {
+				return m, nil
+			}
+			m.soundManager.Play(sound.UI_SAVE)
+			floor := m.floors[m.selected]
+			return m, startPracticeCmd(floor, m.seeds[floor])
+		}
+	}
+	return m, nil
+}
+
+// scroll keeps the selected floor inside the visible rows
+func (m *Model) scroll() {
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+maxRows {
+		m.offset = m.selected - maxRows + 1
+	}
+}
+
+const footer = "↑ ↓ — select, enter — practice, s — sandbox, esc — back"
+
+func (m Model) View() string {
+	if m.boxOpen {
+		return m.sandboxView()
+	}
+	return render.Page(m.ui, "Practice", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+	b.WriteString("Pick a floor you have already reached.\n")
+	b.WriteString("Lives are endless and scores are not recorded.\n\n")
+	end := min(m.offset+maxRows, len(m.floors))
+	for i := m.offset; i < end; i++ {
+		floor := m.floors[i]
+		prefix := "  "
+		if i == m.selected {
+			prefix = "▶ "
+		}
+		line := fmt.Sprintf("%sFloor %4d   seed %d", prefix, floor, m.seeds[floor])
+		if i == m.selected {
+			b.WriteString(m.ui.SetupItemSelected.Render(line))
+		} else {
+			b.WriteString(m.ui.SetupItem.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	// Keep the page height stable while scrolling
+	b.WriteString(strings.Repeat("\n", maxRows-(end-m.offset)))
+	return b.String()
+}
@@ -0,0 +1,130 @@
+package practice
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/model/play"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+)
+
+// StartSandboxMsg is a message sent when the player starts the sandbox.
+// SpeedPercent is the ghost speed relative to the ground floor.
+type StartSandboxMsg struct {
+	Sandbox      play.Sandbox
+	SpeedPercent int
+}
+
+func startSandboxCmd(s play.Sandbox, speed int) tea.Cmd {
+	return func() tea.Msg {
+		return StartSandboxMsg{Sandbox: s, SpeedPercent: speed}
+	}
+}
+
+var (
+	sandboxStates = []dweller.GhostState{dweller.Chase, dweller.Scatter, dweller.Frightened}
+	stateNames    = []string{"chase", "scatter", "frightened"}
+	sandboxSpeeds = []int{50, 75, 100, 150, 200}
+)
+
+// Sandbox rows
+const (
+	rowGhosts = iota
+	rowState
+	rowSpeed
+	rowPellets
+	sandboxRows
+)
+
+// sandbox holds the choices of the sandbox panel as indexes into the option lists.
+type sandbox struct {
+	row     int
+	ghosts  int // 1 to 4
+	state   int
+	speed   int
+	endless bool
+}
+
+func newSandbox() sandbox {
+	return sandbox{ghosts: 4, speed: 2, endless: true}
+}
+
+// updateSandbox handles the keys of the sandbox panel, it returns true if the panel is left.
+func (m *Model) updateSandbox(key string) (tea.Cmd, bool) {
+	s := &m.box
+	switch key {
+	case "esc", "s":
+		m.soundManager.Play(sound.UI_CANCEL)
+		return nil, true
+	case "up":
+		s.row = max(s.row-1, 0)
+	case "down":
+		s.row = min(s.row+1, sandboxRows-1)
+	case "left", "right":
+		step := 1
+		if key == "left" {
+			step = -1
+		}
+		switch s.row {
+		case rowGhosts:
+			s.ghosts = min(max(s.ghosts+step, 1), 4)
+		case rowState:
+			s.state = (s.state + step + len(sandboxStates)) % len(sandboxStates)
+		case rowSpeed:
+			s.speed = min(max(s.speed+step, 0), len(sandboxSpeeds)-1)
+		case rowPellets:
+			s.endless = !s.endless
+		}
+	case "enter", " ":
+		m.soundManager.Play(sound.UI_SAVE)
+		return startSandboxCmd(play.Sandbox{
+			Ghosts:         s.ghosts,
+			State:          sandboxStates[s.state],
+			EndlessPellets: s.endless,
+		}, sandboxSpeeds[s.speed]), false
+	default:
+		return nil, false
+	}
+	m.soundManager.Play(sound.UI_CLICK)
+	return nil, false
+}
+
+const sandboxFooter = "↑ ↓ — select, ← → — change, enter — start, esc — floors"
+
+func (m Model) sandboxView() string {
+	return render.Page(m.ui, "Sandbox", m.renderSandbox(), sandboxFooter, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderSandbox() string {
+	s := m.box
+	pellets := "off"
+	if s.endless {
+		pellets = "endless"
+	}
+	values := [sandboxRows][2]string{
+		{"Ghosts", fmt.Sprintf("%d", s.ghosts)},
+		{"Ghost state", stateNames[s.state]},
+		{"Ghost speed", fmt.Sprintf("%d%%", sandboxSpeeds[s.speed])},
+		{"Power pellets", pellets},
+	}
+	var b strings.Builder
+	b.WriteString("Practice cornering, baiting and pellet chains.\n")
+	b.WriteString("Ghosts keep the state you pick until a pellet frightens them.\n\n")
+	for i, v := range values {
+		prefix := "  "
+		if i == s.row {
+			prefix = "▶ "
+		}
+		line := prefix + render.PadRight(v[0], 14) + render.PadLeft(v[1], 11)
+		if i == s.row {
+			b.WriteString(m.ui.SetupItemSelected.Render(line))
+		} else {
+			b.WriteString(m.ui.SetupItem.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -6,6 +6,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/style"
 )
 
 const quitPeriod = 3 * time.Second
@@ -15,6 +16,7 @@ type Model struct {
 	height     int
 	termWidth  int
 	termHeight int
+	ui         style.UI
 
 	quitUntil time.Time
 }
@@ -35,9 +37,10 @@ func timedoutCmd() tea.Cmd {
 	}
 }
 
-func New(width, height int) Model {
+func New(ui style.UI, width, height int) Model {
 	width = max(width, lipgloss.Width(footer))
 	return Model{
+		ui:     ui,
 		width:  width,
 		height: height,
 
@@ -72,7 +75,7 @@ func (m Model) View() string {
 	if (time.Now().UnixNano()/int64(time.Millisecond)/500)%2 == 0 {
 		flash = "Quitting..."
 	}
-	return render.Page(flash, m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+	return render.Page(m.ui, flash, m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
 }
 
 func (m Model) renderContent() string {
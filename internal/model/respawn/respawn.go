@@ -7,6 +7,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/style"
 )
 
 const respawnPeriod = 5 * time.Second
@@ -16,6 +17,7 @@ type Model struct {
 	height     int
 	termWidth  int
 	termHeight int
+	ui         style.UI
 
 	lives        int
 	respawnUntil time.Time
@@ -38,11 +40,12 @@ func timedoutCmd() tea.Cmd {
 	}
 }
 
-func New(lives, width, height int) Model {
+func New(lives int, ui style.UI, width, height int) Model {
 	if width < lipgloss.Width(footer) {
 		width = lipgloss.Width(footer)
 	}
 	return Model{
+		ui:     ui,
 		width:  width,
 		height: height,
 
@@ -74,7 +77,7 @@ func (m Model) View() string {
 	if (time.Now().UnixNano()/int64(time.Millisecond)/500)%2 == 0 {
 		flash = "Respawning..."
 	}
-	return render.Page(flash, m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+	return render.Page(m.ui, flash, m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
 }
 
 func (m Model) renderContent() string {
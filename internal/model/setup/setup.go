@@ -3,11 +3,15 @@ package setup
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
 	"github.com/vinser/haunteed/internal/geoip"
+	"github.com/vinser/haunteed/internal/mutator"
 	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/score"
 	"github.com/vinser/haunteed/internal/sound"
 	"github.com/vinser/haunteed/internal/state"
 	"github.com/vinser/haunteed/internal/style"
@@ -16,22 +20,72 @@ import (
 const (
 	selectedMode = iota
 	selectedCrazyNight
+	selectedTurnBased
+	selectedWeekly
+	selectedRetro
+	selectedSpeedBonus
+	selectedExtraLives
+	selectedLongFright
 	selectedSpriteSize
 	selectedMute
+	selectedBell
+	selectedRepeat
+	selectedDebounce
+	selectedSticky
+	selectedInitials
+	selectedUIScale
+	selectedMouse
+	selectedSaver
+	selectedSplash
+	selectedTelemetry
+	selectedUpdateCheck
+	selectedTermTitle
 	selectedReset
 )
 
+// maxOptions is the number of options shown in crazy mode, used to keep the view height steady
+const maxOptions = 23
+
+// Input accessibility choices, cycled in order
+var (
+	repeatChoices   = []int{state.RepeatDefault, 150, 200, 300, 50}
+	debounceChoices = []int{0, 100, 200, 300}
+	stickyChoices   = []int{1, 2, 3, 5}
+	saverChoices    = []int{state.SaverDefault, 40, 100, 0}
+	livesChoices    = []int{0, 1, 2, state.MaxExtraLives}
+)
+
 type Model struct {
 	width      int
 	height     int
 	termWidth  int
 	termHeight int
+	ui         style.UI
 
 	mode       string // easy, noisy or crazy
 	crazyNight string // never, always or real (at location)
 	spriteSize string // small, medium or large
 	mute       bool
+	bell       bool   // terminal bell patterns for key events
+	turnBased  bool   // puzzle variant
+	weekly     bool   // modifiers of the week
+	retro      bool   // symmetric arcade-like mazes
+	speedBonus bool   // handicap: slower ghosts
+	extraLives int    // handicap: lives on top of the mode ones
+	longFright bool   // handicap: longer frightened ghosts
+	repeatMs   int    // auto-repeat anticheat threshold
+	debounceMs int    // input debounce, 0 is off
+	sticky     int    // cells moved by a single key press
+	initials   bool   // arcade three-initials entry of high scores
+	uiScale    string // normal or large
+	mouse      bool   // wheel scrolling and clicks
+	saverBelow int    // battery percent the energy saver kicks in at, 0 is off
+	splash     string // auto or always
+	telemetry  bool   // collect gameplay stats locally
+	update     bool   // check for updates daily
+	termTitle  bool   // show the game in the terminal title
 	reset      bool
+	streamer   bool // hide the located city, not a setting but a flag
 
 	selectedSetting int
 	soundManager    *sound.Manager
@@ -45,22 +99,130 @@ func viewAboutCmd() tea.Cmd {
 	}
 }
 
+type ViewPracticeMsg struct{}
+
+func viewPracticeCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewPracticeMsg{}
+	}
+}
+
+type ViewVersusMsg struct{}
+
+func viewVersusCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewVersusMsg{}
+	}
+}
+
+type ViewBestiaryMsg struct{}
+
+func viewBestiaryCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewBestiaryMsg{}
+	}
+}
+
+type ViewChallengesMsg struct{}
+
+func viewChallengesCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewChallengesMsg{}
+	}
+}
+
+type ViewGalleryMsg struct{}
+
+func viewGalleryCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewGalleryMsg{}
+	}
+}
+
+type ViewVaultMsg struct{}
+
+func viewVaultCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewVaultMsg{}
+	}
+}
+
+type ViewAlbumMsg struct{}
+
+func viewAlbumCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewAlbumMsg{}
+	}
+}
+
+type ViewLatencyMsg struct{}
+
+func viewLatencyCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewLatencyMsg{}
+	}
+}
+
+type ViewIssueMsg struct{}
+
+func viewIssueCmd() tea.Cmd {
+	return func() tea.Msg {
+		return ViewIssueMsg{}
+	}
+}
+
 type SaveSettingsMsg struct {
 	Mode       string
 	CrazyNight string
 	SpriteSize string
 	Mute       bool
+	Bell       bool
+	TurnBased  bool
+	Weekly     bool
+	Retro      bool
+	SpeedBonus bool
+	ExtraLives int
+	LongFright bool
+	RepeatMs   int
+	DebounceMs int
+	Sticky     int
+	Initials   bool
+	UIScale    string
+	Mouse      bool
+	SaverBelow int
+	Splash     string
+	Telemetry  bool
+	Update     bool
+	TermTitle  bool
 	Reset      bool
 }
 
-func saveSettingsCmd(mode, crazyNight, spriteSize string, mute, reset bool) tea.Cmd {
+func saveSettingsCmd(m Model) tea.Cmd {
 	return func() tea.Msg {
 		return SaveSettingsMsg{
-			Mode:       mode,
-			CrazyNight: crazyNight,
-			SpriteSize: spriteSize,
-			Mute:       mute,
-			Reset:      reset,
+			Mode:       m.mode,
+			CrazyNight: m.crazyNight,
+			SpriteSize: m.spriteSize,
+			Mute:       m.mute,
+			Bell:       m.bell,
+			TurnBased:  m.turnBased,
+			Weekly:     m.weekly,
+			Retro:      m.retro,
+			SpeedBonus: m.speedBonus,
+			ExtraLives: m.extraLives,
+			LongFright: m.longFright,
+			RepeatMs:   m.repeatMs,
+			DebounceMs: m.debounceMs,
+			Sticky:     m.sticky,
+			Initials:   m.initials,
+			UIScale:    m.uiScale,
+			Mouse:      m.mouse,
+			SaverBelow: m.saverBelow,
+			Splash:     m.splash,
+			Telemetry:  m.telemetry,
+			Update:     m.update,
+			TermTitle:  m.termTitle,
+			Reset:      m.reset,
 		}
 	}
 }
@@ -73,19 +235,39 @@ func discardSettingsCmd() tea.Cmd {
 	}
 }
 
-func New(mode, crazyNight, spriteSize string, mute bool, width, height int, sm *sound.Manager) Model {
+func New(st *state.State, ui style.UI, width, height int, sm *sound.Manager) Model {
 	if width < lipgloss.Width(footer) {
 		width = lipgloss.Width(footer)
 	}
 	return Model{
+		ui:     ui,
 		width:  width,
 		height: height,
 
-		mode:       mode,
-		crazyNight: crazyNight,
-		spriteSize: spriteSize,
-		mute:       mute,
+		mode:       st.GameMode,
+		crazyNight: st.NightOption,
+		spriteSize: st.SpriteSize,
+		mute:       st.Mute,
+		bell:       st.Bell,
+		turnBased:  st.TurnBased,
+		weekly:     st.Weekly,
+		retro:      st.Retro,
+		speedBonus: st.SpeedBonus,
+		extraLives: st.ExtraLives,
+		longFright: st.LongFright,
+		repeatMs:   int(st.RepeatThreshold().Milliseconds()),
+		debounceMs: st.DebounceMs,
+		sticky:     max(st.StickySteps, 1),
+		initials:   st.Initials,
+		uiScale:    st.UIScale,
+		mouse:      st.Mouse,
+		saverBelow: st.SaverBelow,
+		splash:     splashValue(st.Splash),
+		telemetry:  st.Telemetry,
+		update:     st.UpdateCheck,
+		termTitle:  st.TermTitle,
 		reset:      false,
+		streamer:   st.Streamer,
 
 		selectedSetting: 0,
 		soundManager:    sm,
@@ -105,17 +287,41 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 	switch msg := msg.(type) {
 
 	case tea.KeyMsg:
-		numSettings := 4
-		if m.mode == state.ModeCrazy {
-			numSettings = 5
-		}
+		keys := m.optionKeys()
 
 		switch msg.String() {
 		case "a":
 			return m, viewAboutCmd()
+		case "p":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewPracticeCmd()
+		case "v":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewVersusCmd()
+		case "c":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewChallengesCmd()
+		case "e":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewGalleryCmd()
+		case "g":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewBestiaryCmd()
+		case "?":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewIssueCmd()
+		case "l":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewLatencyCmd()
+		case "o":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewVaultCmd()
+		case "f":
+			m.soundManager.Play(sound.UI_CLICK)
+			return m, viewAlbumCmd()
 		case "s":
 			m.soundManager.Play(sound.UI_SAVE)
-			return m, saveSettingsCmd(m.mode, m.crazyNight, m.spriteSize, m.mute, m.reset)
+			return m, saveSettingsCmd(m)
 		case "esc":
 			m.soundManager.Play(sound.UI_CANCEL)
 			return m, discardSettingsCmd()
@@ -126,56 +332,134 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 			m.soundManager.Play(sound.UI_CLICK)
 			return m, nil
 		case "down":
-			if m.selectedSetting < numSettings-1 {
+			if m.selectedSetting < len(keys)-1 {
 				m.selectedSetting++
 			}
 			m.soundManager.Play(sound.UI_CLICK)
 			return m, nil
 		case "enter", " ":
-			if numSettings == 5 {
-				switch m.selectedSetting {
-				case 0:
-					m.mode = nextMode(m.mode)
-					// If mode changes away from crazy, reset night mode and selection
-					if m.mode != state.ModeCrazy {
-						m.crazyNight = "never"
-					}
-				case 1:
-					m.crazyNight = nextCrazyNight(m.crazyNight)
-				case 2:
-					m.spriteSize = nextSpriteSize(m.spriteSize)
-				case 3:
-					// Toggle mute
-					m.mute = !m.mute
-				case 4:
-					m.reset = !m.reset
-				}
-			} else {
-				switch m.selectedSetting {
-				case 0:
-					m.mode = nextMode(m.mode)
-					// If mode changes away from crazy, reset night mode and selection
-					if m.mode != state.ModeCrazy {
-						m.crazyNight = "never"
-					}
-				case 1:
-					m.spriteSize = nextSpriteSize(m.spriteSize)
-				case 2:
-					// Toggle mute
-					m.mute = !m.mute
-				case 3:
-					m.reset = !m.reset
-				}
-
-			}
+			m.change(keys[m.selectedSetting])
 			m.soundManager.Play(sound.UI_CLICK)
 			return m, nil
 		}
 
+	case tea.MouseMsg:
+		return m.updateMouse(msg)
+	}
+	return m, nil
+}
+
+// updateMouse scrolls the options with the wheel, a click selects an option and a click on the selected one changes it.
+func (m Model) updateMouse(msg tea.MouseMsg) (Model, tea.Cmd) {
+	keys := m.optionKeys()
+	switch {
+	case msg.Button == tea.MouseButtonWheelUp:
+		m.selectedSetting = max(m.selectedSetting-1, 0)
+	case msg.Button == tea.MouseButtonWheelDown:
+		m.selectedSetting = min(m.selectedSetting+1, len(keys)-1)
+	case msg.Button == tea.MouseButtonLeft && msg.Action == tea.MouseActionPress:
+		i, ok := m.optionAt(msg.Y)
+		if !ok {
+			return m, nil
+		}
+		if i == m.selectedSetting {
+			m.change(keys[i])
+		}
+		m.selectedSetting = i
+		m.soundManager.Play(sound.UI_CLICK)
 	}
 	return m, nil
 }
 
+// optionAt returns the option shown on the screen row y.
+// The rows are found from the selected one, options take a row each.
+func (m Model) optionAt(y int) (int, bool) {
+	lines := strings.Split(m.View(), "\n")
+	for row, line := range lines {
+		if strings.Contains(ansi.Strip(line), "▶ ") {
+			i := m.selectedSetting + y - row
+			return i, i >= 0 && i < len(m.optionKeys())
+		}
+	}
+	return 0, false
+}
+
+// change changes the value of the option.
+func (m *Model) change(key int) {
+	switch key {
+	case selectedMode:
+		m.mode = nextMode(m.mode)
+		// If mode changes away from crazy, reset night mode and selection
+		if m.mode != state.ModeCrazy {
+			m.crazyNight = "never"
+		}
+	case selectedCrazyNight:
+		m.crazyNight = nextCrazyNight(m.crazyNight)
+	case selectedTurnBased:
+		m.turnBased = !m.turnBased
+	case selectedWeekly:
+		m.weekly = !m.weekly
+	case selectedRetro:
+		m.retro = !m.retro
+	case selectedSpeedBonus:
+		m.speedBonus = !m.speedBonus
+	case selectedExtraLives:
+		m.extraLives = nextChoice(livesChoices, m.extraLives)
+	case selectedLongFright:
+		m.longFright = !m.longFright
+	case selectedSpriteSize:
+		m.spriteSize = nextSpriteSize(m.spriteSize)
+	case selectedMute:
+		// Toggle mute
+		m.mute = !m.mute
+	case selectedBell:
+		m.bell = !m.bell
+	case selectedRepeat:
+		m.repeatMs = nextChoice(repeatChoices, m.repeatMs)
+	case selectedDebounce:
+		m.debounceMs = nextChoice(debounceChoices, m.debounceMs)
+	case selectedSticky:
+		m.sticky = nextChoice(stickyChoices, m.sticky)
+	case selectedInitials:
+		m.initials = !m.initials
+	case selectedUIScale:
+		m.uiScale = nextUIScale(m.uiScale)
+	case selectedMouse:
+		m.mouse = !m.mouse
+	case selectedSaver:
+		m.saverBelow = nextChoice(saverChoices, m.saverBelow)
+	case selectedSplash:
+		m.splash = nextSplash(m.splash)
+	case selectedTelemetry:
+		m.telemetry = !m.telemetry
+	case selectedUpdateCheck:
+		m.update = !m.update
+	case selectedTermTitle:
+		m.termTitle = !m.termTitle
+	case selectedReset:
+		m.reset = !m.reset
+	}
+}
+
+// optionKeys returns the options shown for the current mode in display order.
+func (m Model) optionKeys() []int {
+	keys := []int{selectedMode}
+	if m.mode == state.ModeCrazy {
+		keys = append(keys, selectedCrazyNight)
+	}
+	return append(keys, selectedTurnBased, selectedWeekly, selectedRetro, selectedSpeedBonus, selectedExtraLives, selectedLongFright, selectedSpriteSize, selectedMute, selectedBell, selectedRepeat, selectedDebounce, selectedSticky, selectedInitials, selectedUIScale, selectedMouse, selectedSaver, selectedSplash, selectedTelemetry, selectedUpdateCheck, selectedTermTitle, selectedReset)
+}
+
+// nextChoice returns the choice following the current one, unknown values start over.
+func nextChoice(choices []int, current int) int {
+	for i, c := range choices {
+		if c == current {
+			return choices[(i+1)%len(choices)]
+		}
+	}
+	return choices[0]
+}
+
 func nextMode(current string) string {
 	switch current {
 	case "easy":
@@ -215,10 +499,27 @@ func nextSpriteSize(current string) string {
 	}
 }
 
-const footer = "↑ ↓ — select, space — change, a — about, s — save, esc — cancel"
+func nextUIScale(current string) string {
+	if current == state.UIScaleLarge {
+		return state.UIScaleNormal
+	}
+	return state.UIScaleLarge
+}
+
+func nextSplash(current string) string {
+	if current == state.SplashAlways {
+		return state.SplashAuto
+	}
+	return state.SplashAlways
+}
+
+// footer takes three lines to fit the minimal terminal width
+const footer = "↑ ↓ — select, space — change, s — save, esc — cancel\n" +
+	"p — practice, v — versus, c — challenges, e — evidence, g — ghosts, a — about\n" +
+	"o — vault, l — audio latency, f — screenshots, ? — report issue"
 
 func (m Model) View() string {
-	return render.Page("Settings", m.renderOptions(), footer, m.width, m.height, m.termWidth, m.termHeight)
+	return render.Page(m.ui, "Settings", m.renderOptions(), footer, m.width, m.height, m.termWidth, m.termHeight)
 }
 
 func (m Model) renderOptions() string {
@@ -240,6 +541,22 @@ func (m Model) renderOptions() string {
 - always: permanent night — the basement won
 - real: follows your location — day, dusk, night, regret, repeat.`,
 
+		selectedTurnBased: `Time stands still until you move:
+every step you take, each ghost takes one too.
+Every step also costs a point — the shortest escape wins.`,
+
+		selectedWeekly: fmt.Sprintf(`Same twist for everyone until Monday: %s.
+Weekly runs have their own high scores, wiped when the week is over.`, mutator.Weekly(time.Now()).Names()),
+
+		selectedRetro: `Mazes mirrored left to right around a den in the middle,
+just like the arcade cabinet in the basement. Applies to new floors.`,
+
+		selectedSpeedBonus: m.handicapDescription(`Your legs remember the gym: ghosts step a quarter slower than usual.`),
+
+		selectedExtraLives: m.handicapDescription(`Spare souls in your pocket: start every run with extra lives.`),
+
+		selectedLongFright: m.handicapDescription(`Power pellets hit harder: ghosts stay frightened 15 seconds instead of 10.`),
+
 		selectedSpriteSize: `How big the horrors appear:
 - small: plausible deniability
 - medium: comfortably terrifying
@@ -248,32 +565,92 @@ func (m Model) renderOptions() string {
 		selectedMute: `Silence the datacenter… or at least pretend to.
 Ghosts don’t need speakers anyway.`,
 
+		selectedBell: `No speakers in the server room? Ring the terminal bell instead:
+once for a pellet or stairs, twice for a ghost eaten or the klaxon,
+three times for a life lost. Many terminals flash or buzz on it.`,
+
+		selectedRepeat: `How fast a key may repeat before it counts as cheating:
+holding a key down is autopilot, and ghosts hate autopilot.
+Raise it if your keyboard stutters.`,
+
+		selectedDebounce: `Ignores any key pressed too soon after the last one.
+Helps shaky hands — and shaky nerves — avoid double steps.`,
+
+		selectedSticky: `How many cells a single press walks you:
+- 1: one step at a time, like a sane person
+- more: keep walking until you bump into something.`,
+
+		selectedInitials: `Sign high scores like the arcade cabinet did: three letters,
+← → to pick the slot, ↑ ↓ to roll the letter. No keyboard gymnastics
+over SSH from a phone or on a kiosk joystick.`,
+
+		selectedUIScale: `How loud the screens shout at you:
+- normal: the usual glow of tired monitors
+- large: giant titles in stark black and white — no squinting allowed.`,
+
+		selectedMouse: `Scroll menus and the paused map with the wheel,
+click a setting to pick it and click it again to change it.
+Selecting text in the terminal may need Shift while it's on.`,
+
+		selectedSaver: `Go easy on a laptop running on battery:
+fewer frames, no music loops and a still splash screen
+once the charge drops to the threshold. Off keeps the full show.`,
+
+		selectedSplash: `The big haunteed and the ghost parade before every shift:
+- auto: skip it three times in a row and it stays skipped
+- always: the full show every time — the ghosts insist.`,
+
+		selectedTelemetry: `Keep anonymous stats — modes played, run lengths, crashes —
+in a local file. Nothing leaves the building unless you export it
+with --telemetry-export and share it yourself.`,
+
+		selectedUpdateCheck: `Ask GitHub once a day whether a fresher build escaped the lab.
+You'll get a quiet note in the footer; run "haunteed update" to let it in.`,
+
+		selectedTermTitle: `Put the floor and the score into the terminal title and tab.
+The boss key swaps it for something painfully dull.`,
+
 		selectedReset: `Erase your sins and start another night shift.
 Heads up — ghosts never forget.`,
 	}
 
 	// Build option list based on current mode
-	options := []option{{"Game mode", m.mode, selectedMode}}
-	if m.mode == state.ModeCrazy {
-		options = append(options, option{"Night shadows", m.crazyNight, selectedCrazyNight})
+	labels := map[int]option{
+		selectedMode:        {"Game mode", m.mode, selectedMode},
+		selectedCrazyNight:  {"Night shadows", m.crazyNight, selectedCrazyNight},
+		selectedTurnBased:   {"Puzzle (turn-based)", checkBox(m.turnBased), selectedTurnBased},
+		selectedWeekly:      {"Weekly modifiers", checkBox(m.weekly), selectedWeekly},
+		selectedRetro:       {"Retro layout", checkBox(m.retro), selectedRetro},
+		selectedSpeedBonus:  {"Handicap: speed", checkBox(m.speedBonus), selectedSpeedBonus},
+		selectedExtraLives:  {"Handicap: lives", livesValue(m.extraLives), selectedExtraLives},
+		selectedLongFright:  {"Handicap: fright", checkBox(m.longFright), selectedLongFright},
+		selectedSpriteSize:  {"Sprite size", m.spriteSize, selectedSpriteSize},
+		selectedMute:        {"Mute all sounds", checkBox(m.mute), selectedMute},
+		selectedBell:        {"Bell patterns", checkBox(m.bell), selectedBell},
+		selectedRepeat:      {"Repeat threshold", fmt.Sprintf("%d ms", m.repeatMs), selectedRepeat},
+		selectedDebounce:    {"Input debounce", msOrOff(m.debounceMs), selectedDebounce},
+		selectedSticky:      {"Sticky steps", stickyValue(m.sticky), selectedSticky},
+		selectedInitials:    {"Arcade initials", checkBox(m.initials), selectedInitials},
+		selectedUIScale:     {"UI scale", uiScaleValue(m.uiScale), selectedUIScale},
+		selectedMouse:       {"Mouse", checkBox(m.mouse), selectedMouse},
+		selectedSaver:       {"Energy saver", saverValue(m.saverBelow), selectedSaver},
+		selectedSplash:      {"Splash screen", m.splash, selectedSplash},
+		selectedTelemetry:   {"Local stats", checkBox(m.telemetry), selectedTelemetry},
+		selectedUpdateCheck: {"Check for updates", checkBox(m.update), selectedUpdateCheck},
+		selectedTermTitle:   {"Terminal title", checkBox(m.termTitle), selectedTermTitle},
+		selectedReset:       {"Reset progress", checkBox(m.reset), selectedReset},
+	}
+	var options []option
+	for _, key := range m.optionKeys() {
+		options = append(options, labels[key])
 	}
-	options = append(options,
-		option{"Sprite size", m.spriteSize, selectedSpriteSize},
-		option{"Mute all sounds", checkBox(m.mute), selectedMute},
-		option{"Reset progress", checkBox(m.reset), selectedReset},
-	)
 
-	// Calculate maximum label/value widths for aligned layout
+	// Calculate maximum label/value widths in terminal columns for aligned layout
 	maxLabel, maxValue := 10, 10
 	for _, row := range options {
-		if len(row.label) > maxLabel {
-			maxLabel = len(row.label)
-		}
-		if len(row.value) > maxValue {
-			maxValue = len(row.value)
-		}
+		maxLabel = max(maxLabel, lipgloss.Width(row.label))
+		maxValue = max(maxValue, lipgloss.Width(row.value))
 	}
-	format := fmt.Sprintf("%%-2s%%-%ds:%%%ds", maxLabel, maxValue+2)
 
 	var b strings.Builder
 
@@ -283,20 +660,17 @@ Heads up — ghosts never forget.`,
 		if i == m.selectedSetting {
 			prefix = "▶ "
 		}
-		line := fmt.Sprintf(format, prefix, opt.label, opt.value)
+		line := prefix + render.PadRight(opt.label, maxLabel) + ":" + render.PadLeft(opt.value, maxValue+2)
 		if i == m.selectedSetting {
-			b.WriteString(style.SetupItemSelected.Render(line))
+			b.WriteString(m.ui.SetupItemSelected.Render(line))
 		} else {
-			b.WriteString(style.SetupItem.Render(line))
+			b.WriteString(m.ui.SetupItem.Render(line))
 		}
 		b.WriteString("\n")
 	}
 
 	// Gap between options and description
-	gapLines := 3
-	if m.mode == state.ModeCrazy {
-		gapLines = 2
-	}
+	gapLines := 2 + maxOptions - len(options)
 	b.WriteString(strings.Repeat("\n", gapLines))
 
 	// Determine which option is currently selected and show its description
@@ -308,6 +682,8 @@ Heads up — ghosts never forget.`,
 		if err != nil {
 			// Network or lookup failed — fallback to Kansas City
 			desc = "Alert: No network detected.\nYou've been placed in the endless corn maze — Kansas City, MO (CST).\nFind your way out before your DNS expires."
+		} else if loc != nil && m.streamer {
+			desc = "The ghosts have found your datacenter.\nThey’ve synced their shifts with your sunrise — good luck escaping daylight savings."
 		} else if loc != nil {
 			// Successful lookup — replace description with a ghostly message
 			desc = fmt.Sprintf(
@@ -319,7 +695,7 @@ Heads up — ghosts never forget.`,
 	descLines := 0
 	if desc != "" {
 		// Simple ghostly style (gray italic text)
-		descText := style.SetupDescription.Render(desc)
+		descText := m.ui.SetupDescription.Render(desc)
 		b.WriteString(descText)
 		descLines = len(strings.Split(desc, "\n"))
 	}
@@ -343,6 +719,57 @@ Heads up — ghosts never forget.`,
 	return b.String()
 }
 
+// handicapDescription adds the score multiplier of the handicaps chosen to the description of a handicap.
+func (m Model) handicapDescription(desc string) string {
+	percent := score.Multiplier(m.speedBonus, m.extraLives, m.longFright)
+	return fmt.Sprintf("%s\nHandicaps help you finish a run, the final score is multiplied\nby %s with the ones chosen — high scores stay honest.", desc, score.FormatMultiplier(percent))
+}
+
+func livesValue(lives int) string {
+	if lives == 0 {
+		return "off"
+	}
+	return fmt.Sprintf("+%d", lives)
+}
+
+func uiScaleValue(scale string) string {
+	if scale == "" {
+		return state.UIScaleDefault
+	}
+	return scale
+}
+
+func splashValue(splash string) string {
+	if splash == "" {
+		return state.SplashDefault
+	}
+	return splash
+}
+
+func msOrOff(ms int) string {
+	if ms <= 0 {
+		return "off"
+	}
+	return fmt.Sprintf("%d ms", ms)
+}
+
+func stickyValue(cells int) string {
+	if cells <= 1 {
+		return "off"
+	}
+	return fmt.Sprintf("%d cells", cells)
+}
+
+func saverValue(below int) string {
+	switch {
+	case below <= 0:
+		return "off"
+	case below >= 100:
+		return "on battery"
+	}
+	return fmt.Sprintf("at %d%%", below)
+}
+
 func checkBox(value bool) string {
 	if value {
 		return "[▪]"
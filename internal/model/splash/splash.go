@@ -6,6 +6,7 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/mutator"
 	"github.com/vinser/haunteed/internal/render"
 	"github.com/vinser/haunteed/internal/state"
 	"github.com/vinser/haunteed/internal/style"
@@ -88,12 +89,22 @@ const (
 	spriteHeight = 7
 
 	middlePause      = 3 * time.Second
+	bonusSpacing     = 12 // columns between bonus dots
+	BonusPoints      = 10 // starting score for each bonus dot caught
 	moveTickDuration = 50 * time.Millisecond
 	chewTickDuration = 500 * time.Millisecond
 )
 
 var ghostSprites = []string{curly, lofty, fluffy, virty}
 
+// bonusRows are the offsets of the bonus dots from the dots line, taken in turn.
+var bonusRows = []int{-3, 2, -1, 3, -2, 1}
+
+type bonusDot struct {
+	x, y   int
+	caught bool
+}
+
 type movingGhost struct {
 	index      int
 	pos        int
@@ -108,11 +119,16 @@ type Model struct {
 	height     int
 	termWidth  int
 	termHeight int
+	ui         style.UI
 
 	pos        int
 	open       bool
+	still      bool // the energy saver skips the animation, see SetStill
 	pauseUntil time.Time
 	dots       []bool
+	lift       int        // rows the haunteed is nudged up (negative) or down from the middle
+	bonus      []bonusDot // bonus dots off the dots line, caught by nudging the haunteed
+	caught     int
 
 	ghostIndex    int
 	ghostPos      int
@@ -149,15 +165,18 @@ func makeSettingsCmd() tea.Cmd {
 	}
 }
 
-type TimedoutMsg struct{}
+// TimedoutMsg ends the splash, Skipped is set if the player cut it short.
+type TimedoutMsg struct {
+	Skipped bool
+}
 
-func timedoutCmd() tea.Cmd {
+func timedoutCmd(skipped bool) tea.Cmd {
 	return func() tea.Msg {
-		return TimedoutMsg{}
+		return TimedoutMsg{Skipped: skipped}
 	}
 }
 
-func New(state *state.State, width, height int) Model {
+func New(state *state.State, ui style.UI, width, height int) Model {
 	width = max(width, lipgloss.Width(footer))
 	dots := make([]bool, width)
 	for i := range dots {
@@ -174,13 +193,24 @@ func New(state *state.State, width, height int) Model {
 		}
 	}
 
+	dotY := (height-spriteHeight)/2 + spriteHeight/2 + 1
+	var bonus []bonusDot
+	for i, x := 0, bonusSpacing+2; x < width; i, x = i+1, x+bonusSpacing {
+		y := dotY + bonusRows[i%len(bonusRows)]
+		if y >= 0 && y < height {
+			bonus = append(bonus, bonusDot{x: x, y: y})
+		}
+	}
+
 	return Model{
 		state:          state,
+		ui:             ui,
 		width:          width,
 		height:         height,
 		pos:            -spriteWidth,
 		open:           true,
 		dots:           dots,
+		bonus:          bonus,
 		grid:           grid,
 		ghostColorGrid: ghostColorGrid,
 		sb:             &strings.Builder{},
@@ -193,7 +223,29 @@ func (m *Model) SetSize(width, height int) {
 	m.termHeight = height
 }
 
+// SetStill parks the haunteed in the middle of the screen instead of animating the splash.
+func (m *Model) SetStill(still bool) {
+	m.still = still
+	if !still {
+		return
+	}
+	m.pos = m.width/2 - spriteWidth/2
+	mouthPos := m.pos + spriteWidth - 1
+	for i := 0; i <= mouthPos && i < len(m.dots); i++ {
+		m.dots[i] = false
+	}
+	m.bonus = nil
+}
+
+// Bonus returns the starting score earned by the bonus dots caught on the splash.
+func (m Model) Bonus() int {
+	return m.caught * BonusPoints
+}
+
 func (m Model) Init() tea.Cmd {
+	if m.still {
+		return nil
+	}
 	return tea.Batch(moveCmd(), chewCmd())
 }
 
@@ -214,7 +266,11 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 		case "s":
 			return m, makeSettingsCmd()
 		case "enter", "esc", " ":
-			return m, timedoutCmd()
+			return m, timedoutCmd(true)
+		case "up":
+			m.nudge(-1)
+		case "down":
+			m.nudge(1)
 		}
 	}
 	return m, nil
@@ -222,6 +278,30 @@ func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
 
 // --- Sub-functions for Update ---
 
+// nudge moves the haunteed up or down a row while it crosses the screen, keeping it on the screen.
+func (m *Model) nudge(dy int) {
+	if m.still || m.showGhosts {
+		return
+	}
+	// The sprite lines start one row below spriteY, see drawHaunteed
+	spriteY := (m.height - spriteHeight) / 2
+	m.lift = min(max(m.lift+dy, -spriteY-1), m.height-spriteHeight-spriteY-1)
+	m.eatBonus()
+}
+
+// eatBonus catches the bonus dots at the haunteed's mouth, the mouth spans the three middle rows of the sprite.
+func (m *Model) eatBonus() {
+	mouthPos := m.pos + spriteWidth - 1
+	mouthY := (m.height-spriteHeight)/2 + m.lift + spriteHeight/2 + 1
+	for i := range m.bonus {
+		b := &m.bonus[i]
+		if !b.caught && b.x == mouthPos && b.y >= mouthY-1 && b.y <= mouthY+1 {
+			b.caught = true
+			m.caught++
+		}
+	}
+}
+
 func (m Model) updateHaunteed() (Model, tea.Cmd) {
 	now := time.Now()
 	if !m.pauseUntil.IsZero() {
@@ -236,6 +316,7 @@ func (m Model) updateHaunteed() (Model, tea.Cmd) {
 		if mouthPos >= 0 && mouthPos < len(m.dots) {
 			m.dots[mouthPos] = false
 		}
+		m.eatBonus()
 		return m, moveCmd()
 	}
 	if m.pos == (m.width/2 - spriteWidth/2) {
@@ -247,6 +328,7 @@ func (m Model) updateHaunteed() (Model, tea.Cmd) {
 	if mouthPos >= 0 && mouthPos < len(m.dots) {
 		m.dots[mouthPos] = false
 	}
+	m.eatBonus()
 	if m.pos > m.width {
 		m.showGhosts = true
 		m.ghostIndex = -1
@@ -299,7 +381,7 @@ func (m Model) updateGhosts() (Model, tea.Cmd) {
 
 	// If all ghosts have exited, finish splash
 	if len(m.movingGhosts) == 0 && m.ghostsStarted == len(ghostSprites) {
-		return m, timedoutCmd()
+		return m, timedoutCmd(false)
 	}
 
 	// Mark ghostIndex for coloring in View
@@ -311,11 +393,14 @@ func (m Model) updateGhosts() (Model, tea.Cmd) {
 }
 
 // --- View ---
-const footer = `s — settings, m — mute, space — skip, q — quit`
+const footer = `↑↓ — catch bonus, s — settings, m — mute, space — skip, q — quit`
 
 func (m Model) View() string {
 	m.clearGrid()
 	m.drawDots()
+	if !m.showGhosts {
+		m.drawBonus()
+	}
 	if m.showGhosts && len(m.movingGhosts) > 0 {
 		for _, g := range m.movingGhosts {
 			m.drawGhost(g.index, g.pos)
@@ -324,7 +409,9 @@ func (m Model) View() string {
 		m.drawHaunteed()
 	}
 	view := m.renderGrid()
-	return render.Page("", view, footer, m.width, m.height, m.termWidth, m.termHeight)
+	// Tease the modifiers of the week
+	title := "This week: " + mutator.Weekly(time.Now()).Names()
+	return render.Page(m.ui, title, view, footer, m.width, m.height, m.termWidth, m.termHeight)
 }
 
 func (m *Model) clearGrid() {
@@ -379,6 +466,14 @@ func (m *Model) drawDots() {
 	}
 }
 
+func (m *Model) drawBonus() {
+	for _, b := range m.bonus {
+		if !b.caught && b.x < m.width {
+			m.grid[b.y][b.x] = '◆'
+		}
+	}
+}
+
 func (m *Model) drawGhost(ghostIdx, ghostPos int) {
 	spriteY := m.ghostY(ghostIdx, ghostPos)
 	spriteLines := strings.Split(ghostSprites[ghostIdx], "\n")
@@ -398,7 +493,7 @@ func (m *Model) drawGhost(ghostIdx, ghostPos int) {
 }
 
 func (m *Model) drawHaunteed() {
-	spriteY := (m.height - spriteHeight) / 2
+	spriteY := (m.height-spriteHeight)/2 + m.lift
 	var spriteLines []string
 	switch {
 	case !m.pauseUntil.IsZero():
@@ -429,6 +524,8 @@ func (m *Model) renderGrid() string {
 			switch r {
 			case '●':
 				m.sb.WriteString(style.SplashDot.Render(string(r)))
+			case '◆':
+				m.sb.WriteString(style.SplashBonus.Render(string(r)))
 			case ' ', 0:
 				m.sb.WriteRune(' ')
 			default:
@@ -444,3 +541,45 @@ func (m *Model) renderGrid() string {
 	}
 	return m.sb.String()
 }
+
+// Parade renders the splash ghosts marching in a row across width columns, frame moves them one column on.
+func Parade(width, frame int) string {
+	const spacing = spriteWidth + 2
+	cycle := width + len(ghostSprites)*spacing
+	grid := make([][]rune, spriteHeight)
+	colors := make([][]int, spriteHeight)
+	for y := range grid {
+		grid[y] = []rune(strings.Repeat(" ", width))
+		colors[y] = make([]int, width)
+	}
+	for i, sprite := range ghostSprites {
+		// Curly leads, each ghost wraps around to the back once it is off screen
+		lag := (len(ghostSprites) - 1) * spacing
+		pos := (frame+lag-i*spacing)%cycle - lag
+		for y, line := range strings.Split(strings.Trim(sprite, "\n"), "\n") {
+			if y >= spriteHeight {
+				break
+			}
+			for x, r := range []rune(line) {
+				if sx := pos + x; sx >= 0 && sx < width && r != ' ' {
+					grid[y][sx] = r
+					colors[y][sx] = i
+				}
+			}
+		}
+	}
+	var sb strings.Builder
+	for y, row := range grid {
+		for x, r := range row {
+			if r == ' ' {
+				sb.WriteRune(' ')
+				continue
+			}
+			sb.WriteString(style.SplashGhosts[colors[y][x]%len(style.SplashGhosts)].Render(string(r)))
+		}
+		if y < len(grid)-1 {
+			sb.WriteRune('\n')
+		}
+	}
+	return sb.String()
+}
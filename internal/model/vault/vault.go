@@ -0,0 +1,144 @@
+package vault
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/cosmetic"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/state"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	state    *state.State
+	selected int
+	note     string // outcome of the last purchase
+
+	soundManager *sound.Manager
+}
+
+// CloseVaultMsg is a message sent when the player leaves the vault.
+type CloseVaultMsg struct{}
+
+func closeVaultCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseVaultMsg{}
+	}
+}
+
+// New returns the vault where ectoplasm buys looks, bought looks are worn and taken off here too.
+// Purchases change st, the caller saves it.
+func New(st *state.State, ui style.UI, width, height int, sm *sound.Manager) Model {
+	return Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(footer)),
+		height:       height,
+		state:        st,
+		soundManager: sm,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "esc":
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, closeVaultCmd()
+		case "up":
+			if m.selected > 0 {
+				m.selected--
+			}
+			m.note = ""
+			m.soundManager.Play(sound.UI_CLICK)
+		case "down":
+			if m.selected < len(cosmetic.Items)-1 {
+				m.selected++
+			}
+			m.note = ""
+			m.soundManager.Play(sound.UI_CLICK)
+		case "enter", " ":
+			m.take(cosmetic.Items[m.selected])
+		}
+	}
+	return m, nil
+}
+
+// take buys the item, or wears and takes it off if it is owned already.
+func (m *Model) take(it cosmetic.Item) {
+	err := cosmetic.Buy(m.state, it)
+	switch {
+	case err == nil:
+		m.note = "Bought " + it.Name
+		m.soundManager.Play(sound.UI_SAVE)
+	case errors.Is(err, cosmetic.ErrOwned):
+		cosmetic.Wear(m.state, it)
+		m.note = ""
+		m.soundManager.Play(sound.UI_CLICK)
+	default:
+		m.note = fmt.Sprintf("%d more ectoplasm to go", it.Price-m.state.Ectoplasm)
+		m.soundManager.Play(sound.UI_CANCEL)
+	}
+}
+
+const footer = "↑ ↓ — select, space — buy or wear, esc — back"
+
+func (m Model) View() string {
+	return render.Page(m.ui, "Vault", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf("Ectoplasm: %d\n\n", m.state.Ectoplasm))
+	nameWidth := 0
+	for _, it := range cosmetic.Items {
+		nameWidth = max(nameWidth, lipgloss.Width(it.Name))
+	}
+	for i, it := range cosmetic.Items {
+		prefix := "  "
+		if i == m.selected {
+			prefix = "▶ "
+		}
+		value := fmt.Sprintf("%d", it.Price)
+		switch {
+		case cosmetic.Worn(m.state, it.ID):
+			value = "worn"
+		case cosmetic.Owned(m.state, it.ID):
+			value = "owned"
+		}
+		line := prefix + render.PadRight(it.Name, nameWidth) + render.PadLeft(value, 8)
+		if i == m.selected {
+			b.WriteString(m.ui.SetupItemSelected.Render(line))
+		} else {
+			b.WriteString(m.ui.SetupItem.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	if m.note != "" {
+		b.WriteString(m.ui.SetupDescription.Render(m.note))
+	} else {
+		b.WriteString(m.ui.SetupDescription.Render("Every run leaves some ectoplasm behind, the deeper the more.\nLooks change colors only, scores stay fair."))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
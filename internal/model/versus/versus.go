@@ -0,0 +1,157 @@
+package versus
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/vinser/haunteed/internal/dweller"
+	"github.com/vinser/haunteed/internal/render"
+	"github.com/vinser/haunteed/internal/sound"
+	"github.com/vinser/haunteed/internal/style"
+)
+
+// RoundTime is how long the haunteed has to survive to win a round
+const RoundTime = 90 * time.Second
+
+// Scoreboard holds versus results of the session
+type Scoreboard struct {
+	HaunteedWins int           // Rounds survived
+	GhostWins    int           // Captures
+	Longest      time.Duration // Longest time the haunteed held out before a capture
+	Last         string        // Result of the last round
+}
+
+// Record adds a finished round to the scoreboard.
+func (b *Scoreboard) Record(ghost dweller.GhostType, captured bool, survived time.Duration) {
+	survived = survived.Round(time.Second)
+	if !captured {
+		b.HaunteedWins++
+		b.Last = fmt.Sprintf("The haunteed survived %s — %s goes hungry.", clock(survived), ghost)
+		return
+	}
+	b.GhostWins++
+	b.Longest = max(b.Longest, survived)
+	b.Last = fmt.Sprintf("%s caught the haunteed after %s.", ghost, clock(survived))
+}
+
+type Model struct {
+	width      int
+	height     int
+	termWidth  int
+	termHeight int
+	ui         style.UI
+
+	selected   int
+	scoreboard *Scoreboard
+
+	soundManager *sound.Manager
+}
+
+// StartVersusMsg is a message sent when player two picks a ghost to control.
+type StartVersusMsg struct {
+	Ghost dweller.GhostType
+}
+
+func startVersusCmd(ghost dweller.GhostType) tea.Cmd {
+	return func() tea.Msg {
+		return StartVersusMsg{Ghost: ghost}
+	}
+}
+
+// CloseVersusMsg is a message sent when the players leave the versus screen.
+type CloseVersusMsg struct{}
+
+func closeVersusCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CloseVersusMsg{}
+	}
+}
+
+func New(board *Scoreboard, ghost dweller.GhostType, ui style.UI, width, height int, sm *sound.Manager) Model {
+	return Model{
+		ui:           ui,
+		width:        max(width, lipgloss.Width(footer)),
+		height:       height,
+		selected:     int(ghost),
+		scoreboard:   board,
+		soundManager: sm,
+	}
+}
+
+func (m *Model) SetSize(width, height int) {
+	m.termWidth = width
+	m.termHeight = height
+}
+
+func (m Model) Init() tea.Cmd {
+	return nil
+}
+
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "esc":
+			m.soundManager.Play(sound.UI_CANCEL)
+			return m, closeVersusCmd()
+		case "up":
+			if m.selected > 0 {
+				m.selected--
+			}
+			m.soundManager.Play(sound.UI_CLICK)
+		case "down":
+			if m.selected < len(dweller.GhostNames)-1 {
+				m.selected++
+			}
+			m.soundManager.Play(sound.UI_CLICK)
+		case "enter", " ":
+			m.soundManager.Play(sound.UI_SAVE)
+			return m, startVersusCmd(dweller.GhostType(m.selected))
+		}
+	}
+	return m, nil
+}
+
+const footer = "↑ ↓ — select ghost, enter — start round, esc — back"
+
+func (m Model) View() string {
+	return render.Page(m.ui, "Versus", m.renderContent(), footer, m.width, m.height, m.termWidth, m.termHeight)
+}
+
+func (m Model) renderContent() string {
+	var b strings.Builder
+	b.WriteString("Hot seat: player one moves the haunteed with arrows,\n")
+	b.WriteString("player two steers a ghost with w a s d, the others hunt on their own.\n")
+	b.WriteString(fmt.Sprintf("The haunteed wins by holding out for %s.\n\n", clock(RoundTime)))
+	for i, name := range dweller.GhostNames {
+		prefix := "  "
+		if i == m.selected {
+			prefix = "▶ "
+		}
+		line := fmt.Sprintf("%sPlay as %s", prefix, name)
+		if i == m.selected {
+			b.WriteString(m.ui.SetupItemSelected.Render(line))
+		} else {
+			b.WriteString(m.ui.SetupItem.Render(line))
+		}
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("Haunteed %d : %d Ghosts", m.scoreboard.HaunteedWins, m.scoreboard.GhostWins))
+	if m.scoreboard.Longest > 0 {
+		b.WriteString(fmt.Sprintf("   Longest escape before capture: %s", clock(m.scoreboard.Longest)))
+	}
+	b.WriteString("\n")
+	b.WriteString(m.ui.SetupDescription.Render(m.scoreboard.Last))
+	b.WriteString("\n")
+	return b.String()
+}
+
+// clock formats a duration as minutes and seconds
+func clock(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
@@ -0,0 +1,296 @@
+// Package mutator provides floor modifiers that tweak the rules of play.
+// Every mutator implements Mutator and any number of optional hook interfaces,
+// a Set composes the hooks of all its mutators.
+package mutator
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+)
+
+// Mutator is a named floor modifier.
+type Mutator interface {
+	Name() string
+	Description() string
+}
+
+// StepHook lets a mutator change how far the haunteed moves per key press.
+type StepHook interface {
+	ExtraSteps() int
+}
+
+// NoiseHook lets a mutator change how loud the haunteed is and how ghosts react to it.
+type NoiseHook interface {
+	StepVolume(db float64) float64
+	GhostsHearSteps() bool
+}
+
+// VisibilityHook lets a mutator change the visibility radius over time.
+type VisibilityHook interface {
+	Visibility(radius int, now time.Time) int
+}
+
+// PelletHook lets a mutator take the power pellets off the floor.
+type PelletHook interface {
+	NoPellets() bool
+}
+
+// RushHook lets a mutator release all ghosts at once.
+type RushHook interface {
+	GhostRush() bool
+}
+
+// ScentHook lets a mutator make the ghosts track the haunteed by the trail it leaves.
+type ScentHook interface {
+	GhostsFollowScent() bool
+}
+
+// SurroundHook lets a mutator make the chasing ghosts hunt together instead of each on its own.
+type SurroundHook interface {
+	GhostsSurround() bool
+}
+
+// SpeedHook lets a mutator change how often the ghosts move.
+type SpeedHook interface {
+	GhostTick(d time.Duration) time.Duration
+}
+
+// Slippery floors make every move continue one extra cell.
+type Slippery struct{}
+
+func (Slippery) Name() string        { return "Slippery floors" }
+func (Slippery) Description() string { return "moves continue one extra cell" }
+func (Slippery) ExtraSteps() int     { return 1 }
+
+// Echoing halls amplify footsteps, ghosts hear them and skip scattering.
+type Echoing struct{}
+
+func (Echoing) Name() string                  { return "Echoing halls" }
+func (Echoing) Description() string           { return "ghosts hear every step" }
+func (Echoing) StepVolume(db float64) float64 { return db + 3 }
+func (Echoing) GhostsHearSteps() bool         { return true }
+
+// Brownout makes the lights pulse, periodically shrinking the visibility radius.
+type Brownout struct{}
+
+const (
+	brownoutPeriod = 8 * time.Second
+	brownoutDim    = 2 * time.Second
+	brownoutRadius = 3
+)
+
+func (Brownout) Name() string        { return "Brownout" }
+func (Brownout) Description() string { return "the lights flicker and fade" }
+func (Brownout) Visibility(radius int, now time.Time) int {
+	if time.Duration(now.UnixNano())%brownoutPeriod < brownoutDim {
+		return min(radius, brownoutRadius)
+	}
+	return radius
+}
+
+// Bloodhounds makes the chasing ghosts sniff out the haunteed's trail instead of heading straight for it.
+type Bloodhounds struct{}
+
+func (Bloodhounds) Name() string            { return "Bloodhounds" }
+func (Bloodhounds) Description() string     { return "ghosts follow your footsteps" }
+func (Bloodhounds) GhostsFollowScent() bool { return true }
+
+// scentChance is the chance that a floor with room for one more mutator gets Bloodhounds.
+// It is drawn after the other picks, so floors of earlier versions keep their mutators.
+const scentChance = 0.15
+
+// GhostKing crowns a king every kingPeriod floors, the ghosts surround the haunteed on his orders.
+type GhostKing struct{}
+
+// kingPeriod is the number of floors between ghost king floors.
+const kingPeriod = 10
+
+func (GhostKing) Name() string         { return "Ghost king" }
+func (GhostKing) Description() string  { return "the ghosts hunt as one and cut off every way out" }
+func (GhostKing) GhostsSurround() bool { return true }
+
+// All lists the mutators that may be assigned to a floor.
+var All = []Mutator{Slippery{}, Echoing{}, Brownout{}}
+
+// Blackout keeps the floor dark whatever the fuses say.
+type Blackout struct{}
+
+const blackoutRadius = 3
+
+func (Blackout) Name() string                           { return "Blackout" }
+func (Blackout) Description() string                    { return "no light at all" }
+func (Blackout) Visibility(radius int, _ time.Time) int { return min(radius, blackoutRadius) }
+
+// NoPellets takes all the power pellets away.
+type NoPellets struct{}
+
+func (NoPellets) Name() string        { return "No pellets" }
+func (NoPellets) Description() string { return "nothing to fight back with" }
+func (NoPellets) NoPellets() bool     { return true }
+
+// GhostRush sends all the ghosts out at once and faster.
+type GhostRush struct{}
+
+func (GhostRush) Name() string                            { return "Ghost rush" }
+func (GhostRush) Description() string                     { return "everyone is out, and in a hurry" }
+func (GhostRush) GhostRush() bool                         { return true }
+func (GhostRush) GhostTick(d time.Duration) time.Duration { return d * 2 / 3 }
+
+// FastGhosts speeds the ghosts up.
+type FastGhosts struct{}
+
+func (FastGhosts) Name() string                            { return "Fast ghosts" }
+func (FastGhosts) Description() string                     { return "the ghosts had too much coffee" }
+func (FastGhosts) GhostTick(d time.Duration) time.Duration { return d * 3 / 4 }
+
+// weeklyPool holds the mutators the weekly modifiers are picked from.
+var weeklyPool = []Mutator{Slippery{}, Echoing{}, Brownout{}, FastGhosts{}}
+
+// weeklyCount is the number of weekly modifiers.
+const weeklyCount = 2
+
+// Weekly returns the modifiers of the ISO week of t, everyone plays the same ones during the week.
+func Weekly(t time.Time) Set {
+	year, week := t.ISOWeek()
+	rng := rand.New(rand.NewSource(int64(year)*100 + int64(week)))
+	var set Set
+	for _, i := range rng.Perm(len(weeklyPool))[:weeklyCount] {
+		set = append(set, weeklyPool[i])
+	}
+	return set
+}
+
+// Rules are the challenge-only mutators by rule name, they are never assigned to floors at random.
+var Rules = map[string]Mutator{
+	"blackout":   Blackout{},
+	"no-pellets": NoPellets{},
+	"ghost-rush": GhostRush{},
+}
+
+// maxPerFloor is the maximum number of mutators assigned to a floor.
+const maxPerFloor = 2
+
+// Set is a composition of mutators applied to a floor.
+type Set []Mutator
+
+// ForFloor picks 0 to 2 distinct mutators for the floor using its seed, every kingPeriod floors the ghost king joins them.
+// Floor 0 is always left unmodified.
+func ForFloor(index int, seed int64) Set {
+	if index == 0 {
+		return nil
+	}
+	rng := rand.New(rand.NewSource(seed ^ 0x5EED))
+	count := rng.Intn(maxPerFloor + 1)
+	var set Set
+	for _, i := range rng.Perm(len(All))[:count] {
+		set = append(set, All[i])
+	}
+	if count < maxPerFloor && rng.Float64() < scentChance {
+		set = append(set, Bloodhounds{})
+	}
+	if index%kingPeriod == 0 {
+		set = append(set, GhostKing{}) // An event on top of the picks
+	}
+	return set
+}
+
+// Names returns the mutator names joined for display, e.g. "Brownout + Slippery floors".
+func (s Set) Names() string {
+	var names []string
+	for _, m := range s {
+		names = append(names, m.Name())
+	}
+	return strings.Join(names, " + ")
+}
+
+// ExtraSteps sums the extra steps of all step hooks.
+func (s Set) ExtraSteps() int {
+	steps := 0
+	for _, m := range s {
+		if h, ok := m.(StepHook); ok {
+			steps += h.ExtraSteps()
+		}
+	}
+	return steps
+}
+
+// StepVolume applies all noise hooks to the step volume.
+func (s Set) StepVolume(db float64) float64 {
+	for _, m := range s {
+		if h, ok := m.(NoiseHook); ok {
+			db = h.StepVolume(db)
+		}
+	}
+	return db
+}
+
+// GhostsHearSteps returns true if any noise hook lets ghosts hear the haunteed.
+func (s Set) GhostsHearSteps() bool {
+	for _, m := range s {
+		if h, ok := m.(NoiseHook); ok && h.GhostsHearSteps() {
+			return true
+		}
+	}
+	return false
+}
+
+// Visibility applies all visibility hooks to the radius.
+func (s Set) Visibility(radius int, now time.Time) int {
+	for _, m := range s {
+		if h, ok := m.(VisibilityHook); ok {
+			radius = h.Visibility(radius, now)
+		}
+	}
+	return radius
+}
+
+// NoPellets returns true if any pellet hook takes the power pellets away.
+func (s Set) NoPellets() bool {
+	for _, m := range s {
+		if h, ok := m.(PelletHook); ok && h.NoPellets() {
+			return true
+		}
+	}
+	return false
+}
+
+// GhostRush returns true if any rush hook sends the ghosts out at once.
+func (s Set) GhostRush() bool {
+	for _, m := range s {
+		if h, ok := m.(RushHook); ok && h.GhostRush() {
+			return true
+		}
+	}
+	return false
+}
+
+// GhostsFollowScent returns true if any scent hook makes the ghosts follow the haunteed's trail.
+func (s Set) GhostsFollowScent() bool {
+	for _, m := range s {
+		if h, ok := m.(ScentHook); ok && h.GhostsFollowScent() {
+			return true
+		}
+	}
+	return false
+}
+
+// GhostsSurround returns true if any surround hook makes the chasing ghosts hunt together.
+func (s Set) GhostsSurround() bool {
+	for _, m := range s {
+		if h, ok := m.(SurroundHook); ok && h.GhostsSurround() {
+			return true
+		}
+	}
+	return false
+}
+
+// GhostTick applies all speed hooks to the ghost tick interval.
+func (s Set) GhostTick(d time.Duration) time.Duration {
+	for _, m := range s {
+		if h, ok := m.(SpeedHook); ok {
+			d = h.GhostTick(d)
+		}
+	}
+	return d
+}
@@ -0,0 +1,128 @@
+package mutator
+
+import (
+	"testing"
+	"time"
+)
+
+func TestForFloor(t *testing.T) {
+	if set := ForFloor(0, 42); len(set) != 0 {
+		t.Errorf("ForFloor(0) = %v, want no mutators", set.Names())
+	}
+	scent := 0
+	for seed := int64(1); seed < 100; seed++ {
+		set := ForFloor(1, seed)
+		if set.GhostsFollowScent() {
+			scent++
+		}
+		if len(set) > maxPerFloor {
+			t.Fatalf("ForFloor(1, %d) has %d mutators, want at most %d", seed, len(set), maxPerFloor)
+		}
+		if again := ForFloor(1, seed); again.Names() != set.Names() {
+			t.Fatalf("ForFloor(1, %d) is not deterministic: %q vs %q", seed, set.Names(), again.Names())
+		}
+		seen := make(map[string]bool)
+		for _, m := range set {
+			if seen[m.Name()] {
+				t.Fatalf("ForFloor(1, %d) has duplicate %q", seed, m.Name())
+			}
+			seen[m.Name()] = true
+		}
+	}
+	if scent == 0 {
+		t.Error("no floor has ghosts following the scent")
+	}
+	for index, want := range map[int]bool{1: false, 9: false, 10: true, 20: true, 25: false} {
+		if got := ForFloor(index, 42).GhostsSurround(); got != want {
+			t.Errorf("ForFloor(%d) has the ghost king %v, want %v", index, got, want)
+		}
+	}
+}
+
+func TestSetHooks(t *testing.T) {
+	dim := time.Unix(0, 0)
+	lit := time.Unix(0, int64(brownoutDim))
+	tests := []struct {
+		name       string
+		set        Set
+		extraSteps int
+		volume     float64
+		hear       bool
+		dimRadius  int
+		litRadius  int
+	}{
+		{"empty", nil, 0, 0, false, 10, 10},
+		{"slippery", Set{Slippery{}}, 1, 0, false, 10, 10},
+		{"echoing", Set{Echoing{}}, 0, 3, true, 10, 10},
+		{"brownout", Set{Brownout{}}, 0, 0, false, brownoutRadius, 10},
+		{"all", Set{Slippery{}, Echoing{}, Brownout{}}, 1, 3, true, brownoutRadius, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.set.ExtraSteps(); got != tt.extraSteps {
+				t.Errorf("ExtraSteps() = %d, want %d", got, tt.extraSteps)
+			}
+			if got := tt.set.StepVolume(0); got != tt.volume {
+				t.Errorf("StepVolume(0) = %v, want %v", got, tt.volume)
+			}
+			if got := tt.set.GhostsHearSteps(); got != tt.hear {
+				t.Errorf("GhostsHearSteps() = %v, want %v", got, tt.hear)
+			}
+			if got := tt.set.Visibility(10, dim); got != tt.dimRadius {
+				t.Errorf("Visibility(10, dim) = %d, want %d", got, tt.dimRadius)
+			}
+			if got := tt.set.Visibility(10, lit); got != tt.litRadius {
+				t.Errorf("Visibility(10, lit) = %d, want %d", got, tt.litRadius)
+			}
+		})
+	}
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		set       Set
+		noPellets bool
+		rush      bool
+		radius    int
+	}{
+		{"empty", nil, false, false, 10},
+		{"blackout", Set{Rules["blackout"]}, false, false, blackoutRadius},
+		{"no pellets", Set{Rules["no-pellets"]}, true, false, 10},
+		{"ghost rush", Set{Rules["ghost-rush"]}, false, true, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.set.NoPellets(); got != tt.noPellets {
+				t.Errorf("NoPellets() = %v, want %v", got, tt.noPellets)
+			}
+			if got := tt.set.GhostRush(); got != tt.rush {
+				t.Errorf("GhostRush() = %v, want %v", got, tt.rush)
+			}
+			if got := tt.set.Visibility(10, time.Unix(0, int64(brownoutDim))); got != tt.radius {
+				t.Errorf("Visibility(10) = %d, want %d", got, tt.radius)
+			}
+		})
+	}
+	for _, m := range Rules {
+		for _, random := range All {
+			if m.Name() == random.Name() {
+				t.Errorf("challenge rule %q may be assigned to floors at random", m.Name())
+			}
+		}
+	}
+}
+
+func TestWeekly(t *testing.T) {
+	monday := time.Date(2026, time.October, 12, 0, 0, 0, 0, time.UTC)
+	set := Weekly(monday)
+	if len(set) != weeklyCount {
+		t.Fatalf("Weekly() has %d mutators, want %d", len(set), weeklyCount)
+	}
+	if sunday := Weekly(monday.AddDate(0, 0, 6)); sunday.Names() != set.Names() {
+		t.Errorf("Weekly() changed within the week: %q vs %q", set.Names(), sunday.Names())
+	}
+	if got := (Set{FastGhosts{}}).GhostTick(100 * time.Millisecond); got != 75*time.Millisecond {
+		t.Errorf("GhostTick() = %v, want 75ms", got)
+	}
+}
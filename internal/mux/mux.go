@@ -0,0 +1,130 @@
+// Package mux makes the escape sequences of the game survive terminal multiplexers: tmux and GNU screen
+// swallow clipboard and title sequences meant for the real terminal and may draw a pane smaller than
+// the terminal reports.
+package mux
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Kind is a terminal multiplexer.
+type Kind int
+
+const (
+	None Kind = iota
+	Tmux
+	Screen
+)
+
+// String returns the multiplexer name.
+func (k Kind) String() string {
+	switch k {
+	case Tmux:
+		return "tmux"
+	case Screen:
+		return "screen"
+	}
+	return "none"
+}
+
+// Detect returns the multiplexer the game runs in, from the variables they set for their panes.
+func Detect() Kind {
+	switch {
+	case os.Getenv("TMUX") != "":
+		return Tmux
+	case os.Getenv("STY") != "":
+		return Screen
+	}
+	return None
+}
+
+// screenChunk is the longest string GNU screen passes through in one go
+const screenChunk = 768
+
+// Passthrough wraps the escape sequence so the multiplexer hands it to the real terminal unchanged,
+// outside of one it is returned as is. tmux needs allow-passthrough on for that.
+func Passthrough(seq string) string {
+	switch Detect() {
+	case Tmux:
+		return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+	case Screen:
+		var b strings.Builder
+		for len(seq) > 0 {
+			n := min(len(seq), screenChunk)
+			b.WriteString("\x1bP" + seq[:n] + "\x1b\\")
+			seq = seq[n:]
+		}
+		return b.String()
+	}
+	return seq
+}
+
+// Title returns the escape sequences that set the terminal title. In a multiplexer the title names
+// the window, the way both tmux and screen take it, and is also passed through to the real terminal.
+func Title(title string) string {
+	osc := titleStart + title + titleEnd
+	if Detect() == None {
+		return osc
+	}
+	return "\x1bk" + title + "\x1b\\" + Passthrough(osc)
+}
+
+const (
+	titleStart = "\x1b]2;"
+	titleEnd   = "\a"
+)
+
+// Terminal is what the program writes to: the TTY itself or a writer passing output on to it.
+type Terminal interface {
+	io.ReadWriteCloser
+	Fd() uintptr
+}
+
+// Output passes output on to the terminal and turns the title sequences in it into the ones Title
+// returns, so titles set by the program reach the real terminal in a multiplexer as well.
+// It keeps the terminal's Fd so the program still sees a TTY it can size and put in raw mode.
+type Output struct {
+	Terminal
+}
+
+// NewOutput wraps the terminal the program writes to.
+func NewOutput(t Terminal) *Output {
+	return &Output{t}
+}
+
+// Write writes p to the terminal. A title sequence is only recognized when written on its own,
+// the way the program sets the title.
+func (o *Output) Write(p []byte) (int, error) {
+	title, ok := bytes.CutPrefix(p, []byte(titleStart))
+	if ok {
+		title, ok = bytes.CutSuffix(title, []byte(titleEnd))
+	}
+	if !ok || Detect() == None || bytes.ContainsAny(title, "\x1b\a") {
+		return o.Terminal.Write(p)
+	}
+	if _, err := io.WriteString(o.Terminal, Title(string(title))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// PaneSize asks tmux for the size of the pane the game runs in. ok is false outside of tmux
+// or if tmux doesn't answer, screen has no way to ask.
+func PaneSize() (width, height int, ok bool) {
+	if Detect() != Tmux {
+		return 0, 0, false
+	}
+	out, err := exec.Command("tmux", "display-message", "-p", "-t", os.Getenv("TMUX_PANE"), "#{pane_width} #{pane_height}").Output()
+	if err != nil {
+		return 0, 0, false
+	}
+	if _, err := fmt.Sscan(string(out), &width, &height); err != nil {
+		return 0, 0, false
+	}
+	return width, height, true
+}
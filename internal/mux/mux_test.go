@@ -0,0 +1,77 @@
+package mux
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestPassthrough(t *testing.T) {
+	seq := "\x1b]52;c;Ym9v\a"
+	tests := []struct {
+		tmux, sty string
+		want      string
+	}{
+		{"", "", seq},
+		{"/tmp/tmux-1000/default,1,0", "", "\x1bPtmux;\x1b\x1b]52;c;Ym9v\a\x1b\\"},
+		{"", "1234.pts-0.host", "\x1bP\x1b]52;c;Ym9v\a\x1b\\"},
+	}
+	for _, tt := range tests {
+		t.Setenv("TMUX", tt.tmux)
+		t.Setenv("STY", tt.sty)
+		if got := Passthrough(seq); got != tt.want {
+			t.Errorf("Passthrough() in %s = %q, want %q", Detect(), got, tt.want)
+		}
+	}
+}
+
+func TestScreenChunks(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "1234.pts-0.host")
+	seq := make([]byte, screenChunk+1)
+	for i := range seq {
+		seq[i] = 'x'
+	}
+	want := "\x1bP" + string(seq[:screenChunk]) + "\x1b\\\x1bPx\x1b\\"
+	if got := Passthrough(string(seq)); got != want {
+		t.Errorf("Passthrough() = %d bytes, want %d in two chunks", len(got), len(want))
+	}
+}
+
+func TestTitle(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "")
+	if got, want := Title("Haunteed"), "\x1b]2;Haunteed\a"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	t.Setenv("STY", "1234.pts-0.host")
+	if got, want := Title("Haunteed"), "\x1bkHaunteed\x1b\\\x1bP\x1b]2;Haunteed\a\x1b\\"; got != want {
+		t.Errorf("Title() in screen = %q, want %q", got, want)
+	}
+}
+
+// terminal is a Terminal writing to a buffer
+type terminal struct{ bytes.Buffer }
+
+func (*terminal) Close() error { return nil }
+func (*terminal) Fd() uintptr  { return 0 }
+
+func TestOutput(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("STY", "1234.pts-0.host")
+	tests := []struct {
+		write, want string
+	}{
+		{"\x1b]2;Haunteed\a", "\x1bkHaunteed\x1b\\\x1bP\x1b]2;Haunteed\a\x1b\\"},
+		{"\x1b[H\x1b]2;Haunteed\a", "\x1b[H\x1b]2;Haunteed\a"},
+		{"frame", "frame"},
+	}
+	for _, tt := range tests {
+		var term terminal
+		if n, err := NewOutput(&term).Write([]byte(tt.write)); err != nil || n != len(tt.write) {
+			t.Errorf("Write(%q) = %d, %v", tt.write, n, err)
+		}
+		if got := term.String(); got != tt.want {
+			t.Errorf("Write(%q) wrote %q, want %q", tt.write, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package nick cleans up the nicknames players enter for the high-score tables.
+package nick
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+// MaxWidth is the widest nickname in terminal columns, a wide CJK glyph takes two
+const MaxWidth = 26
+
+// Filter rejects a sanitized nickname with an error shown to the player, e.g. an abusive one.
+type Filter func(nick string) error
+
+var filter Filter
+
+// SetFilter installs the filter applied by Check, hosted leaderboards plug their own one in.
+// A nil filter accepts every nickname.
+func SetFilter(f Filter) {
+	filter = f
+}
+
+// Check runs the installed filter on the nickname.
+func Check(nick string) error {
+	if filter == nil {
+		return nil
+	}
+	return filter(nick)
+}
+
+// Sanitize strips ANSI sequences, control and bidi override characters from the nickname,
+// collapses the whitespace and truncates it to MaxWidth columns.
+func Sanitize(nick string) string {
+	nick = ansi.Strip(nick)
+	nick = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || unicode.Is(unicode.Bidi_Control, r) {
+			return ' '
+		}
+		return r
+	}, nick)
+	nick = strings.Join(strings.Fields(nick), " ")
+	return strings.TrimSpace(ansi.Truncate(nick, MaxWidth, ""))
+}
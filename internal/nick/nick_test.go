@@ -0,0 +1,51 @@
+package nick
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestSanitize(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"  Casper  ", "Casper"},
+		{"\x1b[31mRed\x1b[0m Ghost", "Red Ghost"},
+		{"tab\there\nnew\x07line", "tab here new line"},
+		{"\u202eevil\u202c", "evil"},
+		{"幽霊幽霊幽霊幽霊幽霊幽霊幽霊幽霊", "幽霊幽霊幽霊幽霊幽霊幽霊幽"},
+		{"", ""},
+	}
+	for _, c := range cases {
+		got := Sanitize(c.in)
+		if got != c.want {
+			t.Errorf("Sanitize(%q) = %q, want %q", c.in, got, c.want)
+		}
+		if w := ansi.StringWidth(got); w > MaxWidth {
+			t.Errorf("Sanitize(%q) is %d columns wide, want at most %d", c.in, w, MaxWidth)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	defer SetFilter(nil)
+
+	if err := Check("anyone"); err != nil {
+		t.Fatalf("Check without a filter = %v, want nil", err)
+	}
+	banned := errors.New("not allowed")
+	SetFilter(func(nick string) error {
+		if nick == "boo" {
+			return banned
+		}
+		return nil
+	})
+	if err := Check("boo"); !errors.Is(err, banned) {
+		t.Errorf("Check(boo) = %v, want %v", err, banned)
+	}
+	if err := Check("casper"); err != nil {
+		t.Errorf("Check(casper) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,173 @@
+// Package observer lets external tools watch and steer the game through a local Unix socket:
+// stream overlays, home automation and research bots read newline-delimited JSON events
+// and send one command per line.
+//
+//	{"time":"2026-10-15T02:06:36.123Z","event":"caught by Blinky"}
+//
+// Commands are answered on the same connection with a JSON line, see Command.Reply.
+package observer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Commands lists the commands the game understands
+var Commands = []string{"pause", "resume", "screenshot", "state", "vision"}
+
+// writeTimeout keeps a stalled client from holding up the game, it is dropped instead
+const writeTimeout = 100 * time.Millisecond
+
+// Command is a command line sent by a client.
+type Command struct {
+	Name string
+	Args []string
+	conn *client
+}
+
+// Reply writes v as a JSON line to the client that sent the command.
+func (c Command) Reply(v any) {
+	c.conn.send(v)
+}
+
+// Event is a game event sent to every client.
+type Event struct {
+	Time  time.Time `json:"time"`
+	Event string    `json:"event"`
+}
+
+// Server accepts observers on a Unix socket. A nil *Server observes nothing, all methods are nil-safe.
+type Server struct {
+	listener net.Listener
+	path     string
+	commands chan Command
+
+	mu      sync.Mutex
+	clients map[*client]bool
+}
+
+type client struct {
+	conn net.Conn
+	mu   sync.Mutex
+}
+
+// Listen creates the socket at path, readable by the user only. A socket left by a crashed game is replaced.
+func Listen(path string) (*Server, error) {
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		os.Remove(path)
+	}
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	s := &Server{
+		listener: listener,
+		path:     path,
+		commands: make(chan Command),
+		clients:  make(map[*client]bool),
+	}
+	go s.accept()
+	return s, nil
+}
+
+// Commands returns the channel of commands sent by clients, it is closed with the server.
+func (s *Server) Commands() <-chan Command {
+	if s == nil {
+		return nil
+	}
+	return s.commands
+}
+
+// Emit sends the event to every client.
+func (s *Server) Emit(event string) {
+	if s == nil {
+		return
+	}
+	e := Event{Time: time.Now().UTC(), Event: event}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for c := range s.clients {
+		c.send(e)
+	}
+}
+
+// Close stops accepting clients, disconnects them and removes the socket.
+func (s *Server) Close() error {
+	if s == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	s.mu.Lock()
+	for c := range s.clients {
+		c.conn.Close()
+	}
+	s.mu.Unlock()
+	os.Remove(s.path)
+	return err
+}
+
+func (s *Server) accept() {
+	defer close(s.commands)
+	var wg sync.WaitGroup
+	defer wg.Wait() // Readers send commands until they are gone
+	for {
+		conn, err := s.listener.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return
+		}
+		if err != nil {
+			continue
+		}
+		c := &client{conn: conn}
+		s.mu.Lock()
+		s.clients[c] = true
+		s.mu.Unlock()
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.read(c)
+		}()
+	}
+}
+
+// read passes the command lines of the client on until it disconnects.
+func (s *Server) read(c *client) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.clients, c)
+		s.mu.Unlock()
+		c.conn.Close()
+	}()
+	scanner := bufio.NewScanner(c.conn)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		s.commands <- Command{Name: strings.ToLower(fields[0]), Args: fields[1:], conn: c}
+	}
+}
+
+// send writes v as a JSON line, a client too slow to take it is disconnected.
+func (c *client) send(v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	if _, err := c.conn.Write(append(data, '\n')); err != nil {
+		c.conn.Close()
+	}
+}
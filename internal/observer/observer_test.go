@@ -0,0 +1,56 @@
+package observer
+
+import (
+	"bufio"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "haunteed.sock")
+	s, err := Listen(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	conn, err := net.Dial("unix", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	conn.SetDeadline(time.Now().Add(5 * time.Second))
+	lines := bufio.NewScanner(conn)
+
+	if _, err := conn.Write([]byte("STATE now\n")); err != nil {
+		t.Fatal(err)
+	}
+	c := <-s.Commands()
+	if c.Name != "state" || len(c.Args) != 1 || c.Args[0] != "now" {
+		t.Fatalf("command = %q %q, want state [now]", c.Name, c.Args)
+	}
+	c.Reply(map[string]int{"floor": 3})
+	if !lines.Scan() || lines.Text() != `{"floor":3}` {
+		t.Fatalf("reply = %q, %v", lines.Text(), lines.Err())
+	}
+
+	s.Emit("caught by Blinky")
+	if !lines.Scan() {
+		t.Fatal(lines.Err())
+	}
+	var e Event
+	if err := json.Unmarshal(lines.Bytes(), &e); err != nil || e.Event != "caught by Blinky" {
+		t.Errorf("event = %q, %v", lines.Text(), err)
+	}
+}
+
+func TestNilServer(t *testing.T) {
+	var s *Server
+	s.Emit("ate pellet")
+	if s.Commands() != nil || s.Close() != nil {
+		t.Error("nil server should do nothing")
+	}
+}
@@ -0,0 +1,164 @@
+// Package paths locates the directories haunteed keeps its files in, following the XDG base directory
+// specification on Unix: config for settings, data for saves and reports, cache for files that can be rebuilt.
+// Each directory can be moved with its own environment variable.
+package paths
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// app is the subdirectory of the base directories
+const app = "haunteed"
+
+// Dir is the directory of one kind of files.
+type Dir struct {
+	Name string // config, data or cache
+	Env  string // Environment variable overriding the whole directory
+	base func() (string, error)
+}
+
+var (
+	Config = Dir{Name: "config", Env: "HAUNTEED_CONFIG_DIR", base: os.UserConfigDir}
+	Data   = Dir{Name: "data", Env: "HAUNTEED_DATA_DIR", base: dataHome}
+	Cache  = Dir{Name: "cache", Env: "HAUNTEED_CACHE_DIR", base: os.UserCacheDir}
+)
+
+// All lists the directories in the order "haunteed paths" prints them
+var All = []Dir{Config, Data, Cache}
+
+// Path returns the directory without creating it.
+func (d Dir) Path() (string, error) {
+	if dir := os.Getenv(d.Env); dir != "" {
+		return dir, nil
+	}
+	base, err := d.base()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, app), nil
+}
+
+// File returns the path of the named file in the directory, creating the directory if needed.
+// Files that older versions kept in the config directory are moved over along with their
+// numbered copies (name.1, name.2 and so on), so nothing is left behind on upgrade.
+// A file that can't be moved is an error, a fresh one must not take its place.
+func (d Dir) File(name string) (string, error) {
+	dir, err := d.Path()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, name)
+	if legacy, err := legacyDir(); err == nil && legacy != dir {
+		if err := migrate(filepath.Join(legacy, name), path); err != nil {
+			return "", err
+		}
+	}
+	return path, nil
+}
+
+// legacyDir is where every file was kept before the directories were split.
+func legacyDir() (string, error) {
+	base, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, app), nil
+}
+
+// migrate moves the old file and its numbered copies to the new path unless the new one exists already.
+// The file itself goes last, so a failed move is tried again on the next start.
+func migrate(old, path string) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if _, err := os.Stat(old); err != nil {
+		return nil
+	}
+	copies, _ := filepath.Glob(old + ".[0-9]*")
+	for _, c := range copies {
+		if err := move(c, path+c[len(old):]); err != nil {
+			return err
+		}
+	}
+	return move(old, path)
+}
+
+// rename is os.Rename, tests replace it to move files across filesystems
+var rename = os.Rename
+
+// move renames the file, or copies and removes it if it can't be renamed, e.g. to another filesystem.
+func move(src, dst string) error {
+	if rename(src, dst) == nil {
+		return nil
+	}
+	if err := copyFile(src, dst); err != nil {
+		os.Remove(dst)
+		return fmt.Errorf("moving %s to %s: %w", src, dst, err)
+	}
+	if err := os.Remove(src); err != nil {
+		return fmt.Errorf("moving %s to %s: %w", src, dst, err)
+	}
+	return nil
+}
+
+// copyFile copies the contents and permissions of src to dst and syncs them to disk.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	info, err := in.Stat()
+	if err != nil {
+		return err
+	}
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, info.Mode().Perm())
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(out, in)
+	if err == nil {
+		err = out.Sync()
+	}
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// dataHome is $XDG_DATA_HOME or ~/.local/share on Unix. Other systems have no separate data directory
+// and keep data with the settings.
+func dataHome() (string, error) {
+	switch runtime.GOOS {
+	case "windows", "darwin", "ios", "plan9":
+		return os.UserConfigDir()
+	}
+	if dir := os.Getenv("XDG_DATA_HOME"); filepath.IsAbs(dir) {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// Hide returns the path with the home directory replaced by ~, so it can be shown without the user name.
+func Hide(path string) string {
+	home, err := os.UserHomeDir()
+	if err != nil || home == "" {
+		return path
+	}
+	if rel, err := filepath.Rel(home, path); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return filepath.Join("~", rel)
+	}
+	return path
+}
@@ -0,0 +1,98 @@
+package paths
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestOverride(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv(Cache.Env, dir)
+	if got, err := Cache.Path(); err != nil || got != dir {
+		t.Errorf("Cache.Path() = %q, %v, want %q", got, err, dir)
+	}
+}
+
+func TestMigrate(t *testing.T) {
+	config, data := t.TempDir(), t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", config)
+	t.Setenv(Data.Env, data)
+
+	legacy := filepath.Join(config, app)
+	if err := os.MkdirAll(legacy, 0755); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"state.dat", "state.dat.1", "state.dat.2"} {
+		if err := os.WriteFile(filepath.Join(legacy, name), []byte(name), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	path, err := Data.File("state.dat")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if path != filepath.Join(data, "state.dat") {
+		t.Errorf("Data.File() = %q", path)
+	}
+	for _, name := range []string{"state.dat", "state.dat.1", "state.dat.2"} {
+		if b, err := os.ReadFile(filepath.Join(data, name)); err != nil || string(b) != name {
+			t.Errorf("%s not moved: %q, %v", name, b, err)
+		}
+		if _, err := os.Stat(filepath.Join(legacy, name)); err == nil {
+			t.Errorf("%s left in the config directory", name)
+		}
+	}
+}
+
+func TestMigrateAcrossFilesystems(t *testing.T) {
+	dir := t.TempDir()
+	old, path := filepath.Join(dir, "old"), filepath.Join(dir, "new")
+	for _, name := range []string{old, old + ".1"} {
+		if err := os.WriteFile(name, []byte(filepath.Base(name)), 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rename = func(string, string) error { return &os.LinkError{Op: "rename", Err: syscall.EXDEV} }
+	defer func() { rename = os.Rename }()
+
+	if err := migrate(old, path); err != nil {
+		t.Fatal(err)
+	}
+	for src, dst := range map[string]string{old: path, old + ".1": path + ".1"} {
+		if b, err := os.ReadFile(dst); err != nil || string(b) != filepath.Base(src) {
+			t.Errorf("%s not copied: %q, %v", dst, b, err)
+		}
+		if _, err := os.Stat(src); err == nil {
+			t.Errorf("%s not removed", src)
+		}
+	}
+
+	// A file that can't be copied is reported and stays where it was
+	if err := os.WriteFile(old, nil, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := migrate(old, filepath.Join(dir, "missing", "new")); err == nil {
+		t.Error("migrate() = nil, want the copy error")
+	}
+	if _, err := os.Stat(old); err != nil {
+		t.Errorf("old file lost after a failed move: %v", err)
+	}
+}
+
+func TestHide(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	tests := map[string]string{
+		filepath.Join(home, "data", "report.txt"): filepath.Join("~", "data", "report.txt"),
+		home + "-other/report.txt":                home + "-other/report.txt",
+		"/tmp/report.txt":                         "/tmp/report.txt",
+	}
+	for path, want := range tests {
+		if got := Hide(path); got != want {
+			t.Errorf("Hide(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Package power tells whether the machine runs on battery, so the game can go easy on it.
+package power
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Status is the power supply of the machine.
+type Status struct {
+	OnBattery bool // unplugged and discharging
+	Level     int  // battery charge in percent
+}
+
+// Saving reports whether the energy saver should be on: the machine runs on battery charged at or below the given percent.
+// A percent of 0 or less turns the saver off, and machines without a readable battery never save.
+func Saving(below int) bool {
+	if below <= 0 {
+		return false
+	}
+	st, ok := Read()
+	return ok && st.OnBattery && st.Level <= below
+}
+
+// readSysfs reads the first battery of the Linux power supply class, usually /sys/class/power_supply.
+func readSysfs(dir string) (Status, bool) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return Status{}, false
+	}
+	for _, e := range entries {
+		if readAttr(dir, e.Name(), "type") != "Battery" {
+			continue
+		}
+		level, err := strconv.Atoi(readAttr(dir, e.Name(), "capacity"))
+		if err != nil {
+			continue
+		}
+		return Status{OnBattery: readAttr(dir, e.Name(), "status") == "Discharging", Level: level}, true
+	}
+	return Status{}, false
+}
+
+func readAttr(dir, supply, attr string) string {
+	data, err := os.ReadFile(filepath.Join(dir, supply, attr))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(data))
+}
+
+var pmsetLevel = regexp.MustCompile(`(\d+)%`)
+
+// parsePmset parses the output of macOS "pmset -g batt", e.g.
+//
+//	Now drawing from 'Battery Power'
+//	 -InternalBattery-0 (id=4653155)	57%; discharging; 3:12 remaining present: true
+func parsePmset(out string) (Status, bool) {
+	m := pmsetLevel.FindStringSubmatch(out)
+	if m == nil {
+		return Status{}, false
+	}
+	level, _ := strconv.Atoi(m[1])
+	return Status{OnBattery: strings.Contains(out, "'Battery Power'"), Level: level}, true
+}
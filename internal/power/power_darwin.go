@@ -0,0 +1,14 @@
+//go:build darwin
+
+package power
+
+import "os/exec"
+
+// Read returns the power supply status reported by pmset, false if there is no battery.
+func Read() (Status, bool) {
+	out, err := exec.Command("pmset", "-g", "batt").Output()
+	if err != nil {
+		return Status{}, false
+	}
+	return parsePmset(string(out))
+}
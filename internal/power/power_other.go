@@ -0,0 +1,11 @@
+//go:build !darwin
+
+package power
+
+// sysfsDir is the Linux power supply class, other systems don't have it and report no battery
+const sysfsDir = "/sys/class/power_supply"
+
+// Read returns the power supply status of the first battery, false if there is none.
+func Read() (Status, bool) {
+	return readSysfs(sysfsDir)
+}
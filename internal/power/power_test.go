@@ -0,0 +1,57 @@
+package power
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSupply(t *testing.T, dir, name string, attrs map[string]string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, name), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for attr, value := range attrs {
+		if err := os.WriteFile(filepath.Join(dir, name, attr), []byte(value+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestReadSysfs(t *testing.T) {
+	dir := t.TempDir()
+	if _, ok := readSysfs(dir); ok {
+		t.Fatal("readSysfs found a battery in an empty directory")
+	}
+
+	writeSupply(t, dir, "AC", map[string]string{"type": "Mains", "online": "0"})
+	writeSupply(t, dir, "BAT0", map[string]string{"type": "Battery", "status": "Discharging", "capacity": "17"})
+	got, ok := readSysfs(dir)
+	if want := (Status{OnBattery: true, Level: 17}); !ok || got != want {
+		t.Errorf("readSysfs = %+v, %v, want %+v, true", got, ok, want)
+	}
+
+	writeSupply(t, dir, "BAT0", map[string]string{"status": "Charging"})
+	got, ok = readSysfs(dir)
+	if want := (Status{OnBattery: false, Level: 17}); !ok || got != want {
+		t.Errorf("readSysfs = %+v, %v, want %+v, true", got, ok, want)
+	}
+}
+
+func TestParsePmset(t *testing.T) {
+	cases := []struct {
+		out  string
+		want Status
+		ok   bool
+	}{
+		{"Now drawing from 'Battery Power'\n -InternalBattery-0 (id=4653155)\t57%; discharging; 3:12 remaining present: true\n", Status{OnBattery: true, Level: 57}, true},
+		{"Now drawing from 'AC Power'\n -InternalBattery-0 (id=4653155)\t100%; charged; 0:00 remaining present: true\n", Status{OnBattery: false, Level: 100}, true},
+		{"Now drawing from 'AC Power'\n", Status{}, false},
+	}
+	for _, c := range cases {
+		got, ok := parsePmset(c.out)
+		if got != c.want || ok != c.ok {
+			t.Errorf("parsePmset(%q) = %+v, %v, want %+v, %v", c.out, got, ok, c.want, c.ok)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package render
+
+import (
+	"strings"
+	"unicode"
+)
+
+// bannerHeight is the number of terminal rows taken by a banner letter
+const bannerHeight = 3
+
+// bannerFont holds block letters for large text, unknown characters are rendered as '?'
+var bannerFont = map[rune][bannerHeight]string{
+	'A': {"▄▀▄", "█▀█", "▀ ▀"},
+	'B': {"█▀▄", "█▀▄", "▀▀ "},
+	'C': {"▄▀▀", "█  ", " ▀▀"},
+	'D': {"█▀▄", "█ █", "▀▀ "},
+	'E': {"█▀▀", "█▀▀", "▀▀▀"},
+	'F': {"█▀▀", "█▀ ", "▀  "},
+	'G': {"█▀▀", "█ █", "▀▀▀"},
+	'H': {"█ █", "█▀█", "▀ ▀"},
+	'I': {"▀█▀", " █ ", "▀▀▀"},
+	'J': {"  █", "▄ █", " ▀ "},
+	'K': {"█ ▄", "█▀▄", "▀ ▀"},
+	'L': {"█  ", "█  ", "▀▀▀"},
+	'M': {"█▄ ▄█", "█ ▀ █", "▀   ▀"},
+	'N': {"█▄ █", "█ ▀█", "▀  ▀"},
+	'O': {"█▀█", "█ █", "▀▀▀"},
+	'P': {"█▀█", "█▀▀", "▀  "},
+	'Q': {"█▀█", "█ █", "▀▀█"},
+	'R': {"█▀█", "█▀▄", "▀ ▀"},
+	'S': {"█▀▀", "▀▀█", "▀▀▀"},
+	'T': {"▀█▀", " █ ", " ▀ "},
+	'U': {"█ █", "█ █", "▀▀▀"},
+	'V': {"█ █", "█ █", " ▀ "},
+	'W': {"█   █", "█ █ █", " ▀ ▀ "},
+	'X': {"▀▄▀", "▄▀▄", "▀ ▀"},
+	'Y': {"█ █", "▀█▀", " ▀ "},
+	'Z': {"▀▀█", "▄▀ ", "▀▀▀"},
+	'0': {"█▀█", "█ █", "▀▀▀"},
+	'1': {"▄█ ", " █ ", "▀▀▀"},
+	'2': {"▀▀█", "█▀▀", "▀▀▀"},
+	'3': {"▀▀█", " ▀█", "▀▀▀"},
+	'4': {"█ █", "▀▀█", "  ▀"},
+	'5': {"█▀▀", "▀▀█", "▀▀▀"},
+	'6': {"█▀▀", "█▀█", "▀▀▀"},
+	'7': {"▀▀█", "  █", "  ▀"},
+	'8': {"█▀█", "█▀█", "▀▀▀"},
+	'9': {"█▀█", "▀▀█", "▀▀▀"},
+	' ': {"  ", "  ", "  "},
+	'!': {"█", "▀", "▀"},
+	'.': {" ", " ", "▀"},
+	':': {" ", "▀", "▀"},
+	'-': {"   ", "▀▀▀", "   "},
+	'#': {"▄█▄█▄", "▀█▀█▀", "     "},
+	'?': {"▀▀█", " █▀", " ▀ "},
+}
+
+// Banner renders text with large block letters for low-vision players.
+// Empty text still takes the banner height so blinking titles don't shift the page.
+func Banner(text string) string {
+	var rows [bannerHeight]strings.Builder
+	for i, r := range strings.ToUpper(text) {
+		glyph, ok := bannerFont[r]
+		if !ok {
+			if r == '—' {
+				glyph = bannerFont['-']
+			} else if unicode.IsSpace(r) {
+				glyph = bannerFont[' ']
+			} else {
+				glyph = bannerFont['?']
+			}
+		}
+		for row := range rows {
+			if i > 0 {
+				rows[row].WriteString(" ")
+			}
+			rows[row].WriteString(glyph[row])
+		}
+	}
+	lines := make([]string, bannerHeight)
+	for row := range rows {
+		lines[row] = rows[row].String()
+	}
+	return strings.Join(lines, "\n")
+}
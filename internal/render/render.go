@@ -7,14 +7,28 @@ import (
 	"github.com/vinser/haunteed/internal/style"
 )
 
+// notice is a one line message shown above the footer of every page, see SetNotice
+var notice string
+
+// SetNotice sets a non-blocking notice shown above page footers, empty text hides it.
+func SetNotice(text string) {
+	notice = text
+}
+
 // Page renders page with title at the top, content block and footer at the botttom
-// Style of content leave intact
-func Page(title, renderedContent, footer string, width, height, termWidth, termHeight int) string {
+// Style of content leave intact, the rest takes the ui styles
+func Page(ui style.UI, title, renderedContent, footer string, width, height, termWidth, termHeight int) string {
 	// Render top pattern of slashes
-	renderedTopPattern := style.TopPattern.Render(strings.Repeat("/", width))
+	renderedTopPattern := ui.TopPattern.Render(strings.Repeat("/", width))
 
 	// Render the title
-	renderedTitle := style.Title.Render(title)
+	renderedTitle := ui.Title.Render(title)
+	if ui.Banner {
+		// Fall back to the plain title if the banner doesn't fit the page
+		if banner := Banner(title); lipgloss.Width(banner) <= width {
+			renderedTitle = ui.Title.Render(banner)
+		}
+	}
 
 	// Render the content
 	// renderedContent := content
@@ -23,7 +37,10 @@ func Page(title, renderedContent, footer string, width, height, termWidth, termH
 	if lipgloss.Width(footer) < width {
 		footer = strings.TrimSuffix(footer, "\n") + " " + strings.Repeat("/", width-lipgloss.Width(footer)-1)
 	}
-	renderedFooter := style.Footer.Render(footer)
+	renderedFooter := ui.Footer.Render(footer)
+	if notice != "" {
+		renderedFooter = lipgloss.JoinVertical(lipgloss.Left, ui.Title.Render(notice), renderedFooter)
+	}
 
 	// Calculate available height for content after accounting for title and footer
 	availableHeight := height - lipgloss.Height(renderedTopPattern) - lipgloss.Height(renderedTitle) - lipgloss.Height(renderedFooter)
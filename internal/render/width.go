@@ -0,0 +1,33 @@
+package render
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// PadRight pads s with spaces to the given width in terminal columns.
+// Wide CJK glyphs take two columns, so padding by bytes or runes misaligns them.
+func PadRight(s string, width int) string {
+	return s + strings.Repeat(" ", max(width-lipgloss.Width(s), 0))
+}
+
+// PadLeft pads s with leading spaces to the given width in terminal columns.
+func PadLeft(s string, width int) string {
+	return strings.Repeat(" ", max(width-lipgloss.Width(s), 0)) + s
+}
+
+// lrm is the left-to-right mark, a zero width character which ends a right-to-left run
+const lrm = "\u200e"
+
+// Isolate closes right-to-left text, e.g. an Arabic or Hebrew nickname, with a left-to-right mark,
+// so bidi-aware terminals don't pull the rest of the line into its run. Other text is left as it is.
+func Isolate(s string) string {
+	for _, r := range s {
+		if unicode.In(r, unicode.Arabic, unicode.Hebrew, unicode.Syriac, unicode.Thaana, unicode.Nko) {
+			return lrm + s + lrm
+		}
+	}
+	return s
+}
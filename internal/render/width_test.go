@@ -0,0 +1,30 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestPad(t *testing.T) {
+	for _, s := range []string{"ghost", "幽霊", "[▪]", "שד"} {
+		if got := lipgloss.Width(PadRight(s, 8)); got != 8 {
+			t.Errorf("PadRight(%q, 8) is %d columns wide", s, got)
+		}
+		if got := lipgloss.Width(PadLeft(s, 8)); got != 8 {
+			t.Errorf("PadLeft(%q, 8) is %d columns wide", s, got)
+		}
+	}
+	if got := PadRight("幽霊幽霊幽霊", 8); got != "幽霊幽霊幽霊" {
+		t.Errorf("PadRight() cut a wider text to %q", got)
+	}
+}
+
+func TestIsolate(t *testing.T) {
+	if got := Isolate("rootless"); got != "rootless" {
+		t.Errorf("Isolate() changed left-to-right text to %q", got)
+	}
+	if got := Isolate("שד"); got != lrm+"שד"+lrm || lipgloss.Width(got) != 2 {
+		t.Errorf("Isolate() = %q, want the text between left-to-right marks", got)
+	}
+}
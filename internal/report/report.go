@@ -0,0 +1,107 @@
+// Package report composes issue reports with what it takes to reproduce a problem:
+// the version, settings, floor seed, terminal and the latest game events.
+package report
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/vinser/haunteed/internal/paths"
+	"github.com/vinser/haunteed/internal/state"
+)
+
+// issuesURL is where new issues are filed
+const issuesURL = "https://github.com/vinser/haunteed/issues/new"
+
+// maxURLLength keeps the issue URL within what browsers and GitHub accept,
+// longer reports leave the events out of the URL and point to the file.
+const maxURLLength = 8000
+
+// Report is an issue report.
+type Report struct {
+	Version     string
+	Description string
+	Settings    *state.State
+	Floor       int   // Floor the report was made on, 0 outside of a run
+	Seed        int64 // Seed of the floor
+	Width       int   // Terminal size
+	Height      int
+	Events      []string // Latest game events, the latest last
+	CreatedAt   time.Time
+}
+
+// Title returns the issue title, the first line of the description.
+func (r Report) Title() string {
+	title, _, _ := strings.Cut(strings.TrimSpace(r.Description), "\n")
+	if title == "" {
+		return "Issue report"
+	}
+	return title
+}
+
+// Text returns the report as markdown.
+func (r Report) Text() string {
+	var b strings.Builder
+	r.writeHead(&b)
+	b.WriteString("\n### Latest events\n\n")
+	if len(r.Events) == 0 {
+		b.WriteString("none\n")
+	}
+	for _, e := range r.Events {
+		fmt.Fprintf(&b, "- %s\n", e)
+	}
+	return b.String()
+}
+
+// writeHead writes all of the report but the events.
+func (r Report) writeHead(b *strings.Builder) {
+	desc := strings.TrimSpace(r.Description)
+	if desc == "" {
+		desc = "_No description_"
+	}
+	fmt.Fprintf(b, "%s\n\n### Environment\n\n", desc)
+	fmt.Fprintf(b, "- Version: %s\n", r.Version)
+	fmt.Fprintf(b, "- OS: %s/%s\n", runtime.GOOS, runtime.GOARCH)
+	fmt.Fprintf(b, "- Terminal: %dx%d, TERM=%s, COLORTERM=%s\n", r.Width, r.Height, os.Getenv("TERM"), os.Getenv("COLORTERM"))
+	fmt.Fprintf(b, "- Floor: %d, seed %d\n", r.Floor, r.Seed)
+	if st := r.Settings; st != nil {
+		fmt.Fprintf(b, "- Settings: mode %s, night %s, sprite %s, ui scale %s, mute %t\n",
+			st.ModeName(), st.NightOption, st.SpriteSize, st.UIScale, st.Mute)
+		fmt.Fprintf(b, "- Input: repeat %d ms, debounce %d ms, sticky steps %d\n",
+			st.RepeatThreshold().Milliseconds(), st.DebounceMs, max(st.StickySteps, 1))
+	}
+}
+
+// IssueURL returns the URL of a new GitHub issue filled in with the report.
+// The events are left out if the URL gets too long, the saved file has them all.
+func (r Report) IssueURL(path string) string {
+	u := issueURL(r.Title(), r.Text())
+	if len(u) <= maxURLLength {
+		return u
+	}
+	var b strings.Builder
+	r.writeHead(&b)
+	fmt.Fprintf(&b, "\n### Latest events\n\nToo many to fit, please attach %s\n", filepath.Base(path))
+	return issueURL(r.Title(), b.String())
+}
+
+func issueURL(title, body string) string {
+	return issuesURL + "?" + url.Values{"title": {title}, "body": {body}}.Encode()
+}
+
+// Save writes the report to the data directory and returns the file path.
+func (r Report) Save() (string, error) {
+	path, err := paths.Data.File("report-" + r.CreatedAt.Format("20060102-150405") + ".md")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(r.Text()), 0o644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/vinser/haunteed/internal/state"
+)
+
+func TestText(t *testing.T) {
+	r := Report{
+		Version:     "1.2.3",
+		Description: "Ghost walked through a wall\nOn the second floor",
+		Settings:    &state.State{Settings: state.Settings{GameMode: state.ModeNoisy, NightOption: state.NightNever}},
+		Floor:       2,
+		Seed:        42,
+		Events:      []string{"ate pellet", "caught by Lofty"},
+	}
+	if got, want := r.Title(), "Ghost walked through a wall"; got != want {
+		t.Errorf("Title() = %q, want %q", got, want)
+	}
+	text := r.Text()
+	for _, want := range []string{"On the second floor", "Version: 1.2.3", "Floor: 2, seed 42", "mode noisy", "- caught by Lofty"} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() lacks %q:\n%s", want, text)
+		}
+	}
+	if got := (Report{}).Title(); got != "Issue report" {
+		t.Errorf("Title() without a description = %q", got)
+	}
+}
+
+func TestIssueURL(t *testing.T) {
+	r := Report{Description: "Stuck", Events: []string{"entered floor 1"}}
+	u, err := url.Parse(r.IssueURL("report.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if q.Get("title") != "Stuck" || !strings.Contains(q.Get("body"), "entered floor 1") {
+		t.Errorf("IssueURL() query = %v", q)
+	}
+
+	for i := range 2000 {
+		r.Events = append(r.Events, fmt.Sprintf("event number %d", i))
+	}
+	long := r.IssueURL("report-1.md")
+	if len(long) > maxURLLength {
+		t.Errorf("IssueURL() is %d long, want at most %d", len(long), maxURLLength)
+	}
+	if !strings.Contains(long, "report-1.md") {
+		t.Errorf("IssueURL() of a long report doesn't point to the file")
+	}
+}
@@ -1,10 +1,52 @@
 package score
 
+import (
+	"fmt"
+	"strconv"
+)
+
 type Score struct {
 	value             int
 	high              int
 	nick              string
 	eatenGhostsStreak int
+	insured           int  // points banked by the insurance
+	insuredDone       bool // insurance is taken once per run
+	percent           int  // share of the final score kept by a handicapped run, 0 is all of it
+}
+
+// DeathPenalty is the percentage of the score every life lost takes, see Die.
+// InsuredShare is the percentage of the score the insurance banks,
+// InsurancePremium the percentage it costs right away.
+const (
+	DeathPenalty     = 20
+	InsuredShare     = 60
+	InsurancePremium = 10
+)
+
+// Percentages each handicap takes off the final score, see Multiplier.
+const (
+	SpeedHandicap  = 20 // ghosts step slower
+	LifeHandicap   = 10 // each extra life to start with
+	FrightHandicap = 15 // ghosts stay frightened longer
+	MinMultiplier  = 40 // a run keeps at least this share however many handicaps it takes
+)
+
+// Multiplier returns the percentage of the final score a run with the handicaps keeps, 100 for a fair run.
+func Multiplier(speed bool, extraLives int, fright bool) int {
+	percent := 100 - extraLives*LifeHandicap
+	if speed {
+		percent -= SpeedHandicap
+	}
+	if fright {
+		percent -= FrightHandicap
+	}
+	return max(percent, MinMultiplier)
+}
+
+// FormatMultiplier returns the percentage as a multiplier, e.g. ×0.75.
+func FormatMultiplier(percent int) string {
+	return fmt.Sprintf("×%d.%02d", percent/100, percent%100)
 }
 
 func NewScore() *Score {
@@ -22,15 +64,69 @@ func (s *Score) Get() int {
 func (s *Score) Reset() {
 	s.value = 0
 	s.eatenGhostsStreak = 0
+	s.insured = 0
+	s.insuredDone = false
+}
+
+// Die takes DeathPenalty percent of the score for a life lost, the last one included.
+// An insured run never ends with less than the banked points, see Final.
+func (s *Score) Die() {
+	s.value -= s.value * DeathPenalty / 100
+}
+
+// Insure banks InsuredShare percent of the current score, the run never ends with less,
+// and takes InsurancePremium percent of it. It returns false if the run is already insured.
+func (s *Score) Insure() bool {
+	if s.insuredDone {
+		return false
+	}
+	s.insured = s.value * InsuredShare / 100
+	s.value -= s.value * InsurancePremium / 100
+	s.insuredDone = true
+	return true
+}
+
+// CanInsure returns true if the insurance is not taken yet in this run.
+func (s *Score) CanInsure() bool {
+	return !s.insuredDone
+}
+
+// Insured returns the banked points and whether the run is insured.
+func (s *Score) Insured() (int, bool) {
+	return s.insured, s.insuredDone
+}
+
+// SetPercent keeps the percentage of the final score of a handicapped run, see Multiplier.
+func (s *Score) SetPercent(percent int) {
+	s.percent = percent
+}
+
+// Percent returns the percentage of the final score the run keeps, 100 unless it is handicapped.
+func (s *Score) Percent() int {
+	if s.percent == 0 {
+		return 100
+	}
+	return s.percent
 }
 
-// Call when Haunteed eats a frightened ghost
-func (s *Score) AddGhostPoints() {
+// Final returns the score the run ends with: the banked points if the score fell below them,
+// e.g. after deaths or shopping on the floor intro, scaled down by the handicaps.
+func (s *Score) Final() int {
+	final := s.value
+	if s.insuredDone && s.insured > s.value {
+		final = s.insured
+	}
+	return final * s.Percent() / 100
+}
+
+// Call when Haunteed eats a frightened ghost, it returns the points added
+func (s *Score) AddGhostPoints() int {
 	points := 200 << s.eatenGhostsStreak // 200, 400, 800, 1600
 	s.value += points
 	if s.eatenGhostsStreak < 3 {
 		s.eatenGhostsStreak++
 	}
+	return points
 }
 
 // Reset when frightened mode ends
@@ -53,3 +149,16 @@ func (s *Score) SetNick(value string) {
 func (s *Score) GetHighNick() string {
 	return s.nick
 }
+
+// Format returns points with thousands separated by commas, e.g. 12,450.
+func Format(points int) string {
+	sign := ""
+	if points < 0 {
+		sign, points = "-", -points
+	}
+	s := strconv.Itoa(points)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return sign + s
+}
@@ -0,0 +1,114 @@
+package score
+
+import "testing"
+
+func TestInsure(t *testing.T) {
+	s := NewScore()
+	s.Add(1000)
+	if !s.Insure() {
+		t.Fatal("Insure() = false on an uninsured run")
+	}
+	if s.Insure() {
+		t.Error("Insure() = true twice in a run")
+	}
+	if banked, ok := s.Insured(); !ok || banked != 600 {
+		t.Errorf("Insured() = %d, %v, want 600, true", banked, ok)
+	}
+
+	if got := s.Final(); got != 900 {
+		t.Errorf("Final() = %d, want 900 after the premium", got)
+	}
+	s.Die()
+	if got := s.Final(); got != 720 {
+		t.Errorf("Final() after a death = %d, want 720", got)
+	}
+	s.Die()
+	if got := s.Final(); got != 600 {
+		t.Errorf("Final() after two deaths = %d, want the banked 600", got)
+	}
+	s.Add(-1000) // spent on the floor intro
+	if got := s.Final(); got != 600 {
+		t.Errorf("Final() after spending = %d, want the banked 600", got)
+	}
+
+	s.Reset()
+	if !s.CanInsure() || s.Final() != 0 {
+		t.Errorf("after Reset() CanInsure() = %v, Final() = %d, want true, 0", s.CanInsure(), s.Final())
+	}
+}
+
+func TestInsuranceTradeOff(t *testing.T) {
+	tests := []struct {
+		name               string
+		add                int
+		deaths             int
+		insured, uninsured int
+	}{
+		{"run keeps going", 2000, 0, 2900, 3000}, // the premium is lost
+		{"run dies hard", 0, 3, 600, 512},        // the banked points are kept
+	}
+	for _, tt := range tests {
+		insured, uninsured := NewScore(), NewScore()
+		insured.Add(1000)
+		uninsured.Add(1000)
+		insured.Insure()
+		insured.Add(tt.add)
+		uninsured.Add(tt.add)
+		for range tt.deaths {
+			insured.Die()
+			uninsured.Die()
+		}
+		if got := insured.Final(); got != tt.insured {
+			t.Errorf("%s: insured Final() = %d, want %d", tt.name, got, tt.insured)
+		}
+		if got := uninsured.Final(); got != tt.uninsured {
+			t.Errorf("%s: uninsured Final() = %d, want %d", tt.name, got, tt.uninsured)
+		}
+	}
+}
+
+func TestMultiplier(t *testing.T) {
+	tests := []struct {
+		speed  bool
+		lives  int
+		fright bool
+		want   int
+		text   string
+	}{
+		{false, 0, false, 100, "×1.00"},
+		{true, 0, false, 80, "×0.80"},
+		{false, 2, true, 65, "×0.65"},
+		{true, 9, true, MinMultiplier, "×0.40"},
+	}
+	for _, tt := range tests {
+		got := Multiplier(tt.speed, tt.lives, tt.fright)
+		if got != tt.want || FormatMultiplier(got) != tt.text {
+			t.Errorf("Multiplier(%v, %d, %v) = %d (%s), want %d (%s)", tt.speed, tt.lives, tt.fright, got, FormatMultiplier(got), tt.want, tt.text)
+		}
+	}
+
+	s := NewScore()
+	s.Add(1000)
+	s.SetPercent(75)
+	if s.Get() != 1000 || s.Final() != 750 {
+		t.Errorf("Get(), Final() = %d, %d with 75%%, want 1000, 750", s.Get(), s.Final())
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tests := []struct {
+		points int
+		want   string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{12450, "12,450"},
+		{1000000, "1,000,000"},
+		{-1500, "-1,500"},
+	}
+	for _, tt := range tests {
+		if got := Format(tt.points); got != tt.want {
+			t.Errorf("Format(%d) = %q, want %q", tt.points, got, tt.want)
+		}
+	}
+}
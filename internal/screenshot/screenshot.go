@@ -0,0 +1,117 @@
+// Package screenshot keeps frames of notable moments as text files with ANSI colors,
+// "cat" shows them in a terminal. They live in the screenshots folder of the data directory.
+package screenshot
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vinser/haunteed/internal/paths"
+)
+
+const (
+	MaxShots = 50 // Older screenshots are removed
+
+	folder      = "screenshots"
+	ext         = ".ans"
+	stampLayout = "20060102-150405"
+)
+
+// Reasons to take a screenshot
+const (
+	HighScore    = "high score"
+	DeepestFloor = "deepest floor"
+)
+
+// Shot is a saved screenshot.
+type Shot struct {
+	Path    string
+	Reason  string
+	TakenAt time.Time
+}
+
+// Dir returns the screenshots folder, creating it if needed.
+func Dir() (string, error) {
+	dir, err := paths.Data.File(folder)
+	if err != nil {
+		return "", err
+	}
+	return dir, os.MkdirAll(dir, 0755)
+}
+
+// Save writes the frame to a file named after the time and the reason, e.g. 20261015-020636-high-score.ans,
+// and removes the oldest screenshots over MaxShots. It returns the file path.
+func Save(frame, reason string, at time.Time) (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, at.Format(stampLayout)+"-"+strings.ReplaceAll(reason, " ", "-")+ext)
+	if err := os.WriteFile(path, []byte(frame+"\n"), 0644); err != nil {
+		return "", err
+	}
+	prune(dir)
+	return path, nil
+}
+
+// List returns the saved screenshots, the latest last.
+func List() ([]Shot, error) {
+	dir, err := Dir()
+	if err != nil {
+		return nil, err
+	}
+	return list(dir)
+}
+
+// Read returns the frame of the screenshot.
+func Read(s Shot) (string, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
+
+func list(dir string) ([]Shot, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var shots []Shot
+	for _, e := range entries {
+		if s, ok := parse(dir, e.Name()); ok {
+			shots = append(shots, s)
+		}
+	}
+	sort.Slice(shots, func(i, j int) bool { return shots[i].TakenAt.Before(shots[j].TakenAt) })
+	return shots, nil
+}
+
+// parse reads the time and the reason from the file name, other files are skipped.
+func parse(dir, name string) (Shot, bool) {
+	base, ok := strings.CutSuffix(name, ext)
+	if !ok || len(base) <= len(stampLayout)+1 {
+		return Shot{}, false
+	}
+	at, err := time.ParseInLocation(stampLayout, base[:len(stampLayout)], time.Local)
+	if err != nil {
+		return Shot{}, false
+	}
+	reason := strings.ReplaceAll(base[len(stampLayout)+1:], "-", " ")
+	return Shot{Path: filepath.Join(dir, name), Reason: reason, TakenAt: at}, true
+}
+
+// prune removes the oldest screenshots over MaxShots.
+func prune(dir string) {
+	shots, err := list(dir)
+	if err != nil {
+		return
+	}
+	for len(shots) > MaxShots {
+		os.Remove(shots[0].Path)
+		shots = shots[1:]
+	}
+}
@@ -0,0 +1,39 @@
+package screenshot
+
+import (
+	"testing"
+	"time"
+
+	"github.com/vinser/haunteed/internal/paths"
+)
+
+func TestSaveAndList(t *testing.T) {
+	t.Setenv(paths.Data.Env, t.TempDir())
+	start := time.Date(2026, 10, 15, 2, 6, 36, 0, time.Local)
+	for i := range MaxShots + 2 {
+		if _, err := Save("frame", HighScore, start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := Save("\x1b[31mlast\x1b[0m", DeepestFloor, start.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	shots, err := List()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shots) != MaxShots {
+		t.Fatalf("%d screenshots kept, want %d", len(shots), MaxShots)
+	}
+	if first := start.Add(3 * time.Second); !shots[0].TakenAt.Equal(first) {
+		t.Errorf("oldest kept at %v, want %v", shots[0].TakenAt, first)
+	}
+	last := shots[len(shots)-1]
+	if last.Reason != DeepestFloor {
+		t.Errorf("Reason = %q, want %q", last.Reason, DeepestFloor)
+	}
+	if frame, err := Read(last); err != nil || frame != "\x1b[31mlast\x1b[0m" {
+		t.Errorf("Read() = %q, %v", frame, err)
+	}
+}
@@ -4,6 +4,13 @@
 package sound
 
 import (
+	"errors"
+	"net"
+	"os"
+	"path"
+	"strings"
+	"time"
+
 	"github.com/gopxl/beep/v2"
 	"github.com/jfreymuth/pulse"
 )
@@ -57,6 +64,44 @@ func beepToFloat32Func(ctrl *pulseControl, channels int) func([]float32) (int, e
 	}
 }
 
+// probeBackend quickly checks that a PulseAudio server accepts connections.
+// The server is looked up the same way the pulse client does: PULSE_SERVER or the user runtime socket.
+func probeBackend(timeout time.Duration) error {
+	network, addr := "unix", path.Join(os.Getenv("XDG_RUNTIME_DIR"), "pulse/native")
+	if server, ok := os.LookupEnv("PULSE_SERVER"); ok {
+		fields := strings.Fields(server)
+		if len(fields) == 0 {
+			return errors.New("pulseaudio: empty PULSE_SERVER")
+		}
+		s := fields[0]
+		if strings.HasPrefix(s, "{") {
+			s = s[strings.IndexByte(s, '}')+1:]
+		}
+		switch {
+		case strings.HasPrefix(s, "unix:"):
+			addr = s[len("unix:"):]
+		case strings.HasPrefix(s, "/"):
+			addr = s
+		default:
+			network = "tcp"
+			addr = s[strings.IndexByte(s, ':')+1:]
+			if _, _, err := net.SplitHostPort(addr); err != nil {
+				addr = net.JoinHostPort(addr, "4713") // Default PulseAudio port
+			}
+		}
+	}
+	if network == "unix" {
+		if _, err := os.Stat(addr); err != nil {
+			return err
+		}
+	}
+	conn, err := net.DialTimeout(network, addr, timeout)
+	if err != nil {
+		return err
+	}
+	return conn.Close()
+}
+
 // initBackend initializes PulseAudio instead of beep/speaker.
 func (mgr *Manager) initBackend(sampleRate beep.SampleRate, bufferSize int) error {
 	client, err := pulse.NewClient()
@@ -0,0 +1,55 @@
+package sound
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// bellCues maps sounds to the number of terminal bells standing in for them.
+// Many terminal emulators flash the taskbar on a bell and mobile SSH clients vibrate.
+// Sounds not listed don't ring.
+var bellCues = map[string]int{
+	EAT_PELLET:      1,
+	TRANSITION_UP:   1,
+	TRANSITION_DOWN: 1,
+	KILL_GHOST:      2,
+	KLAXON:          2,
+	HIGH_SCORE:      2,
+	LOSE_LIFE:       3,
+	GAME_OVER:       3,
+}
+
+const bellGap = 150 * time.Millisecond // Pause between the bells of a pattern
+
+var (
+	bellMu  sync.Mutex
+	bellOut io.Writer // nil rings no bells
+)
+
+// SetBell makes sounds ring bell patterns to w, e.g. the terminal, nil turns the bells off.
+// The bells ring even without an audio device.
+func SetBell(w io.Writer) {
+	bellMu.Lock()
+	defer bellMu.Unlock()
+	bellOut = w
+}
+
+// ring rings the bell pattern of the sound, if any.
+func ring(name string) {
+	bellMu.Lock()
+	out := bellOut
+	bellMu.Unlock()
+	count := bellCues[name]
+	if out == nil || count == 0 {
+		return
+	}
+	go func() {
+		for i := range count {
+			if i > 0 {
+				time.Sleep(bellGap)
+			}
+			out.Write([]byte{'\a'})
+		}
+	}()
+}
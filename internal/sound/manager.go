@@ -35,6 +35,7 @@ const (
 	GAME_OVER   = "game_over.wav"   // Game over
 	HIGH_SCORE  = "high_score.wav"  // New high score
 	QUIT        = "quit.wav"        // Quit game
+	KLAXON      = "klaxon"          // Ghost reinforcements are called, synthesized
 	// Transitions
 	TRANSITION_UP   = "transition_up.wav"   // Up stairs
 	TRANSITION_DOWN = "transition_down.wav" // Down stairs
@@ -50,6 +51,14 @@ const (
 
 const CommonSampleRate = 44100 // Common sample rate for normalization for all sounds
 
+// Music tempo, see Tempo
+const (
+	TempoPerFloor = 0.01 // Speed-up for each floor away from the ground
+	TempoMaxDepth = 10   // Floors after which the tempo stops growing
+	TempoTension  = 0.05 // Speed-up when the floor is almost cleared
+	TensionDots   = 20   // Dots left when the tension kicks in
+)
+
 // Manager controls the loading and playback of audio samples.
 type Manager struct {
 	mu         sync.Mutex
@@ -57,8 +66,12 @@ type Manager struct {
 	ctrl       map[string]*beep.Ctrl
 	mix        *beep.Mixer
 	format     beep.Format
-	vol        *effects.Volume    // master volume
-	sampleVols map[string]float64 // per-sample volume in dB
+	vol        *effects.Volume            // master volume
+	sampleVols map[string]float64         // per-sample volume in dB
+	noLoops    bool                       // looping samples are skipped, see SetLoops
+	tempo      float64                    // resampling ratio of looping samples, 0 is normal speed
+	rates      map[string]*beep.Resampler // tempo controls of the loops playing
+	offset     time.Duration              // output latency, scheduled samples start this much earlier
 
 	backend   any           // backend-specific data
 	pulseCtrl *pulseControl // PulseAudio control for immediate stop
@@ -74,6 +87,7 @@ func NewManager(sampleRate beep.SampleRate) (*Manager, error) {
 		mix:        &beep.Mixer{},
 		format:     beep.Format{SampleRate: sampleRate, NumChannels: 1, Precision: 2},
 		sampleVols: make(map[string]float64),
+		rates:      make(map[string]*beep.Resampler),
 	}
 	mgr.vol = &effects.Volume{
 		Streamer: mgr.mix,
@@ -136,6 +150,7 @@ func (mgr *Manager) LoadSamples() error {
 			}
 		}
 	}
+	mgr.synthesize()
 
 	return nil
 }
@@ -185,6 +200,9 @@ func (mgr *Manager) SetVolume(name string, db float64) {
 
 // playInternal plays the sample by name, optionally looping it.
 func (mgr *Manager) playInternal(name string, loop bool, onEnd func()) error {
+	if !loop {
+		ring(name)
+	}
 	if mgr == nil {
 		return errors.New("sound manager is nil")
 	}
@@ -199,15 +217,23 @@ func (mgr *Manager) playInternal(name string, loop bool, onEnd func()) error {
 	if !ok {
 		return errors.New("sample not loaded: " + name)
 	}
+	if loop && mgr.noLoops {
+		return nil
+	}
 
 	// Interrupt previous if exists
 	if ctrl, exists := mgr.ctrl[name]; exists {
 		ctrl.Streamer = nil // Drain the current streamer
 	}
 
+	delete(mgr.rates, name)
+
 	var stream beep.Streamer
 	if loop {
-		stream, _ = beep.Loop2(buf.Streamer(0, buf.Len()))
+		looped, _ := beep.Loop2(buf.Streamer(0, buf.Len()))
+		rate := beep.ResampleRatio(3, mgr.tempoRatio(), looped)
+		mgr.rates[name] = rate
+		stream = rate
 	} else {
 		stream = buf.Streamer(0, buf.Len())
 	}
@@ -249,6 +275,32 @@ func (mgr *Manager) PlayWithCallback(name string, onEnd func()) error {
 	return mgr.playInternal(name, false, onEnd)
 }
 
+// PlayAt plays the sample at the given time made up for the output latency, see SetOffset.
+// Samples due already are played right away.
+func (mgr *Manager) PlayAt(name string, at time.Time) {
+	if mgr == nil {
+		return
+	}
+	mgr.mu.Lock()
+	delay := time.Until(at) - mgr.offset
+	mgr.mu.Unlock()
+	if delay <= 0 {
+		mgr.Play(name)
+		return
+	}
+	time.AfterFunc(delay, func() { mgr.Play(name) })
+}
+
+// SetOffset sets the audio output latency, e.g. of a wireless headset, scheduled samples make it up.
+func (mgr *Manager) SetOffset(offset time.Duration) {
+	if mgr == nil {
+		return
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.offset = offset
+}
+
 // PlayLoop plays the sample in a continuous loop until stopped.
 func (mgr *Manager) PlayLoop(name string) error {
 	return mgr.playInternal(name, true, nil)
@@ -323,6 +375,7 @@ func (mgr *Manager) StopListed(names ...string) {
 			ctrl.Streamer = nil
 			delete(mgr.ctrl, name)
 		}
+		delete(mgr.rates, name)
 	}
 }
 
@@ -337,6 +390,50 @@ func (mgr *Manager) StopAll() {
 		ctrl.Streamer = nil
 	}
 	mgr.ctrl = make(map[string]*beep.Ctrl)
+	mgr.rates = make(map[string]*beep.Resampler)
+}
+
+// Tempo returns the music speed-up for the floor: a little faster with every floor away from the ground
+// and faster still once fewer than TensionDots dots are left, like the arcade.
+func Tempo(floor, dotsLeft int) float64 {
+	depth := min(max(floor, -floor), TempoMaxDepth)
+	tempo := 1 + float64(depth)*TempoPerFloor
+	if dotsLeft > 0 && dotsLeft < TensionDots {
+		tempo += TempoTension
+	}
+	return tempo
+}
+
+// SetTempo changes the speed of looping samples, those playing included. 1 is the normal speed.
+func (mgr *Manager) SetTempo(ratio float64) {
+	if mgr == nil {
+		return
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.tempo = ratio
+	for _, rate := range mgr.rates {
+		rate.SetRatio(mgr.tempoRatio())
+	}
+}
+
+// tempoRatio returns the resampling ratio of looping samples.
+func (mgr *Manager) tempoRatio() float64 {
+	if mgr.tempo <= 0 {
+		return 1
+	}
+	return mgr.tempo
+}
+
+// SetLoops enables or disables looping samples such as background music, the energy saver turns them off.
+// Loops already playing go on until stopped.
+func (mgr *Manager) SetLoops(on bool) {
+	if mgr == nil {
+		return
+	}
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	mgr.noLoops = !on
 }
 
 // Mute disables all audio output.
@@ -359,12 +456,37 @@ func (mgr *Manager) Unmute() {
 	mgr.vol.Silent = false
 }
 
+const (
+	probeTimeout = 300 * time.Millisecond // Audio server connection check
+	initTimeout  = 2 * time.Second        // Audio backend start
+)
+
 // Initialize creates and loads a sound manager.
 // It returns the manager and a boolean indicating if initialization failed (and thus should be muted).
+// Without an audio device, e.g. in containers, it gives up quickly and sounds are not loaded at all.
 func Initialize() (*Manager, bool) {
-	soundMgr, err := NewManager(CommonSampleRate)
-	if err != nil {
-		return nil, true // Muted due to init error
+	if err := probeBackend(probeTimeout); err != nil {
+		return nil, true // No audio server
+	}
+	type result struct {
+		mgr *Manager
+		err error
+	}
+	started := make(chan result, 1)
+	go func() {
+		mgr, err := NewManager(CommonSampleRate)
+		started <- result{mgr, err}
+	}()
+	var soundMgr *Manager
+	select {
+	case r := <-started:
+		if r.err != nil {
+			return nil, true // Muted due to init error
+		}
+		soundMgr = r.mgr
+	case <-time.After(initTimeout):
+		// The backend hangs, go on muted. If it ever starts it only plays silence.
+		return nil, true
 	}
 	if err := soundMgr.LoadSamples(); err != nil {
 		return nil, true // Muted due to load error
@@ -0,0 +1,52 @@
+package sound
+
+import (
+	"math"
+	"time"
+
+	"github.com/gopxl/beep/v2"
+)
+
+// Klaxon honks, synthesized on load as there is no sample for them
+const (
+	klaxonHonks  = 3
+	klaxonHonk   = 350 * time.Millisecond
+	klaxonPause  = 120 * time.Millisecond
+	klaxonHigh   = 520.0 // Hz at the start of a honk
+	klaxonLow    = 380.0 // Hz at the end of a honk
+	klaxonVolume = 0.25
+)
+
+// klaxon returns the alarm of falling honks announcing ghost reinforcements.
+// A square wave sweeping down sounds close enough to an old car horn.
+func klaxon(sr beep.SampleRate) beep.Streamer {
+	honk, pause := sr.N(klaxonHonk), sr.N(klaxonPause)
+	total := klaxonHonks * (honk + pause)
+	i, phase := 0, 0.0
+	return beep.StreamerFunc(func(samples [][2]float64) (n int, ok bool) {
+		for n < len(samples) && i < total {
+			v := 0.0
+			if t := i % (honk + pause); t < honk {
+				progress := float64(t) / float64(honk)
+				phase += (klaxonHigh - (klaxonHigh-klaxonLow)*progress) / float64(sr)
+				v = klaxonVolume
+				if math.Sin(2*math.Pi*phase) < 0 {
+					v = -v
+				}
+			}
+			samples[n] = [2]float64{v, v}
+			n++
+			i++
+		}
+		return n, n > 0
+	})
+}
+
+// synthesize adds the samples generated in code.
+func (mgr *Manager) synthesize() {
+	mgr.mu.Lock()
+	defer mgr.mu.Unlock()
+	buf := beep.NewBuffer(mgr.format)
+	buf.Append(klaxon(mgr.format.SampleRate))
+	mgr.samples[KLAXON] = buf
+}
@@ -0,0 +1,259 @@
+// Package speech speaks short lines for game events through a text-to-speech command of the player's
+// choice, such as espeak, say or a PowerShell one-liner for SAPI, so the game can be followed by ear.
+package speech
+
+import (
+	"container/heap"
+	"errors"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	minGap      = 1200 * time.Millisecond // Quiet time between the starts of two lines
+	staleAfter  = 3 * time.Second         // Lines waiting longer are dropped, they are old news
+	queueSize   = 4                       // Lines waiting at most, the least urgent one is dropped
+	placeholder = "{}"                    // Stands for the line in the command template
+)
+
+// Priorities of lines, urgent ones jump the queue
+const (
+	Info = iota
+	Notice
+	Danger
+)
+
+// rule turns events with a prefix or suffix into a spoken line, empty say speaks the event as it is.
+type rule struct {
+	prefix, suffix string
+	say            string
+	priority       int
+}
+
+// rules are checked in order, the first match wins
+var rules = []rule{
+	{prefix: "caught by ", priority: Danger},
+	{suffix: " is behind you", priority: Danger},
+	{suffix: " is ahead", priority: Danger},
+	{suffix: " is beside you", priority: Danger},
+	{prefix: "overheated", say: "overheated!", priority: Danger},
+	{prefix: "ate pellet", say: "power pellet!", priority: Notice},
+	{suffix: " eaten", priority: Notice},
+	{prefix: "reinforcements out", say: "reinforcements!", priority: Notice},
+	{prefix: "low battery", say: "reinforcements!", priority: Notice},
+	{prefix: "brownout", say: "lights out!", priority: Notice},
+	{prefix: "rewound", priority: Notice},
+	{prefix: "entered floor ", priority: Info},
+	{prefix: "checkpoint", priority: Info},
+	{prefix: "got ", priority: Info},
+	{prefix: "found colleague", priority: Info},
+	{prefix: "rescued colleague", priority: Info},
+}
+
+// Line returns what to say for a game event and how urgent it is, ok is false for events left unsaid.
+func Line(event string) (text string, priority int, ok bool) {
+	if i := strings.Index(event, " +"); i > 0 {
+		event = event[:i] // Points are left to the screen
+	}
+	for _, r := range rules {
+		if !strings.HasPrefix(event, r.prefix) || !strings.HasSuffix(event, r.suffix) {
+			continue
+		}
+		text = r.say
+		if text == "" {
+			text = event
+		}
+		return text, r.priority, true
+	}
+	return "", 0, false
+}
+
+// line is a line waiting to be spoken.
+type line struct {
+	text     string
+	priority int
+	at       time.Time
+}
+
+// queue is a priority queue of lines, the most urgent and then the oldest first.
+type queue []line
+
+func (q queue) Len() int { return len(q) }
+func (q queue) Less(i, j int) bool {
+	if q[i].priority != q[j].priority {
+		return q[i].priority > q[j].priority
+	}
+	return q[i].at.Before(q[j].at)
+}
+func (q queue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x any)   { *q = append(*q, x.(line)) }
+func (q *queue) Pop() any {
+	old := *q
+	l := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return l
+}
+
+// Speaker speaks lines one at a time. A nil *Speaker says nothing, all methods are nil-safe.
+type Speaker struct {
+	command []string
+	run     func(args []string) error // runs the command, replaced in tests
+	now     func() time.Time
+
+	mu     sync.Mutex
+	queue  queue
+	wake   chan struct{}
+	closed bool
+}
+
+// ErrNoCommand is returned for an empty command template.
+var ErrNoCommand = errors.New("no speech command")
+
+// New returns a speaker running the command template for every line. {} in the template stands
+// for the line, without it the line is added as the last argument, e.g. "espeak -s 170", "say -v Samantha {}"
+// or powershell -c "(New-Object -ComObject SAPI.SpVoice).Speak('{}')". The command runs without a shell,
+// double quotes keep an argument with spaces together.
+func New(template string) (*Speaker, error) {
+	command := fields(template)
+	if len(command) == 0 {
+		return nil, ErrNoCommand
+	}
+	if _, err := exec.LookPath(command[0]); err != nil {
+		return nil, err
+	}
+	s := newSpeaker(command, func(args []string) error {
+		return exec.Command(args[0], args[1:]...).Run()
+	})
+	go s.loop()
+	return s, nil
+}
+
+// fields splits the template into arguments at spaces outside of double quotes.
+func fields(template string) []string {
+	var args []string
+	var b strings.Builder
+	quoted, started := false, false
+	for _, r := range template {
+		switch {
+		case r == '"':
+			quoted, started = !quoted, true
+		case (r == ' ' || r == '\t') && !quoted:
+			if started {
+				args = append(args, b.String())
+				b.Reset()
+			}
+			started = false
+		default:
+			b.WriteRune(r)
+			started = true
+		}
+	}
+	if started {
+		args = append(args, b.String())
+	}
+	return args
+}
+
+func newSpeaker(command []string, run func(args []string) error) *Speaker {
+	return &Speaker{
+		command: command,
+		run:     run,
+		now:     time.Now,
+		wake:    make(chan struct{}, 1),
+	}
+}
+
+// Say queues the line for the event, if the event has one. It never waits for the speech.
+func (s *Speaker) Say(event string) {
+	if s == nil {
+		return
+	}
+	text, priority, ok := Line(event)
+	if !ok {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	heap.Push(&s.queue, line{text: text, priority: priority, at: s.now()})
+	if s.queue.Len() > queueSize {
+		s.dropLeastUrgent()
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default: // The loop is awake already
+	}
+}
+
+// dropLeastUrgent removes the least urgent line, the newest of them if several are as urgent.
+func (s *Speaker) dropLeastUrgent() {
+	worst := 0
+	for i := range s.queue {
+		if s.queue.Less(worst, i) {
+			worst = i
+		}
+	}
+	heap.Remove(&s.queue, worst)
+}
+
+// next returns the most urgent line that is still news, ok is false if there is none.
+func (s *Speaker) next() (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for s.queue.Len() > 0 {
+		l := heap.Pop(&s.queue).(line)
+		if s.now().Sub(l.at) <= staleAfter {
+			return l.text, true
+		}
+	}
+	return "", false
+}
+
+// args returns the command speaking the text.
+func (s *Speaker) args(text string) []string {
+	args := make([]string, 0, len(s.command)+1)
+	said := false
+	for _, a := range s.command {
+		if strings.Contains(a, placeholder) {
+			a = strings.ReplaceAll(a, placeholder, text)
+			said = true
+		}
+		args = append(args, a)
+	}
+	if !said {
+		args = append(args, text)
+	}
+	return args
+}
+
+// loop speaks the queued lines until the speaker is closed, leaving at least minGap between their starts.
+func (s *Speaker) loop() {
+	for range s.wake {
+		for {
+			text, ok := s.next()
+			if !ok {
+				break
+			}
+			start := s.now()
+			s.run(s.args(text)) // A failing command stays quiet, the game goes on
+			time.Sleep(minGap - s.now().Sub(start))
+		}
+	}
+}
+
+// Close stops speaking once the current line is over.
+func (s *Speaker) Close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closed {
+		s.closed = true
+		close(s.wake)
+	}
+}
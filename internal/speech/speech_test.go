@@ -0,0 +1,70 @@
+package speech
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLine(t *testing.T) {
+	tests := []struct {
+		event    string
+		text     string
+		priority int
+		ok       bool
+	}{
+		{"ate pellet", "power pellet!", Notice, true},
+		{"Curly is behind you", "Curly is behind you", Danger, true},
+		{"Lofty eaten +400", "Lofty eaten", Notice, true},
+		{"pulled lever", "", 0, false},
+	}
+	for _, tt := range tests {
+		text, priority, ok := Line(tt.event)
+		if text != tt.text || priority != tt.priority || ok != tt.ok {
+			t.Errorf("Line(%q) = %q, %d, %v, want %q, %d, %v", tt.event, text, priority, ok, tt.text, tt.priority, tt.ok)
+		}
+	}
+}
+
+func TestQueue(t *testing.T) {
+	now := time.Now()
+	s := newSpeaker([]string{"espeak"}, nil)
+	s.now = func() time.Time { return now }
+	s.Say("got camera")
+	now = now.Add(time.Millisecond)
+	s.Say("ate pellet")
+	now = now.Add(time.Millisecond)
+	s.Say("Curly is behind you")
+	for _, want := range []string{"Curly is behind you", "power pellet!", "got camera"} {
+		if got, ok := s.next(); !ok || got != want {
+			t.Errorf("next() = %q, %v, want %q", got, ok, want)
+		}
+	}
+
+	for range queueSize {
+		s.Say("ate pellet")
+	}
+	s.Say("got camera") // Dropped, the queue is full of more urgent lines
+	for range queueSize {
+		if got, _ := s.next(); got != "power pellet!" {
+			t.Errorf("next() = %q, want power pellet!", got)
+		}
+	}
+
+	s.Say("ate pellet")
+	now = now.Add(staleAfter + time.Second)
+	if got, ok := s.next(); ok {
+		t.Errorf("next() = %q, want stale lines dropped", got)
+	}
+}
+
+func TestArgs(t *testing.T) {
+	s := newSpeaker([]string{"espeak", "-s", "170"}, nil)
+	if got, want := s.args("boo"), []string{"espeak", "-s", "170", "boo"}; !slices.Equal(got, want) {
+		t.Errorf("args() = %q, want %q", got, want)
+	}
+	s = newSpeaker(fields(`powershell  -c "(New-Object -ComObject SAPI.SpVoice).Speak('{}')"`), nil)
+	if got, want := s.args("boo"), []string{"powershell", "-c", "(New-Object -ComObject SAPI.SpVoice).Speak('boo')"}; !slices.Equal(got, want) {
+		t.Errorf("args() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,104 @@
+package state
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/pbkdf2"
+)
+
+// A save file locked with a passphrase starts with passMagic and the salt of its key,
+// the rest is sealed like any other save file. The key doesn't depend on the machine,
+// so such saves survive restoring a backup onto new hardware.
+var passMagic = []byte("HNTPASS1")
+
+const (
+	saltSize       = 16
+	passIterations = 200_000 // PBKDF2 rounds
+	passTries      = 3       // Passphrase prompts before giving up
+)
+
+// ErrPassphrase is returned when no passphrase is given or the given one doesn't open the save file.
+var ErrPassphrase = errors.New("wrong or missing save passphrase")
+
+var (
+	askPassphrase      func(newKey bool) (string, error)
+	rememberPassphrase func(passphrase string)
+
+	// Key of the session, asked once
+	passKey  []byte
+	passSalt []byte
+)
+
+// SetPassphrase sets where save passphrases come from: ask returns the passphrase, newKey is set
+// when one is being chosen, and remember is called with the passphrase that locked or opened the save file.
+// Without it saves locked with a passphrase can't be opened.
+func SetPassphrase(ask func(newKey bool) (string, error), remember func(passphrase string)) {
+	askPassphrase = ask
+	rememberPassphrase = remember
+}
+
+// sealPass encrypts the save file with the passphrase key, the passphrase is asked for once per session.
+func sealPass(data []byte) ([]byte, error) {
+	if passKey == nil {
+		if askPassphrase == nil {
+			return nil, ErrPassphrase
+		}
+		passphrase, err := askPassphrase(true)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrPassphrase, err)
+		}
+		salt := make([]byte, saltSize)
+		if _, err := rand.Read(salt); err != nil {
+			return nil, err
+		}
+		passKey, passSalt = deriveKey(passphrase, salt, passIterations), salt
+		if rememberPassphrase != nil {
+			rememberPassphrase(passphrase)
+		}
+	}
+	sealed, err := encrypt(passKey, data)
+	if err != nil {
+		return nil, err
+	}
+	return append(append(append([]byte{}, passMagic...), passSalt...), sealed...), nil
+}
+
+// openPass decrypts a save file locked with a passphrase. The passphrase is asked for
+// up to passTries times unless the key of the session fits.
+func openPass(data []byte) ([]byte, error) {
+	data = data[len(passMagic):]
+	if len(data) < saltSize {
+		return nil, errors.New("save file too short")
+	}
+	salt, sealed := data[:saltSize], data[saltSize:]
+	if passKey != nil && bytes.Equal(salt, passSalt) {
+		return decrypt(passKey, sealed) // The key is right, the file is damaged if it fails
+	}
+	if askPassphrase == nil {
+		return nil, ErrPassphrase
+	}
+	for range passTries {
+		passphrase, err := askPassphrase(false)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrPassphrase, err)
+		}
+		key := deriveKey(passphrase, salt, passIterations)
+		if plain, err := decrypt(key, sealed); err == nil {
+			passKey, passSalt = key, bytes.Clone(salt)
+			if rememberPassphrase != nil {
+				rememberPassphrase(passphrase)
+			}
+			return plain, nil
+		}
+	}
+	return nil, ErrPassphrase
+}
+
+// deriveKey derives a 32-byte AES key from the passphrase with PBKDF2-HMAC-SHA256.
+func deriveKey(passphrase string, salt []byte, iterations int) []byte {
+	return pbkdf2.Key([]byte(passphrase), salt, iterations, 32, sha256.New)
+}
@@ -0,0 +1,97 @@
+package state
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/vinser/haunteed/internal/paths"
+)
+
+// Settings are the choices of the player. They are kept apart from the progress as readable JSON
+// in the config directory, so they can be edited, shared and kept in a dotfiles repo.
+type Settings struct {
+	GameMode      string   `json:"game_mode"`       // Current game mode: easy, noisy or crazy
+	NightOption   string   `json:"crazy_night"`     // Night option for crazy mode: never, always or real
+	SpriteSize    string   `json:"sprite_size"`     // Sprite size: small, medium, large
+	Mute          bool     `json:"mute"`            // Mute all sounds
+	TurnBased     bool     `json:"turn_based"`      // Puzzle variant: time only advances when the haunteed moves
+	Weekly        bool     `json:"weekly"`          // Play with the modifiers of the week
+	Retro         bool     `json:"retro"`           // Left-right symmetric mazes with the den in the middle, like the arcade
+	SpeedBonus    bool     `json:"speed_bonus"`     // Handicap: ghosts step slower than the haunteed, see score.Multiplier
+	ExtraLives    int      `json:"extra_lives"`     // Handicap: lives to start a run with on top of the mode ones, up to MaxExtraLives
+	LongFright    bool     `json:"long_fright"`     // Handicap: ghosts stay frightened longer after a power pellet
+	RepeatMs      int      `json:"repeat_ms"`       // Auto-repeat anticheat threshold in milliseconds, 0 is the default
+	DebounceMs    int      `json:"debounce_ms"`     // Input debounce in milliseconds, 0 is off
+	StickySteps   int      `json:"sticky_steps"`    // Cells moved by a single key press, 0 or 1 is off
+	UIScale       string   `json:"ui_scale"`        // UI scale: normal or large (banner titles and high contrast)
+	Telemetry     bool     `json:"telemetry"`       // Opt-in to collect anonymous gameplay stats locally
+	UpdateCheck   bool     `json:"update_check"`    // Opt-in to check for a newer release once a day
+	TermTitle     bool     `json:"term_title"`      // Show the floor and the score in the terminal title
+	QuickBar      []string `json:"quick_bar"`       // Actions bound to the number keys 1-9, empty is QuickActions
+	SaverBelow    int      `json:"saver_below"`     // Battery percent the energy saver kicks in at when unplugged, 0 is off
+	AudioOffsetMs int      `json:"audio_offset_ms"` // Audio output latency in milliseconds made up by scheduled sounds
+	Mouse         bool     `json:"mouse"`           // Mouse wheel scrolling and clicks in menus and the paused map
+	Bell          bool     `json:"bell"`            // Ring terminal bell patterns for key events, for terminals without audio
+	Speech        string   `json:"speech"`          // Text-to-speech command template speaking game events, empty is off
+	Initials      bool     `json:"initials"`        // Enter high score nicknames as three arcade initials
+	Splash        string   `json:"splash"`          // Splash screen: auto (skipped after SplashSkipsAuto skips in a row) or always
+	FameIdleMin   int      `json:"fame_idle_min"`   // Minutes idle on the splash or game over screen before the hall of fame shows, 0 is off
+	HostEvents    bool     `json:"host_events"`     // Power failure events driven by the load, disk and battery of the real machine
+	Plaintext     bool     `json:"plaintext"`       // Save as readable JSON instead of encrypting, to keep it in a dotfiles repo
+	Passphrase    bool     `json:"passphrase"`      // Encrypt with a passphrase key instead of the machine ID one, so saves survive new hardware
+}
+
+// DefaultSettings returns the settings of a new player.
+func DefaultSettings() Settings {
+	return Settings{
+		GameMode:    ModeDefault,
+		NightOption: NightDefault,
+		SpriteSize:  SpriteDefault,
+		UIScale:     UIScaleDefault,
+		SaverBelow:  SaverDefault,
+		Splash:      SplashDefault,
+	}
+}
+
+// SettingsPath returns the path to the settings file.
+func SettingsPath() (string, error) {
+	return paths.Config.File("settings.json")
+}
+
+// saveSettings writes the settings file in full before it takes the place of the old one.
+func (s *State) saveSettings() error {
+	path, err := SettingsPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s.Settings, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := writeTemp(path, append(data, '\n'))
+	if err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// loadSettings reads the settings file over the settings of s, those it doesn't have are kept.
+// Older versions kept the settings in the save file, they are read from there until the settings file is written.
+func (s *State) loadSettings() {
+	path, err := SettingsPath()
+	if err != nil {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	settings := s.Settings
+	if err := json.Unmarshal(data, &settings); err == nil {
+		s.Settings = settings
+	}
+}
@@ -1,6 +1,7 @@
 package state
 
 import (
+	"bytes"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -8,14 +9,17 @@ import (
 	"encoding/binary"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"hash/crc32"
 	"os"
 	"path/filepath"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/denisbrodbeck/machineid"
 	"github.com/vinser/haunteed/internal/geoip"
+	"github.com/vinser/haunteed/internal/paths"
 )
 
 // HighScore holds a single high score entry.
@@ -28,16 +32,65 @@ type HighScore struct {
 // State holds persistent game data such as high scores.
 
 type State struct {
-	Version      string             `json:"version"`       // Version of the app when the state was last saved
-	GameMode     string             `json:"game_mode"`     // Current game mode: easy, noisy or crazy
-	NightOption  string             `json:"crazy_night"`   // Night option for crazy mode: never, always or real
-	SpriteSize   string             `json:"sprite_size"`   // Sprite size: small, medium, large
-	Mute         bool               `json:"mute"`          // Mute all sounds
-	FloorSeeds   map[int]int64      `json:"floor_seeds"`   // Seed for each floor to reproduce the same sequence of mazes
-	EasyScores   []HighScore        `json:"easy_scores"`   // Easy mode high score
-	NoisyScores  []HighScore        `json:"noisy_scores"`  // Noisy mode high score
-	CrazyScores  []HighScore        `json:"crazy_scores"`  // Crazy mode high score
-	LocationInfo geoip.LocationInfo `json:"location_info"` // Location information
+	Settings `json:"-"` // Kept in the settings file of the config directory, see Load
+
+	Version      string                 `json:"version"`       // Version of the app when the state was last saved
+	SplashSkips  int                    `json:"splash_skips"`  // Splash screens skipped in a row
+	CheckedAt    time.Time              `json:"checked_at"`    // Last update check
+	Latest       string                 `json:"latest"`        // Latest released version found by the update check
+	FloorSeeds   map[int]int64          `json:"floor_seeds"`   // Seed for each floor to reproduce the same sequence of mazes
+	FloorEnds    map[int]FloorEnds      `json:"floor_ends"`    // Connection points each floor was last carved around, see FloorEnds
+	EasyScores   []HighScore            `json:"easy_scores"`   // Easy mode high score
+	NoisyScores  []HighScore            `json:"noisy_scores"`  // Noisy mode high score
+	CrazyScores  []HighScore            `json:"crazy_scores"`  // Crazy mode high score
+	PuzzleScores map[string][]HighScore `json:"puzzle_scores"` // Puzzle variant high scores by game mode
+	WeeklyScores map[string][]HighScore `json:"weekly_scores"` // High scores of this week's modifiers, see weeklyKey
+	LocationInfo geoip.LocationInfo     `json:"-"`             // Location of the IP address, kept in the cache directory, see loadLocation
+	Gallery      []Evidence             `json:"gallery"`       // Ghost photos, the latest last
+	GhostsMet    map[string]int         `json:"ghosts_met"`    // Encounters by ghost name, unlock bestiary entries
+	GhostsEaten  map[string]int         `json:"ghosts_eaten"`  // Eaten ghosts by name, reveal their weaknesses in the bestiary
+	Challenges   map[string]int64       `json:"challenges"`    // Best clear time of each challenge in milliseconds
+	Runs         []Run                  `json:"runs"`          // Finished runs, the latest last
+	Ectoplasm    int                    `json:"ectoplasm"`     // Earned by runs, spent on looks in the vault
+	Unlocked     []string               `json:"unlocked"`      // Looks bought in the vault
+	Worn         map[string]string      `json:"worn"`          // Looks worn by kind
+
+	Recovered string `json:"-"` // Backup the state was restored from when the save file was damaged, not saved
+	Locked    bool   `json:"-"` // The save file is locked with a passphrase that wasn't given, nothing is saved
+	Streamer  bool   `json:"-"` // Personal details are hidden on screen for streaming, set by --streamer only
+}
+
+// Point is a cell of a floor.
+type Point struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+// FloorEnds are the connection points a floor was carved around, nil if the maze picked them itself.
+// With the floor seed they carve the same maze again, e.g. for practice.
+type FloorEnds struct {
+	Start *Point `json:"start,omitempty"`
+	End   *Point `json:"end,omitempty"`
+}
+
+// Run sums up a finished run for the share card.
+type Run struct {
+	Mode    string         `json:"mode"`  // Mode name of the run, see ModeName
+	Floor   int            `json:"floor"` // Deepest floor reached
+	Score   int            `json:"score"`
+	Eaten   map[string]int `json:"eaten"` // Eaten ghosts by name
+	Deaths  int            `json:"deaths"`
+	Photos  int            `json:"photos"` // Kinds of ghosts photographed
+	Insured bool           `json:"insured"`
+	EndedAt time.Time      `json:"ended_at"`
+}
+
+// Evidence is a photo of a ghost taken with a camera.
+type Evidence struct {
+	Ghost    string    `json:"ghost"`
+	Floor    int       `json:"floor"`
+	TakenAt  time.Time `json:"taken_at"`
+	Snapshot []string  `json:"snapshot"` // plain text picture of the ghost surroundings
 }
 
 const (
@@ -59,7 +112,42 @@ const (
 	SpriteLarge   = "large"
 	SpriteDefault = SpriteMedium
 
+	// UI scales
+	UIScaleNormal  = "normal"
+	UIScaleLarge   = "large"
+	UIScaleDefault = UIScaleNormal
+
+	// Input defaults
+	RepeatDefault = 100 // Anticheat
+
+	// Energy saver
+	SaverDefault = 20 // Battery percent
+
+	// Handicaps
+	MaxExtraLives = 3
+
+	// Audio latency calibration
+	MaxAudioOffsetMs = 500
+
+	// Splash screen
+	SplashAuto      = "auto"
+	SplashAlways    = "always"
+	SplashDefault   = SplashAuto
+	SplashSkipsAuto = 3 // Skips in a row after which the auto splash is skipped for good
+
+	// Quick actions, see QuickBarActions
+	ActionGuide      = "guide"
+	ActionTrap       = "trap"
+	ActionFuse       = "fuse"
+	ActionRewind     = "rewind"
+	ActionCamera     = "camera"
+	ActionFlashlight = "flashlight"
+	ActionLog        = "log"
+	MaxQuickBar      = 9 // Number keys 1-9
+
 	maxHighScores = 5
+	maxGallery    = 20
+	maxRuns       = 20
 )
 
 var encryptionKey = generateKey()
@@ -74,19 +162,92 @@ func generateKey() []byte {
 	return sum[:]
 }
 
+// QuickActions are the actions of the quick-action bar in the default key order
+var QuickActions = []string{ActionGuide, ActionTrap, ActionFuse, ActionRewind, ActionCamera, ActionFlashlight, ActionLog}
+
+// QuickBarActions returns the actions bound to the number keys, the first one to 1.
+func (s *State) QuickBarActions() []string {
+	if len(s.QuickBar) == 0 {
+		return QuickActions
+	}
+	return s.QuickBar
+}
+
 // SetMute toggles the mute state.
 func (s *State) SetMute(mute bool) {
 	s.Mute = mute
 }
 
+// RepeatThreshold returns the period in which the same key pressed again is treated as auto-repeat.
+func (s *State) RepeatThreshold() time.Duration {
+	if s.RepeatMs <= 0 {
+		return RepeatDefault * time.Millisecond
+	}
+	return time.Duration(s.RepeatMs) * time.Millisecond
+}
+
+// SkipSplash returns true if the player skipped the splash often enough to go straight to the game.
+func (s *State) SkipSplash() bool {
+	return s.Splash != SplashAlways && s.SplashSkips >= SplashSkipsAuto
+}
+
+// AudioOffset returns the audio output latency scheduled sounds make up for.
+func (s *State) AudioOffset() time.Duration {
+	return time.Duration(min(s.AudioOffsetMs, MaxAudioOffsetMs)) * time.Millisecond
+}
+
+// InputDebounce returns the period in which any key pressed after an accepted one is ignored.
+func (s *State) InputDebounce() time.Duration {
+	return time.Duration(s.DebounceMs) * time.Millisecond
+}
+
+// ModeName returns the game mode as shown to the player, the puzzle variant and the weekly modifiers have their own leaderboards.
+func (s *State) ModeName() string {
+	name := s.GameMode
+	if s.TurnBased {
+		name += " puzzle"
+	}
+	if s.Weekly {
+		name += " weekly"
+	}
+	return name
+}
+
+// weeklyKey returns the key of the weekly leaderboard of the game mode, e.g. "2026-W42 easy puzzle".
+func (s *State) weeklyKey(t time.Time) string {
+	year, week := t.ISOWeek()
+	name := s.GameMode
+	if s.TurnBased {
+		name += " puzzle"
+	}
+	return fmt.Sprintf("%d-W%02d %s", year, week, name)
+}
+
 // UpdateAndSave updates the state with new game results and persists it to a file.
 func (s *State) UpdateAndSave(floor int, score int, seed int64, nick string) error {
-	switch s.GameMode {
-	case ModeEasy:
+	switch {
+	case s.Weekly:
+		key := s.weeklyKey(time.Now())
+		week, _, _ := strings.Cut(key, " ")
+		for k := range s.WeeklyScores {
+			if !strings.HasPrefix(k, week+" ") {
+				delete(s.WeeklyScores, k) // Boards of past weeks are dropped
+			}
+		}
+		if s.WeeklyScores == nil {
+			s.WeeklyScores = make(map[string][]HighScore)
+		}
+		s.WeeklyScores[key] = updateHighScores(s.WeeklyScores[key], score, nick)
+	case s.TurnBased:
+		if s.PuzzleScores == nil {
+			s.PuzzleScores = make(map[string][]HighScore)
+		}
+		s.PuzzleScores[s.GameMode] = updateHighScores(s.PuzzleScores[s.GameMode], score, nick)
+	case s.GameMode == ModeEasy:
 		s.EasyScores = updateHighScores(s.EasyScores, score, nick)
-	case ModeNoisy:
+	case s.GameMode == ModeNoisy:
 		s.NoisyScores = updateHighScores(s.NoisyScores, score, nick)
-	case ModeCrazy:
+	case s.GameMode == ModeCrazy:
 		s.CrazyScores = updateHighScores(s.CrazyScores, score, nick)
 	}
 	// Ensure the seed for the current floor is saved if it's new.
@@ -94,6 +255,74 @@ func (s *State) UpdateAndSave(floor int, score int, seed int64, nick string) err
 	return s.Save()
 }
 
+// AddEvidence puts a photo into the gallery, the oldest photos are dropped when it is full.
+func (s *State) AddEvidence(e Evidence) {
+	s.Gallery = append(s.Gallery, e)
+	if len(s.Gallery) > maxGallery {
+		s.Gallery = s.Gallery[len(s.Gallery)-maxGallery:]
+	}
+}
+
+// AddRun puts a finished run into the history, the oldest runs are dropped when it is full.
+func (s *State) AddRun(r Run) {
+	s.Runs = append(s.Runs, r)
+	if len(s.Runs) > maxRuns {
+		s.Runs = s.Runs[len(s.Runs)-maxRuns:]
+	}
+}
+
+// LastRun returns the latest finished run, if any.
+func (s *State) LastRun() (Run, bool) {
+	if len(s.Runs) == 0 {
+		return Run{}, false
+	}
+	return s.Runs[len(s.Runs)-1], true
+}
+
+// DeepestRun returns the deepest floor reached by the finished runs of the mode, if any.
+func (s *State) DeepestRun(mode string) (int, bool) {
+	deepest, ok := 0, false
+	for _, r := range s.Runs {
+		if r.Mode == mode && (!ok || r.Floor > deepest) {
+			deepest, ok = r.Floor, true
+		}
+	}
+	return deepest, ok
+}
+
+// RecordSighting counts an encounter with a ghost, or the ghost being eaten.
+func (s *State) RecordSighting(ghost string, eaten bool) {
+	if eaten {
+		if s.GhostsEaten == nil {
+			s.GhostsEaten = make(map[string]int)
+		}
+		s.GhostsEaten[ghost]++
+		return
+	}
+	if s.GhostsMet == nil {
+		s.GhostsMet = make(map[string]int)
+	}
+	s.GhostsMet[ghost]++
+}
+
+// RecordChallenge keeps the clear time of the challenge if it is the best so far and returns true then.
+func (s *State) RecordChallenge(id string, d time.Duration) bool {
+	if best, ok := s.Challenges[id]; ok && best <= d.Milliseconds() {
+		return false
+	}
+	if s.Challenges == nil {
+		s.Challenges = make(map[string]int64)
+	}
+	s.Challenges[id] = d.Milliseconds()
+	return true
+}
+
+// ChallengeBest returns the best clear time of the challenge and whether it was ever cleared.
+func (s *State) ChallengeBest(id string) (time.Duration, bool) {
+	best, ok := s.Challenges[id]
+	return time.Duration(best) * time.Millisecond, ok
+}
+
 func updateHighScores(scores []HighScore, newScore int, newNick string) []HighScore {
 	if newNick == "" {
 		newNick = "nowhere man (aka rootless)"
@@ -114,17 +343,75 @@ func updateHighScores(scores []HighScore, newScore int, newNick string) []HighSc
 	return scores
 }
 
-// Save persists the current state to an encrypted file with an integrity check.
+// Save persists the settings to the settings file and the progress to an encrypted file with an integrity check.
+// The file is written in full next to the save file before it takes its place, so a crash never leaves half of it.
 func (s *State) Save() error {
+	if s.Locked {
+		return nil // Never overwrite the save file that couldn't be opened
+	}
 	path, err := getSavePath()
 	if err != nil {
 		return err
 	}
 
+	if err := s.saveSettings(); err != nil {
+		return err
+	}
+	data, err := s.encode()
+	if err != nil {
+		return err
+	}
+
+	tmp, err := writeTemp(path, data)
+	if err != nil {
+		return err
+	}
+	rotate(path)
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	return nil
+}
+
+// writeTemp writes data to a new file in the directory of path and syncs it to disk, it returns the file name.
+func writeTemp(path string, data []byte) (string, error) {
+	f, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp*")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(data)
+	if err == nil {
+		err = f.Chmod(0644)
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// encode returns the save file contents: encrypted JSON with a checksum,
+// or pretty-printed JSON in plaintext mode.
+func (s *State) encode() ([]byte, error) {
+	if s.Plaintext {
+		raw, err := json.MarshalIndent(s, "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		return append(raw, '\n'), nil
+	}
+
 	// Serialize to JSON
 	raw, err := json.Marshal(s)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	// Prepend CRC32 checksum
@@ -134,13 +421,30 @@ func (s *State) Save() error {
 	copy(data[4:], raw)
 
 	// Encrypt
-	encrypted, err := encrypt(data)
-	if err != nil {
-		return err
+	if s.Passphrase {
+		return sealPass(data)
 	}
+	return encrypt(encryptionKey, data)
+}
+
+// Backups is the number of previous save files kept next to the save file as state.dat.1 (the newest) and so on.
+const Backups = 3
 
-	// Save to file
-	return os.WriteFile(path, encrypted, 0644)
+// backupPath returns the path of the n-th backup of the save file.
+func backupPath(path string, n int) string {
+	return fmt.Sprintf("%s.%d", path, n)
+}
+
+// rotate shifts the backups by one and makes the save file the newest backup.
+// Failures are ignored, a missed backup must not stop the game from saving.
+func rotate(path string) {
+	if _, err := os.Stat(path); err != nil {
+		return
+	}
+	for n := Backups - 1; n > 0; n-- {
+		os.Rename(backupPath(path, n), backupPath(path, n+1))
+	}
+	os.Rename(path, backupPath(path, 1))
 }
 
 var fallbackLocation = &geoip.LocationInfo{
@@ -154,75 +458,153 @@ var fallbackLocation = &geoip.LocationInfo{
 	TimeStamp: time.Now(),
 }
 
+// loadLocation returns the location of the IP address kept in the cache directory.
+// It is looked up and kept there if there is none yet, the fallback one is used if the lookup fails.
+func loadLocation() geoip.LocationInfo {
+	path, err := paths.Cache.File("location.json")
+	if err != nil {
+		return *fallbackLocation
+	}
+	var loc geoip.LocationInfo
+	if data, err := os.ReadFile(path); err == nil && json.Unmarshal(data, &loc) == nil && loc.Timezone != "" {
+		return loc
+	}
+	found, err := geoip.GetLocationInfo()
+	if err != nil {
+		return *fallbackLocation
+	}
+	if data, err := json.Marshal(found); err == nil {
+		os.WriteFile(path, data, 0644) // A cache, it is looked up again if it is lost
+	}
+	return *found
+}
+
 func New(appVersion string) *State {
 	seeds := make(map[int]int64)
 	seeds[0] = time.Now().UnixNano()
-	loc, err := geoip.GetLocationInfo()
-	if err != nil {
-		loc = fallbackLocation
-	}
 	s := &State{
 		Version:      appVersion,
-		GameMode:     ModeDefault,
-		NightOption:  NightDefault,
-		SpriteSize:   SpriteDefault,
+		Settings:     DefaultSettings(),
 		FloorSeeds:   seeds,
-		LocationInfo: *loc,
+		LocationInfo: loadLocation(),
 	}
 	return s
 }
 
-// Load reads the state from disk, decrypts and verifies it.
+// Load reads the progress from the save file and the settings from the settings file.
+// A damaged, missing or unreadable save file is replaced by the newest valid backup, see Recovered.
 func Load(appVersion string) *State {
-	s := &State{}
+	s := loadProgress(appVersion)
+	s.loadSettings()
+	return s
+}
 
+// loadProgress reads the save file, decrypts and verifies it.
+func loadProgress(appVersion string) *State {
 	path, err := getSavePath()
 	if err != nil {
 		return New(appVersion)
 	}
 
+	var s *State
 	encrypted, err := os.ReadFile(path)
+	if err == nil {
+		s, err = decode(encrypted)
+	}
+	if errors.Is(err, ErrPassphrase) {
+		// Backups are locked with the same passphrase, and the save file must not be overwritten
+		s = New(appVersion)
+		s.Locked = true
+		return s
+	}
 	if err != nil {
+		s = loadBackup(path)
+	}
+	if s == nil {
 		return New(appVersion)
 	}
+	s.Version = appVersion // Saved by this version from now on
+	s.LocationInfo = loadLocation()
 
-	decrypted, err := decrypt(encrypted)
-	if err != nil || len(decrypted) < 5 {
-		return New(appVersion)
+	return s
+}
+
+// loadBackup returns the state of the newest valid backup or nil if there is none.
+func loadBackup(path string) *State {
+	for n := 1; n <= Backups; n++ {
+		encrypted, err := os.ReadFile(backupPath(path, n))
+		if err != nil {
+			continue
+		}
+		if s, err := decode(encrypted); err == nil {
+			s.Recovered = filepath.Base(backupPath(path, n))
+			return s
+		}
+	}
+	return nil
+}
+
+// decode reads the save file contents in either format: plaintext JSON as is,
+// otherwise it decrypts them and verifies the checksum.
+func decode(encrypted []byte) (*State, error) {
+	if plain := bytes.TrimSpace(encrypted); len(plain) > 0 && plain[0] == '{' && json.Valid(plain) {
+		s := &State{Settings: DefaultSettings()}
+		if err := json.Unmarshal(plain, s); err != nil {
+			return nil, err
+		}
+		json.Unmarshal(plain, &s.Settings) // Saved with the progress by older versions, see loadSettings
+		return s, nil
+	}
+
+	var decrypted []byte
+	var err error
+	if bytes.HasPrefix(encrypted, passMagic) {
+		decrypted, err = openPass(encrypted)
+	} else {
+		decrypted, err = decrypt(encryptionKey, encrypted)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(decrypted) < 5 {
+		return nil, errors.New("save file too short")
 	}
 
 	crcStored := binary.LittleEndian.Uint32(decrypted[:4])
 	payload := decrypted[4:]
 	if crc32.ChecksumIEEE(payload) != crcStored {
-		return New(appVersion)
+		return nil, errors.New("save file checksum mismatch")
 	}
 
 	// Unmarshal into the current state struct
-	if err = json.Unmarshal(payload, s); err != nil {
-		return New(appVersion) // Corrupted JSON
+	s := &State{Settings: DefaultSettings()}
+	if err := json.Unmarshal(payload, s); err != nil {
+		return nil, err // Corrupted JSON
 	}
-
-	return s
+	json.Unmarshal(payload, &s.Settings) // Saved with the progress by older versions, see loadSettings
+	return s, nil
 }
 
+// Reset removes the save file and the settings file.
 func Reset() error {
-	path, err := getSavePath()
-	if err != nil {
-		return err
-	}
-	// os.Remove returns an error if the file does not exist, which is fine.
-	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil
+	for _, file := range []func() (string, error){getSavePath, SettingsPath} {
+		path, err := file()
+		if err != nil {
+			return err
+		}
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return err
+		}
 	}
-	return os.Remove(path)
+	return nil
 }
 
 // ======================
 // 🔐 AES Encryption
 // ======================
 
-func encrypt(plain []byte) ([]byte, error) {
-	block, err := aes.NewCipher(encryptionKey)
+func encrypt(key, plain []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -237,8 +619,8 @@ func encrypt(plain []byte) ([]byte, error) {
 	return gcm.Seal(nonce, nonce, plain, nil), nil
 }
 
-func decrypt(ciphertext []byte) ([]byte, error) {
-	block, err := aes.NewCipher(encryptionKey)
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	block, err := aes.NewCipher(key)
 	if err != nil {
 		return nil, err
 	}
@@ -252,21 +634,42 @@ func decrypt(ciphertext []byte) ([]byte, error) {
 	return gcm.Open(nil, ciphertext[:gcm.NonceSize()], ciphertext[gcm.NonceSize():], nil)
 }
 
-// getSavePath returns the path to the save file inside the user config directory.
+// SavePath returns the path to the save file.
+func SavePath() (string, error) {
+	return getSavePath()
+}
+
+// getSavePath returns the path to the save file inside the data directory.
 func getSavePath() (string, error) {
-	configDir, err := os.UserConfigDir()
-	if err != nil {
-		return "", err
+	return paths.Data.File("state.dat")
+}
+
+// SetFloorEnds keeps the connection points the floor was carved around.
+func (s *State) SetFloorEnds(index int, ends FloorEnds) {
+	if s.FloorEnds == nil {
+		s.FloorEnds = make(map[int]FloorEnds)
 	}
-	saveDir := filepath.Join(configDir, "haunteed")
-	if err := os.MkdirAll(saveDir, 0755); err != nil {
-		return "", err
+	s.FloorEnds[index] = ends
+}
+
+// ReachedFloors returns indexes of floors with known seeds sorted from the deepest basement to the top.
+func (s *State) ReachedFloors() []int {
+	var floors []int
+	for index := range s.FloorSeeds {
+		floors = append(floors, index)
 	}
-	return filepath.Join(saveDir, "state.dat"), nil
+	sort.Ints(floors)
+	return floors
 }
 
 func (s *State) GetHighScores() []HighScore {
 	var scores []HighScore
+	if s.Weekly {
+		return s.WeeklyScores[s.weeklyKey(time.Now())]
+	}
+	if s.TurnBased {
+		return s.PuzzleScores[s.GameMode]
+	}
 	switch s.GameMode {
 	case ModeEasy:
 		scores = s.EasyScores
@@ -0,0 +1,240 @@
+package state
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/vinser/haunteed/internal/paths"
+)
+
+func TestBackups(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	path, err := SavePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for score := 1; score <= Backups+2; score++ {
+		s := New("test")
+		s.EasyScores = []HighScore{{Nick: "t", Score: score}}
+		if err := s.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for n := 1; n <= Backups; n++ {
+		if _, err := os.Stat(backupPath(path, n)); err != nil {
+			t.Errorf("backup %d: %v", n, err)
+		}
+	}
+	if _, err := os.Stat(backupPath(path, Backups+1)); err == nil {
+		t.Errorf("backup %d kept, want at most %d", Backups+1, Backups)
+	}
+
+	if s := Load("test"); s.Recovered != "" || s.EasyScores[0].Score != Backups+2 {
+		t.Fatalf("Load() = score %d recovered %q, want the latest save", s.EasyScores[0].Score, s.Recovered)
+	}
+
+	// Damage the save file and the newest backup
+	for _, p := range []string{path, backupPath(path, 1)} {
+		if err := os.WriteFile(p, []byte("garbage"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s := Load("test")
+	if want := filepath.Base(backupPath(path, 2)); s.Recovered != want {
+		t.Errorf("Recovered = %q, want %q", s.Recovered, want)
+	}
+	if len(s.EasyScores) == 0 || s.EasyScores[0].Score != Backups {
+		t.Errorf("recovered scores = %v, want score %d", s.EasyScores, Backups)
+	}
+}
+
+func TestLostSaveFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	path, err := SavePath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for score := 1; score <= 2; score++ {
+		s := New("test")
+		s.EasyScores = []HighScore{{Nick: "t", Score: score}}
+		if err := s.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if tmps, _ := filepath.Glob(path + ".tmp*"); len(tmps) > 0 {
+		t.Errorf("temporary files left: %v", tmps)
+	}
+
+	// In order: a deleted save file can't be truncated afterwards
+	lose := []struct {
+		name string
+		loss func() error
+	}{
+		{"truncated", func() error { return os.Truncate(path, 0) }},
+		{"deleted", func() error { return os.Remove(path) }},
+	}
+	for _, l := range lose {
+		name := l.name
+		if err := l.loss(); err != nil {
+			t.Fatal(err)
+		}
+		s := Load("test")
+		if want := filepath.Base(backupPath(path, 1)); s.Recovered != want {
+			t.Errorf("%s: Recovered = %q, want %q", name, s.Recovered, want)
+		}
+		if len(s.EasyScores) == 0 || s.EasyScores[0].Score != 1 {
+			t.Errorf("%s: recovered scores = %v, want score 1", name, s.EasyScores)
+		}
+	}
+}
+
+func TestSettingsFile(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	cache, err := paths.Cache.File("location.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(cache, []byte(`{"city":"Oslo","timezone":"Europe/Oslo"}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := New("test")
+	s.GameMode = ModeCrazy
+	s.Plaintext = true
+	if err := s.Save(); err != nil {
+		t.Fatal(err)
+	}
+	settings, err := SettingsPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir, _ := paths.Config.Path(); filepath.Dir(settings) != dir {
+		t.Errorf("settings file %s is not in the config directory %s", settings, dir)
+	}
+	data, err := os.ReadFile(settings)
+	if err != nil || !bytes.Contains(data, []byte(`"game_mode": "crazy"`)) {
+		t.Errorf("settings file = %s, %v", data, err)
+	}
+	path, _ := SavePath()
+	if data, _ := os.ReadFile(path); bytes.Contains(data, []byte("game_mode")) {
+		t.Errorf("save file keeps the settings: %s", data)
+	}
+	loaded := Load("test")
+	if loaded.GameMode != ModeCrazy || !loaded.Plaintext {
+		t.Errorf("Load() settings = %+v", loaded.Settings)
+	}
+	if loaded.LocationInfo.City != "Oslo" {
+		t.Errorf("Load() location = %+v, want the cached one", loaded.LocationInfo)
+	}
+
+	// Older versions kept the settings in the save file
+	os.Remove(settings)
+	if err := os.WriteFile(path, []byte(`{"game_mode":"noisy","easy_scores":[{"nick":"t","score":7}]}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if loaded := Load("test"); loaded.GameMode != ModeNoisy || loaded.EasyScores[0].Score != 7 {
+		t.Errorf("Load() of an old save = mode %q scores %v", loaded.GameMode, loaded.EasyScores)
+	}
+}
+
+func TestPlaintext(t *testing.T) {
+	s := New("test")
+	s.Plaintext = true
+	s.EasyScores = []HighScore{{Nick: "t", Score: 42}}
+	data, err := s.encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !json.Valid(data) {
+		t.Fatalf("plaintext save is not JSON: %q", data)
+	}
+	if bytes.Contains(data, []byte("location_info")) {
+		t.Errorf("plaintext save keeps the location: %s", data)
+	}
+	got, err := decode(data)
+	if err != nil || got.EasyScores[0].Score != 42 {
+		t.Fatalf("decode(plaintext) = %+v, %v", got, err)
+	}
+
+	s.Plaintext = false
+	if data, err = s.encode(); err != nil {
+		t.Fatal(err)
+	}
+	if json.Valid(data) {
+		t.Fatal("encrypted save is readable JSON")
+	}
+	if got, err = decode(data); err != nil || got.EasyScores[0].Score != 42 {
+		t.Fatalf("decode(encrypted) = %+v, %v", got, err)
+	}
+}
+
+func TestPassphrase(t *testing.T) {
+	given := "secret"
+	asked := 0
+	SetPassphrase(func(bool) (string, error) { asked++; return given, nil }, nil)
+	t.Cleanup(func() {
+		SetPassphrase(nil, nil)
+		passKey, passSalt = nil, nil
+	})
+
+	s := New("test")
+	s.Passphrase = true
+	s.EasyScores = []HighScore{{Nick: "t", Score: 7}}
+	data, err := s.encode()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, passMagic) {
+		t.Fatal("passphrase save has no header")
+	}
+
+	// Another machine: another machine key and no key of the session
+	machineKey := encryptionKey
+	encryptionKey = make([]byte, 32)
+	t.Cleanup(func() { encryptionKey = machineKey })
+	passKey, passSalt = nil, nil
+
+	got, err := decode(data)
+	if err != nil || got.EasyScores[0].Score != 7 {
+		t.Fatalf("decode() = %+v, %v", got, err)
+	}
+
+	passKey, passSalt = nil, nil
+	given, asked = "wrong", 0
+	if _, err := decode(data); !errors.Is(err, ErrPassphrase) {
+		t.Errorf("decode() with a wrong passphrase: %v, want ErrPassphrase", err)
+	}
+	if asked != passTries {
+		t.Errorf("asked %d times, want %d", asked, passTries)
+	}
+}
+
+func TestDeriveKey(t *testing.T) {
+	// PBKDF2-HMAC-SHA256 vectors for password "password" and salt "salt"
+	tests := []struct {
+		iterations int
+		want       string
+	}{
+		{1, "120fb6cffcf8b32c43e7225256c4f837a86548c92ccc35480805987cb70be17b"},
+		{2, "ae4d0c95af6b46d32d0adff928f06dd02a303f8ef3c251dfd6e2d85a95474c43"},
+		{4096, "c5e478d59288c841aa530db6845c4c8d962893a001ce4e11a4963873aa98134a"},
+	}
+	for _, tt := range tests {
+		if got := hex.EncodeToString(deriveKey("password", []byte("salt"), tt.iterations)); got != tt.want {
+			t.Errorf("deriveKey(%d iterations) = %s, want %s", tt.iterations, got, tt.want)
+		}
+	}
+}
@@ -10,6 +10,7 @@ var (
 	// General UI
 	SplashDot      = lipgloss.NewStyle().Foreground(lipgloss.Color("255")) // Bright white
 	SplashHaunteed = lipgloss.NewStyle().Foreground(lipgloss.Color("226")) // Bright yellow
+	SplashBonus    = lipgloss.NewStyle().Foreground(lipgloss.Color("214")) // Orange
 	SplashGhosts   = []lipgloss.Style{
 		lipgloss.NewStyle().Foreground(lipgloss.Color("9")),  // Bright red
 		lipgloss.NewStyle().Foreground(lipgloss.Color("13")), // Bright magenta
@@ -32,6 +33,76 @@ var (
 	Footer     = lipgloss.NewStyle().Foreground(lipgloss.Color("241"))
 )
 
+// UI holds the text styles of pages and menus, see NewUI.
+// Models get it with the state and keep it, so nothing global changes under them.
+type UI struct {
+	SetupTitle        lipgloss.Style
+	SetupItem         lipgloss.Style
+	SetupItemSelected lipgloss.Style
+	SetupDescription  lipgloss.Style
+	PlayHeader        lipgloss.Style
+	HighScore         lipgloss.Style
+	TopPattern        lipgloss.Style
+	Title             lipgloss.Style
+	Footer            lipgloss.Style
+	Banner            bool // Page titles in banner lettering, see render.Page
+}
+
+// NewUI returns the UI text styles of a scale. The large one has banner titles and
+// bold black and white text for low-vision players, maze sprites keep their colors.
+func NewUI(large bool) UI {
+	if !large {
+		return UI{
+			SetupTitle:        SetupTitle,
+			SetupItem:         SetupItem,
+			SetupItemSelected: SetupItemSelected,
+			SetupDescription:  SetupDescription,
+			PlayHeader:        PlayHeader,
+			HighScore:         HighScore,
+			TopPattern:        TopPattern,
+			Title:             Title,
+			Footer:            Footer,
+		}
+	}
+	bright := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("15")).Background(lipgloss.Color("0"))
+	return UI{
+		SetupTitle:        bright,
+		SetupItem:         bright,
+		SetupItemSelected: bright.Reverse(true), // Black on white
+		SetupDescription:  bright,
+		PlayHeader:        bright,
+		HighScore:         bright,
+		TopPattern:        bright,
+		Title:             bright,
+		Footer:            bright,
+		Banner:            true,
+	}
+}
+
+// haunteedColor is the RGBColor name of the haunteed in the maze, see SetLooks
+var haunteedColor = "yellow"
+
+// splashStandard keeps the default splash haunteed style to restore it
+var splashStandard = SplashHaunteed
+
+// SetLooks colors the haunteed in the maze and on the splash screen with RGBColor names bought in the vault.
+// Unknown names, empty ones included, restore the default look.
+func SetLooks(haunteed, splash string) {
+	haunteedColor = "yellow"
+	if _, ok := RGBColor[haunteed]; ok {
+		haunteedColor = haunteed
+	}
+	SplashHaunteed = splashStandard
+	if c, ok := RGBColor[splash]; ok {
+		SplashHaunteed = lipgloss.NewStyle().Foreground(lipgloss.Color(GenerateHexColor(c.R, c.G, c.B)))
+	}
+}
+
+// HaunteedColor returns the RGBColor name of the haunteed in the maze.
+func HaunteedColor() string {
+	return haunteedColor
+}
+
 type RGB struct {
 	R int
 	G int
@@ -49,6 +120,8 @@ var RGBColor = map[string]RGB{
 	"white":   {255, 255, 255},
 	"grey":    {128, 128, 128},
 	"brown":   {165, 42, 42},
+	"orange":  {255, 165, 0},
+	"pink":    {255, 105, 180},
 }
 
 // GenerateHexColor generates hexadcimal string for a given RGB values. r, g, b sould be in the range 0-255
@@ -0,0 +1,158 @@
+package style
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Vision is a simulated color vision impairment for checking colors and looks, see Simulate.
+type Vision int
+
+const (
+	NormalVision Vision = iota
+	Protanopia          // no red cones
+	Deuteranopia        // no green cones
+	LowContrast         // contrast sensitivity loss
+)
+
+// VisionNames are the vision names by Vision
+var VisionNames = []string{"normal", "protanopia", "deuteranopia", "low contrast"}
+
+// String returns the vision name.
+func (v Vision) String() string {
+	if int(v) < len(VisionNames) {
+		return VisionNames[v]
+	}
+	return "vision"
+}
+
+// Next returns the vision following v, normal follows the last one.
+func (v Vision) Next() Vision {
+	return (v + 1) % Vision(len(VisionNames))
+}
+
+// ParseVision returns the vision with the name, ok is false for unknown names.
+func ParseVision(name string) (Vision, bool) {
+	for i, n := range VisionNames {
+		if strings.EqualFold(n, name) || strings.EqualFold(strings.ReplaceAll(n, " ", "-"), name) {
+			return Vision(i), true
+		}
+	}
+	return NormalVision, false
+}
+
+// Color matrices of full dichromacy by Machado, Oliveira and Fernandes (2009),
+// applied to sRGB values directly which is close enough to compare colors.
+var visionMatrix = map[Vision][3][3]float64{
+	Protanopia: {
+		{0.152286, 1.052583, -0.204868},
+		{0.114503, 0.786281, 0.099216},
+		{-0.003882, -0.048116, 1.051998},
+	},
+	Deuteranopia: {
+		{0.367322, 0.860646, -0.227968},
+		{0.280085, 0.672501, 0.047413},
+		{-0.011820, 0.042940, 0.968881},
+	},
+}
+
+// lowContrast is how much of the contrast to mid grey is left with LowContrast
+const lowContrast = 0.4
+
+// see returns the color as seen with the vision.
+func (v Vision) see(c RGB) RGB {
+	switch v {
+	case Protanopia, Deuteranopia:
+		mx := visionMatrix[v]
+		in := [3]float64{float64(c.R), float64(c.G), float64(c.B)}
+		var out [3]int
+		for i, row := range mx {
+			out[i] = clamp(row[0]*in[0] + row[1]*in[1] + row[2]*in[2])
+		}
+		return RGB{out[0], out[1], out[2]}
+	case LowContrast:
+		fade := func(x int) int { return clamp(128 + (float64(x)-128)*lowContrast) }
+		return RGB{fade(c.R), fade(c.G), fade(c.B)}
+	}
+	return c
+}
+
+func clamp(x float64) int {
+	return int(math.Round(math.Max(0, math.Min(255, x))))
+}
+
+// ansi16 are the xterm colors of the 16 basic ANSI colors
+var ansi16 = [16]RGB{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0}, {0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0}, {92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// ansi256 returns the xterm color of a 256-color palette index.
+func ansi256(n int) RGB {
+	switch {
+	case n < 16:
+		return ansi16[n]
+	case n < 232:
+		n -= 16
+		level := func(i int) int {
+			if i == 0 {
+				return 0
+			}
+			return 55 + 40*i
+		}
+		return RGB{level(n / 36), level(n / 6 % 6), level(n % 6)}
+	default:
+		g := 8 + 10*(n-232)
+		return RGB{g, g, g}
+	}
+}
+
+var sgr = regexp.MustCompile(`\x1b\[([0-9;]*)m`)
+
+// Simulate returns the rendered frame as seen with the vision: every color of its escape sequences,
+// basic, 256-color and true color alike, is turned into the true color the vision sees.
+func Simulate(frame string, v Vision) string {
+	if v == NormalVision {
+		return frame
+	}
+	return sgr.ReplaceAllStringFunc(frame, func(seq string) string {
+		params := strings.Split(seq[2:len(seq)-1], ";")
+		out := make([]string, 0, len(params))
+		for i := 0; i < len(params); i++ {
+			p, err := strconv.Atoi(params[i])
+			if err != nil {
+				out = append(out, params[i])
+				continue
+			}
+			layer, c, ok := 0, RGB{}, true
+			switch {
+			case p >= 30 && p <= 37, p >= 40 && p <= 47:
+				layer, c = p/10*10+8, ansi16[p%10]
+			case p >= 90 && p <= 97, p >= 100 && p <= 107:
+				layer, c = (p-60)/10*10+8, ansi16[p%10+8]
+			case (p == 38 || p == 48) && i+2 < len(params) && params[i+1] == "5":
+				n, _ := strconv.Atoi(params[i+2])
+				layer, c = p, ansi256(min(max(n, 0), 255))
+				i += 2
+			case (p == 38 || p == 48) && i+4 < len(params) && params[i+1] == "2":
+				r, _ := strconv.Atoi(params[i+2])
+				g, _ := strconv.Atoi(params[i+3])
+				b, _ := strconv.Atoi(params[i+4])
+				layer, c = p, RGB{r, g, b}
+				i += 4
+			default:
+				ok = false
+			}
+			if !ok {
+				out = append(out, params[i])
+				continue
+			}
+			c = v.see(c)
+			out = append(out, fmt.Sprintf("%d;2;%d;%d;%d", layer, c.R, c.G, c.B))
+		}
+		return "\x1b[" + strings.Join(out, ";") + "m"
+	})
+}
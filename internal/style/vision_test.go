@@ -0,0 +1,36 @@
+package style
+
+import "testing"
+
+func TestSimulate(t *testing.T) {
+	frame := "\x1b[1;38;2;255;0;0mA\x1b[0m \x1b[38;5;46mB\x1b[0m \x1b[91;44mC\x1b[0m"
+	if got := Simulate(frame, NormalVision); got != frame {
+		t.Errorf("Simulate(normal) = %q, want the frame untouched", got)
+	}
+	tests := []struct {
+		vision Vision
+		want   string
+	}{
+		{Protanopia, "\x1b[1;38;2;39;29;0mA\x1b[0m \x1b[38;2;255;201;0mB\x1b[0m \x1b[38;2;39;29;0;48;2;0;24;250mC\x1b[0m"},
+		{LowContrast, "\x1b[1;38;2;179;77;77mA\x1b[0m \x1b[38;2;77;179;77mB\x1b[0m \x1b[38;2;179;77;77;48;2;77;77;172mC\x1b[0m"},
+	}
+	for _, tt := range tests {
+		if got := Simulate(frame, tt.vision); got != tt.want {
+			t.Errorf("Simulate(%s) = %q, want %q", tt.vision, got, tt.want)
+		}
+	}
+}
+
+func TestParseVision(t *testing.T) {
+	for i, name := range VisionNames {
+		if v, ok := ParseVision(name); !ok || v != Vision(i) {
+			t.Errorf("ParseVision(%q) = %v, %v", name, v, ok)
+		}
+	}
+	if v, ok := ParseVision("low-contrast"); !ok || v != LowContrast {
+		t.Errorf("ParseVision(low-contrast) = %v, %v", v, ok)
+	}
+	if _, ok := ParseVision("x-ray"); ok {
+		t.Error("ParseVision(x-ray) is ok")
+	}
+}
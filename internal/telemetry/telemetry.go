@@ -0,0 +1,130 @@
+// Package telemetry keeps strictly opt-in, anonymous gameplay statistics in a local file.
+// Nothing is ever sent anywhere: the player exports the stats and shares them by hand.
+package telemetry
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	"github.com/vinser/haunteed/internal/paths"
+)
+
+// Stats holds aggregate gameplay statistics. A nil *Stats means telemetry is off, all methods are nil-safe.
+type Stats struct {
+	Since      time.Time        `json:"since"`       // When the collection started
+	Runs       map[string]int   `json:"runs"`        // Finished runs by game mode
+	RunSeconds map[string]int64 `json:"run_seconds"` // Total run time by game mode
+	Crashes    int              `json:"crashes"`     // Sessions which exited without saying goodbye
+	Running    bool             `json:"running"`     // A session is in progress
+}
+
+// New returns empty stats.
+func New() *Stats {
+	return &Stats{
+		Since:      time.Now(),
+		Runs:       make(map[string]int),
+		RunSeconds: make(map[string]int64),
+	}
+}
+
+// Load reads stats from disk, missing or corrupted stats start over.
+func Load() *Stats {
+	path, err := getPath()
+	if err != nil {
+		return New()
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return New()
+	}
+	s := New()
+	if err := json.Unmarshal(data, s); err != nil {
+		return New()
+	}
+	if s.Runs == nil {
+		s.Runs = make(map[string]int)
+	}
+	if s.RunSeconds == nil {
+		s.RunSeconds = make(map[string]int64)
+	}
+	return s
+}
+
+// Save writes stats to disk as plain JSON, so the player can always see what is collected.
+func (s *Stats) Save() error {
+	if s == nil {
+		return nil
+	}
+	path, err := getPath()
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
+
+// Begin marks a session start. A previous session still marked as running has crashed.
+func (s *Stats) Begin() {
+	if s == nil {
+		return
+	}
+	if s.Running {
+		s.Crashes++
+	}
+	s.Running = true
+}
+
+// End marks a clean session end.
+func (s *Stats) End() {
+	if s == nil {
+		return
+	}
+	s.Running = false
+}
+
+// RecordRun adds a finished run of the given game mode and duration.
+func (s *Stats) RecordRun(mode string, d time.Duration) {
+	if s == nil {
+		return
+	}
+	s.Runs[mode]++
+	s.RunSeconds[mode] += int64(d.Seconds())
+}
+
+// AverageRun returns the average run length for the game mode.
+func (s *Stats) AverageRun(mode string) time.Duration {
+	if s == nil || s.Runs[mode] == 0 {
+		return 0
+	}
+	return time.Duration(s.RunSeconds[mode]/int64(s.Runs[mode])) * time.Second
+}
+
+// Export writes the stats with per mode averages as JSON ready to be shared.
+func (s *Stats) Export(w io.Writer) error {
+	if s == nil {
+		s = New()
+	}
+	averages := make(map[string]int64, len(s.Runs))
+	for mode := range s.Runs {
+		averages[mode] = int64(s.AverageRun(mode).Seconds())
+	}
+	report := struct {
+		Since          time.Time        `json:"since"`
+		Runs           map[string]int   `json:"runs"`
+		AverageSeconds map[string]int64 `json:"average_run_seconds"`
+		Crashes        int              `json:"crashes"`
+	}{s.Since, s.Runs, averages, s.Crashes}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// getPath returns the path to the stats file inside the data directory.
+func getPath() (string, error) {
+	return paths.Data.File("telemetry.json")
+}
@@ -0,0 +1,57 @@
+package telemetry
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestBeginCountsCrashes(t *testing.T) {
+	s := New()
+	s.Begin()
+	s.End()
+	s.Begin()
+	s.Begin() // previous session never ended
+	if s.Crashes != 1 {
+		t.Errorf("Crashes = %d, want 1", s.Crashes)
+	}
+}
+
+func TestAverageRun(t *testing.T) {
+	s := New()
+	s.RecordRun("easy", 60*time.Second)
+	s.RecordRun("easy", 120*time.Second)
+	s.RecordRun("crazy", 30*time.Second)
+	if got := s.AverageRun("easy"); got != 90*time.Second {
+		t.Errorf("AverageRun(easy) = %v, want 90s", got)
+	}
+	if got := s.AverageRun("noisy"); got != 0 {
+		t.Errorf("AverageRun(noisy) = %v, want 0", got)
+	}
+
+	var buf bytes.Buffer
+	if err := s.Export(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var report struct {
+		Runs           map[string]int   `json:"runs"`
+		AverageSeconds map[string]int64 `json:"average_run_seconds"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatal(err)
+	}
+	if report.Runs["easy"] != 2 || report.AverageSeconds["crazy"] != 30 {
+		t.Errorf("unexpected report: %s", buf.String())
+	}
+}
+
+func TestNilStats(t *testing.T) {
+	var s *Stats
+	s.Begin()
+	s.RecordRun("easy", time.Second)
+	s.End()
+	if err := s.Save(); err != nil {
+		t.Errorf("Save() on nil stats = %v", err)
+	}
+}
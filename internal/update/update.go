@@ -0,0 +1,231 @@
+// Package update checks GitHub releases for a newer haunteed and swaps the running binary.
+package update
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	releasesURL   = "https://api.github.com/repos/vinser/haunteed/releases/latest"
+	checksumsName = "checksums.txt"
+	httpTimeout   = 5 * time.Second
+	// CheckPeriod is how often the releases API is queried
+	CheckPeriod = 24 * time.Hour
+)
+
+// offline disables the releases API, see SetOffline
+var offline bool
+
+// SetOffline disables update checks and downloads.
+func SetOffline(disabled bool) {
+	offline = disabled
+}
+
+// Release describes the latest published release.
+type Release struct {
+	Version string            // Version without the leading v
+	Assets  map[string]string // Asset name → download URL
+}
+
+type releaseResponse struct {
+	TagName string `json:"tag_name"`
+	Assets  []struct {
+		Name string `json:"name"`
+		URL  string `json:"browser_download_url"`
+	} `json:"assets"`
+}
+
+// Latest queries the releases API for the latest release.
+func Latest() (*Release, error) {
+	if offline {
+		return nil, errors.New("update: network disabled")
+	}
+	client := &http.Client{Timeout: httpTimeout}
+	resp, err := client.Get(releasesURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("update: non-200 response from API")
+	}
+	var r releaseResponse
+	if err := json.NewDecoder(resp.Body).Decode(&r); err != nil {
+		return nil, err
+	}
+	release := &Release{
+		Version: strings.TrimPrefix(r.TagName, "v"),
+		Assets:  make(map[string]string),
+	}
+	for _, a := range r.Assets {
+		release.Assets[a.Name] = a.URL
+	}
+	return release, nil
+}
+
+// Newer reports whether the latest version is newer than the current one.
+// Development builds are never nagged.
+func Newer(latest, current string) bool {
+	l, ok := parseVersion(latest)
+	if !ok {
+		return false
+	}
+	c, ok := parseVersion(current)
+	if !ok {
+		return false
+	}
+	for i := range l {
+		if l[i] != c[i] {
+			return l[i] > c[i]
+		}
+	}
+	return false
+}
+
+func parseVersion(v string) ([3]int, bool) {
+	var parts [3]int
+	fields := strings.Split(strings.TrimPrefix(v, "v"), ".")
+	if len(fields) == 0 || len(fields) > 3 {
+		return parts, false
+	}
+	for i, f := range fields {
+		n, err := strconv.Atoi(f)
+		if err != nil {
+			return parts, false
+		}
+		parts[i] = n
+	}
+	return parts, true
+}
+
+// AssetName returns the release binary name for the platform as built by the Makefile.
+func AssetName(goos, goarch string) string {
+	name := fmt.Sprintf("haunteed-%s-%s", goos, goarch)
+	if goos == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Command returns the command that updates this install, snaps are refreshed by snapd.
+func Command() string {
+	if os.Getenv("SNAP") != "" {
+		return "snap refresh haunteed"
+	}
+	return "haunteed update"
+}
+
+// Run downloads the latest release binary, verifies its checksum and replaces the running executable.
+// Progress is written to w.
+func Run(current string, w io.Writer) error {
+	if os.Getenv("SNAP") != "" {
+		return fmt.Errorf("update: installed as a snap, use '%s' instead", Command())
+	}
+	release, err := Latest()
+	if err != nil {
+		return err
+	}
+	if !Newer(release.Version, current) {
+		fmt.Fprintf(w, "Haunteed %s is up to date.\n", current)
+		return nil
+	}
+	name := AssetName(runtime.GOOS, runtime.GOARCH)
+	binURL, ok := release.Assets[name]
+	if !ok {
+		return fmt.Errorf("update: no %s in release %s", name, release.Version)
+	}
+	sumsURL, ok := release.Assets[checksumsName]
+	if !ok {
+		return fmt.Errorf("update: no %s in release %s", checksumsName, release.Version)
+	}
+
+	fmt.Fprintf(w, "Downloading haunteed %s...\n", release.Version)
+	sums, err := download(sumsURL)
+	if err != nil {
+		return err
+	}
+	want, err := checksumFor(sums, name)
+	if err != nil {
+		return err
+	}
+	bin, err := download(binURL)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(bin)
+	if got := hex.EncodeToString(sum[:]); got != want {
+		return fmt.Errorf("update: checksum mismatch for %s", name)
+	}
+
+	if err := replaceExecutable(bin); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "Updated to haunteed %s.\n", release.Version)
+	return nil
+}
+
+func download(url string) ([]byte, error) {
+	client := &http.Client{Timeout: time.Minute}
+	resp, err := client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("update: non-200 response downloading %s", url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// checksumFor finds the sha256 of the named file in sha256sum output.
+func checksumFor(sums []byte, name string) (string, error) {
+	scanner := bufio.NewScanner(strings.NewReader(string(sums)))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == name {
+			return strings.ToLower(fields[0]), nil
+		}
+	}
+	return "", fmt.Errorf("update: no checksum for %s", name)
+}
+
+// replaceExecutable writes the new binary next to the running one and swaps them.
+// The running binary is moved aside first because Windows can't overwrite it.
+func replaceExecutable(bin []byte) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return err
+	}
+	newPath := exe + ".new"
+	oldPath := exe + ".old"
+	if err := os.WriteFile(newPath, bin, 0755); err != nil {
+		return err
+	}
+	os.Remove(oldPath)
+	if err := os.Rename(exe, oldPath); err != nil {
+		os.Remove(newPath)
+		return err
+	}
+	if err := os.Rename(newPath, exe); err != nil {
+		os.Rename(oldPath, exe) // Roll back
+		return err
+	}
+	os.Remove(oldPath) // Fails on Windows while running, cleaned up on the next update
+	return nil
+}
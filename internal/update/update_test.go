@@ -0,0 +1,47 @@
+package update
+
+import "testing"
+
+func TestNewer(t *testing.T) {
+	tests := []struct {
+		latest, current string
+		want            bool
+	}{
+		{"1.2.0", "1.1.0", true},
+		{"v1.1.1", "1.1.0", true},
+		{"1.1.0", "1.1.0", false},
+		{"1.0.9", "1.1.0", false},
+		{"2", "1.9.9", true},
+		{"1.2.0", "dev", false},
+		{"garbage", "1.1.0", false},
+	}
+	for _, tt := range tests {
+		if got := Newer(tt.latest, tt.current); got != tt.want {
+			t.Errorf("Newer(%q, %q) = %v, want %v", tt.latest, tt.current, got, tt.want)
+		}
+	}
+}
+
+func TestChecksumFor(t *testing.T) {
+	sums := []byte("ABC123  haunteed-linux-amd64\ndef456 *haunteed-windows-amd64.exe\n")
+	if got, err := checksumFor(sums, "haunteed-linux-amd64"); err != nil || got != "abc123" {
+		t.Errorf("checksumFor(linux) = %q, %v", got, err)
+	}
+	if got, err := checksumFor(sums, AssetName("windows", "amd64")); err != nil || got != "def456" {
+		t.Errorf("checksumFor(windows) = %q, %v", got, err)
+	}
+	if _, err := checksumFor(sums, "haunteed-darwin-arm64"); err == nil {
+		t.Error("expected error for missing checksum")
+	}
+}
+
+func TestCommand(t *testing.T) {
+	t.Setenv("SNAP", "")
+	if got := Command(); got != "haunteed update" {
+		t.Errorf("Command() = %q, want %q", got, "haunteed update")
+	}
+	t.Setenv("SNAP", "/snap/haunteed/x1")
+	if got := Command(); got != "snap refresh haunteed" {
+		t.Errorf("Command() in a snap = %q, want %q", got, "snap refresh haunteed")
+	}
+}